@@ -1,12 +1,21 @@
 package main
 
 import (
+	"PishingSimulator_SecurityProject/internal/auth"
+	"PishingSimulator_SecurityProject/internal/curriculum"
 	"PishingSimulator_SecurityProject/internal/handler"
+	"PishingSimulator_SecurityProject/internal/llm"
 	"PishingSimulator_SecurityProject/internal/middleware"
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/oidc"
+	"PishingSimulator_SecurityProject/internal/scenariopack"
 	"PishingSimulator_SecurityProject/internal/storage"
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -41,7 +50,69 @@ func init() {
 // @name Authorization
 // @description Bearer 토큰 형식, Bearer {token}
 func main() {
+	// JWT 서명 키 로드 (JWT_KEY_DIR, 운영 모드에서 키가 없으면 시작하지 않음) 및 키 교체 감시
+	if err := auth.Init(); err != nil {
+		log.Fatalf("main(): Failed to initialize authentication: %v", err)
+	}
+	go auth.WatchKeys(context.Background(), 30*time.Second)
+
 	storage.InitDB()
+
+	// ADMIN_USERNAMES(쉼표 구분)에 등록된 기존 사용자를 관리자로 지정
+	var adminUsernames []string
+	for _, name := range strings.Split(os.Getenv("ADMIN_USERNAMES"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			adminUsernames = append(adminUsernames, name)
+		}
+	}
+	if err := storage.PromoteAdmins(adminUsernames); err != nil {
+		log.Fatalf("main(): Failed to promote admin users: %v", err)
+	}
+
+	// 시나리오 팩 로드 및 변경 감시 (파일 오류는 파일별로 기록하고 나머지는 정상 로드)
+	scenarioDir := os.Getenv("SCENARIO_DIR")
+	if scenarioDir == "" {
+		scenarioDir = "scenarios"
+	}
+	scenarioPacks := scenariopack.NewLoader(scenarioDir)
+	for _, err := range scenarioPacks.Load() {
+		log.Printf("main(): Invalid scenario pack: %v", err)
+	}
+	go scenarioPacks.Watch(context.Background(), 5*time.Second)
+
+	// 커리큘럼 로드 (CURRICULUM_FILE, 파일이 없으면 기본 커리큘럼)
+	curriculumFile := os.Getenv("CURRICULUM_FILE")
+	if curriculumFile == "" {
+		curriculumFile = "curriculum.yaml"
+	}
+	if c, err := curriculum.Load(curriculumFile); err == nil {
+		curriculum.SetCurrent(c)
+		log.Printf("main(): Curriculum loaded from %s (%d modules)", curriculumFile, len(c.Modules))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("main(): Invalid curriculum file %s: %v", curriculumFile, err)
+	}
+	handler.SetPrerequisiteEnforcement(os.Getenv("ENFORCE_PREREQUISITES") == "true")
+	handler.SetTokenQueryCompatibility(os.Getenv("WS_TOKEN_QUERY_COMPAT") == "true")
+
+	// SSO 공급자 로드 (OIDC_CONFIG_FILE, 파일이 없으면 SSO 비활성화)
+	oidcConfigFile := os.Getenv("OIDC_CONFIG_FILE")
+	if oidcConfigFile == "" {
+		oidcConfigFile = "oidc.yaml"
+	}
+	if providers, err := oidc.Load(oidcConfigFile); err == nil {
+		handler.SetOIDCProviders(providers)
+		log.Printf("main(): SSO providers loaded from %s (%d providers)", oidcConfigFile, len(providers))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("main(): Invalid SSO config file %s: %v", oidcConfigFile, err)
+	}
+
+	// 대화 엔진 선택 (LLM_ENGINE: http, openai, scripted)
+	engine, err := llm.NewEngineFromEnv()
+	if err != nil {
+		log.Fatalf("main(): Failed to create conversation engine: %v", err)
+	}
+	handler.SetConversationEngine(engine)
+
 	router := gin.Default()
 
 	// CORS 설정
@@ -61,15 +132,94 @@ func main() {
 	router.Use(rateLimitMiddleware)
 
 	// 라우트 설정
-	router.POST("/signup", rateLimitMiddleware /*middleware.InviteCodeMiddleware(), */, handler.Signup)
+	// SIGNUP_INVITE_REQUIRED=true이면 초대 코드(X-Invite-Code) 없이 가입 불가
+	inviteRequired := os.Getenv("SIGNUP_INVITE_REQUIRED") == "true"
+	router.POST("/signup", rateLimitMiddleware, middleware.InviteCodeMiddleware(inviteRequired), handler.Signup)
 	router.POST("/login", rateLimitMiddleware, handler.Login)
+	router.POST("/login/mfa", rateLimitMiddleware, handler.LoginMFA)
+	router.POST("/token/refresh", rateLimitMiddleware, handler.RefreshToken)
+	router.POST("/logout", handler.Logout)
+	router.POST("/password/reset", rateLimitMiddleware, handler.ResetPassword)
+	router.GET("/.well-known/jwks.json", handler.GetJWKS)
+	router.GET("/auth/oidc/providers", handler.ListSSOProviders)
+	router.GET("/auth/oidc/:provider/login", rateLimitMiddleware, handler.StartSSOLogin)
+	router.GET("/auth/oidc/:provider/callback", rateLimitMiddleware, handler.SSOCallback)
+	router.POST("/auth/oidc/token", rateLimitMiddleware, handler.ExchangeSSOTicket)
+	router.GET("/api/scenarios", middleware.OptionalAuthMiddleware(), handler.ListScenarios)
+	// 녹음 재생은 Authorization 헤더 또는 1회용 티켓(?ticket=)으로 인증
+	router.GET("/api/history/:id/audio", middleware.TicketAuthMiddleware(models.TicketPurposeRecordAudio, "id"), middleware.RequireMFA(), handler.GetRecordAudio)
 
 	// 보호된 라우트 그룹
-	protected := router.Group("/api").Use(middleware.AuthMiddleware())
+	protected := router.Group("/api").Use(middleware.AuthMiddleware(), middleware.RequireMFA())
 	{
-		protected.GET("/profile", handler.Profile)
+		protected.GET("/profile", handler.GetProfile)
+		protected.PATCH("/profile", handler.UpdateProfile)
+		protected.POST("/password", handler.ChangePassword)
 		protected.GET("/history", handler.GetCallHistory)
 		protected.GET("/history/audio/:filename", handler.StreamAudio)
+		protected.GET("/history/:id/transcript", handler.GetTranscript)
+		protected.GET("/history/:id/report", handler.GetReport)
+		protected.POST("/history/:id/audio-url", handler.CreateRecordAudioURL)
+		protected.POST("/ws-ticket", handler.CreateWSTicket)
+		protected.GET("/progress", handler.GetProgress)
+		protected.GET("/invitations", handler.ListMyInvitations)
+		protected.POST("/invitations/:id/accept", handler.AcceptInvitation)
+	}
+
+	// 2단계 인증 등록, 해제 (조직 정책상 필수인 사용자도 등록할 수 있도록 RequireMFA 미적용)
+	mfa := router.Group("/api/mfa").Use(middleware.AuthMiddleware())
+	{
+		mfa.GET("", handler.GetMFAStatus)
+		mfa.POST("/enroll", handler.EnrollMFA)
+		mfa.POST("/verify", handler.VerifyMFA)
+		mfa.POST("/disable", rateLimitMiddleware, handler.DisableMFA)
+	}
+
+	// 관리자 라우트 그룹
+	admin := router.Group("/api/admin").Use(middleware.AuthMiddleware(), middleware.RequireRole(models.RoleAdmin), middleware.RequireMFA())
+	{
+		admin.GET("/scenarios", handler.AdminListScenarios)
+		admin.POST("/scenarios", handler.CreateScenario)
+		admin.PUT("/scenarios/:key", handler.UpdateScenario)
+		admin.DELETE("/scenarios/:key", handler.DisableScenario)
+		admin.GET("/users", handler.AdminListUsers)
+		admin.PUT("/users/:id/role", handler.UpdateUserRole)
+		admin.PUT("/users/:id/group", handler.UpdateUserGroup)
+		admin.PUT("/users/:id/organization", handler.UpdateUserOrganization)
+		admin.DELETE("/users/:id/mfa", handler.AdminResetMFA)
+		admin.DELETE("/users/:id/lockout", handler.UnlockUserLogin)
+		admin.POST("/users/:id/password-reset", handler.CreatePasswordResetToken)
+		admin.GET("/groups", handler.AdminListGroups)
+		admin.POST("/groups", handler.CreateGroup)
+		admin.GET("/organizations", handler.AdminListOrganizations)
+		admin.POST("/organizations", handler.CreateOrganization)
+		admin.PUT("/organizations/:id/scenarios", handler.AdminUpdateOrganizationScenarios)
+		admin.PUT("/organizations/:id/mfa-policy", handler.AdminUpdateOrganizationMFAPolicy)
+		admin.GET("/invite-codes", handler.AdminListInviteCodes)
+		admin.POST("/invite-codes", handler.CreateInviteCode)
+		admin.DELETE("/invite-codes/:id", handler.RevokeInviteCode)
+		admin.GET("/invite-codes/:id/redemptions", handler.GetInviteCodeRedemptions)
+	}
+
+	// 조직 관리자 라우트 그룹
+	org := router.Group("/api/org").Use(middleware.AuthMiddleware(), middleware.RequireRole(models.RoleOrgAdmin), middleware.RequireMFA())
+	{
+		org.GET("", handler.GetOrganization)
+		org.GET("/members", handler.ListOrganizationMembers)
+		org.PUT("/members/:id/group", handler.UpdateOrganizationMemberGroup)
+		org.GET("/groups", handler.ListOrganizationGroups)
+		org.POST("/groups", handler.CreateOrganizationGroup)
+		org.PUT("/scenarios", handler.UpdateOrganizationScenarios)
+		org.PUT("/mfa-policy", handler.UpdateOrganizationMFAPolicy)
+		org.GET("/invitations", handler.ListOrganizationInvitations)
+		org.POST("/invitations", handler.CreateInvitation)
+	}
+
+	// 강사 라우트 그룹 (조직 관리자, 관리자 포함)
+	trainer := router.Group("/api/trainer").Use(middleware.AuthMiddleware(), middleware.RequireRole(models.RoleTrainer, models.RoleOrgAdmin, models.RoleAdmin), middleware.RequireMFA())
+	{
+		trainer.GET("/trainees", handler.ListTrainees)
+		trainer.GET("/trainees/:id/history", handler.GetTraineeHistory)
 	}
 
 	// WebSocket 핸들러
@@ -0,0 +1,242 @@
+/**
+* Name: 			main.go
+* Description: 		로컬 개발, 테스트용 OIDC 공급자(IdP) 모의 서버
+* Workflow: 		/authorize에서 사용자명, 그룹, 2단계 인증 여부를 입력받아 인증 코드 발급 → /token에서 client_id, redirect_uri, PKCE code_verifier 확인 후 RS256 ID 토큰 발급 → /jwks로 서명 공개 키 제공
+ */
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	mockKeyID   = "mock-key"
+	mockCodeTTL = time.Minute
+)
+
+// 발급한 인증 코드에 묶인 요청 정보
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	username      string
+	groups        []string
+	mfa           bool
+	expiresAt     time.Time
+}
+
+type mockProvider struct {
+	issuer string
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+var authorizeForm = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html><body>
+<h3>Mock OIDC login ({{.ClientID}})</h3>
+<form method="post" action="/authorize">
+{{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}<p>Username <input name="username" value="alice"></p>
+<p>Groups (comma separated) <input name="groups" value="trainees"></p>
+<p><label><input type="checkbox" name="mfa" value="true"> MFA</label></p>
+<p><button type="submit">Login</button></p>
+</form>
+</body></html>`))
+
+func main() {
+	addr := os.Getenv("MOCK_OIDC_ADDR")
+	if addr == "" {
+		addr = ":9000"
+	}
+	issuer := strings.TrimSuffix(os.Getenv("MOCK_OIDC_ISSUER"), "/")
+	if issuer == "" {
+		issuer = "http://localhost:9000"
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("main(): Failed to generate signing key: %v", err)
+	}
+	p := &mockProvider{issuer: issuer, key: key, codes: map[string]authorization{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+	log.Printf("main(): Mock OIDC provider %s listening on %s", issuer, addr)
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// GET: 로그인 폼 표시, POST: 입력한 사용자로 인증 코드 발급 후 redirect_uri로 이동
+// 자동화 테스트에서는 GET 요청에 username, groups, mfa 파라미터를 붙이면 폼 없이 바로 코드 발급
+func (p *mockProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("response_type") != "code" || r.Form.Get("client_id") == "" || r.Form.Get("redirect_uri") == "" {
+		http.Error(w, "response_type=code, client_id and redirect_uri are required", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("code_challenge") == "" || r.Form.Get("code_challenge_method") != "S256" {
+		http.Error(w, "PKCE (S256) is required", http.StatusBadRequest)
+		return
+	}
+	if r.Method == http.MethodGet && r.Form.Get("username") == "" {
+		params := map[string]string{}
+		for _, name := range []string{"response_type", "client_id", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+			params[name] = r.Form.Get(name)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		authorizeForm.Execute(w, map[string]any{"ClientID": r.Form.Get("client_id"), "Params": params})
+		return
+	}
+
+	var groups []string
+	for _, group := range strings.Split(r.Form.Get("groups"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	code := randomString()
+	p.mu.Lock()
+	p.codes[code] = authorization{
+		clientID:      r.Form.Get("client_id"),
+		redirectURI:   r.Form.Get("redirect_uri"),
+		codeChallenge: r.Form.Get("code_challenge"),
+		nonce:         r.Form.Get("nonce"),
+		username:      r.Form.Get("username"),
+		groups:        groups,
+		mfa:           r.Form.Get("mfa") == "true",
+		expiresAt:     time.Now().Add(mockCodeTTL),
+	}
+	p.mu.Unlock()
+
+	target, err := url.Parse(r.Form.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	query := target.Query()
+	query.Set("code", code)
+	query.Set("state", r.Form.Get("state"))
+	target.RawQuery = query.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// 인증 코드 교환 (1회용), client_id, redirect_uri, code_verifier가 인증 요청과 일치해야 함
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+	clientID := r.PostForm.Get("client_id")
+	if user, _, ok := r.BasicAuth(); ok {
+		clientID = user
+	}
+
+	p.mu.Lock()
+	auth, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || time.Now().After(auth.expiresAt) ||
+		auth.clientID != clientID ||
+		auth.redirectURI != r.PostForm.Get("redirect_uri") ||
+		auth.codeChallenge != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	amr := []string{"pwd"}
+	if auth.mfa {
+		amr = append(amr, "mfa")
+	}
+	claims := jwt.MapClaims{
+		"iss":                p.issuer,
+		"sub":                "mock|" + auth.username,
+		"aud":                auth.clientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(5 * time.Minute).Unix(),
+		"preferred_username": auth.username,
+		"name":               auth.username,
+		"groups":             auth.groups,
+		"amr":                amr,
+	}
+	if auth.nonce != "" {
+		claims["nonce"] = auth.nonce
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = mockKeyID
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func (p *mockProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": mockKeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
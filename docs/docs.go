@@ -15,88 +15,130 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/history": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "액세스 토큰 서명을 검증할 수 있는 공개 키 목록(RFC 7517)을 반환합니다. 토큰 헤더의 ` + "`" + `kid` + "`" + `로 키를 찾아 검증합니다.\n키 교체 중에는 이전 키도 함께 포함되므로 주기적으로 다시 조회하세요.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "토큰 검증용 공개 키 (JWKS)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_auth.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/groups": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "사용자의 과거 시뮬레이션(통화/채팅) 기록 목록을 최신순으로 반환합니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "API (Protected)"
+                    "Admin"
+                ],
+                "summary": "그룹 목록 조회 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "조직 ID 필터",
+                        "name": "org_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "사용자 통화 기록 조회",
                 "responses": {
                     "200": {
-                        "description": "history: [기록 배열]",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.HistoryResponse"
+                            "$ref": "#/definitions/internal_handler.GroupListResponse"
                         }
                     },
-                    "401": {
-                        "description": "인증 실패",
+                    "400": {
+                        "description": "잘못된 필터",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "DB 조회 실패 등 서버 오류",
+                        "description": "DB 오류",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/history/audio/{filename}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "특정 통화 기록의 오디오 파일(.mp3)을 재생합니다.\n\u003cbr\u003e **[인증]** Header에 ` + "`" + `Authorization: Bearer ...` + "`" + `를 넣거나, URL 파라미터 ` + "`" + `?token=...` + "`" + `을 사용하세요.",
+                "description": "훈련 그룹(팀, 기수)을 생성합니다. 강사는 같은 그룹 훈련생의 기록을 조회할 수 있습니다. ` + "`" + `org_id` + "`" + `로 그룹이 속할 조직을 지정합니다.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "audio/mpeg"
+                    "application/json"
                 ],
                 "tags": [
-                    "API (Protected)"
+                    "Admin"
                 ],
-                "summary": "녹음된 오디오 파일 스트리밍",
+                "summary": "그룹 생성 (관리자)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "오디오 파일명 (예: session_uuid.mp3)",
-                        "name": "filename",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "JWT 토큰 (Header 사용 불가 시)",
-                        "name": "token",
-                        "in": "query"
+                        "description": "그룹 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.CreateGroupRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "오디오 바이너리 데이터",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Group"
                         }
                     },
-                    "401": {
-                        "description": "인증 실패",
+                    "400": {
+                        "description": "잘못된 요청 또는 존재하지 않는 조직",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "해당 파일을 찾을 수 없음",
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "같은 조직에 이미 존재하는 그룹 이름",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
@@ -104,48 +146,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/profile": {
+        "/api/admin/invite-codes": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "인증된 사용자의 프로필 정보를 조회합니다. (JWT 필요)",
+                "description": "초대 코드를 최신순으로 반환합니다. 코드 원문은 저장하지 않으므로 앞 4자리(` + "`" + `code_prefix` + "`" + `)만 표시됩니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "API (Protected)"
+                    "Admin"
+                ],
+                "summary": "초대 코드 목록 조회 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "조직 ID 필터",
+                        "name": "org_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "프로필 조회 (Profile)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                " username": {
-                                    "type": "string"
-                                },
-                                "message": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/internal_handler.InviteCodeListResponse"
                         }
                     },
-                    "401": {
-                        "description": "인증 토큰 누락 또는 만료",
+                    "400": {
+                        "description": "잘못된 필터",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/login": {
+            },
             "post": {
-                "description": "사용자명과 비밀번호로 로그인하고 JWT 토큰을 발급받습니다.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "회원가입 초대 코드를 생성합니다. 가입 시 ` + "`" + `X-Invite-Code` + "`" + ` 헤더로 코드를 보내면 지정한 조직, 역할, 그룹이 적용됩니다.\n코드 원문(` + "`" + `code` + "`" + `)은 이 응답에서만 확인할 수 있습니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -153,41 +210,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "로그인 (Login)",
+                "summary": "초대 코드 생성 (관리자)",
                 "parameters": [
                     {
-                        "description": "로그인 요청 정보",
+                        "description": "초대 코드 설정",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.LoginRequest"
+                            "$ref": "#/definitions/internal_handler.CreateInviteCodeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_handler.LoginSuccessResponse"
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.InviteCode"
                         }
                     },
                     "400": {
-                        "description": "잘못된 요청",
+                        "description": "잘못된 요청, 역할, 사용 횟수, 만료 시각 또는 존재하지 않는 조직/그룹",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "인증 실패 (자격 증명 오류)",
+                    "403": {
+                        "description": "관리자 권한 없음",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "서버 내부 오류",
+                        "description": "DB 오류",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
@@ -195,28 +252,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/signup": {
-            "post": {
-                "description": "새로운 사용자 계정을 생성합니다.",
-                "consumes": [
-                    "application/json"
+        "/api/admin/invite-codes/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "초대 코드를 더 이상 사용할 수 없도록 회수합니다. 사용 기록 보존을 위해 실제로 삭제하지는 않습니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "회원가입 (Signup)",
+                "summary": "초대 코드 회수 (관리자)",
                 "parameters": [
                     {
-                        "description": "회원가입 요청 정보",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_handler.SignupRequest"
-                        }
+                        "type": "integer",
+                        "description": "초대 코드 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -227,13 +284,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "잘못된 초대 코드 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "초대 코드 없음 또는 이미 회수됨",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "DB 오류",
                         "schema": {
                             "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
@@ -241,145 +310,4448 @@ const docTemplate = `{
                 }
             }
         },
-        "/ws/simulation": {
+        "/api/admin/invite-codes/{id}/redemptions": {
             "get": {
-                "description": "지정된 시나리오와 모드로 실시간 시뮬레이션을 위한 WebSocket 연결을 시작합니다.\n\u003cbr\u003e\n**[중요]** 이것은 표준 HTTP API가 아닙니다. ` + "`" + `ws://` + "`" + ` 또는 ` + "`" + `wss://` + "`" + ` 스킴을 사용해야 합니다.\n**인증:** WebSocket 연결 시에는 HTTP Header를 사용할 수 없으므로, **Query Parameter(` + "`" + `token` + "`" + `)**로 JWT를 전달해야 합니다.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "초대 코드로 가입한 사용자, 가입 시각, 요청 IP를 최신순으로 반환합니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Simulation (WebSocket)"
+                    "Admin"
                 ],
-                "summary": "보이스피싱 시뮬레이션 시작 (WebSocket)",
+                "summary": "초대 코드 사용 기록 조회 (관리자)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer 토큰 (접두사 없이 토큰 값만 입력)",
-                        "name": "token",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "시나리오 키 (예: loan_scam, institution_impersonation)",
-                        "name": "scenario",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "모드 선택 (text: 텍스트 채팅, voice: 실시간 음성 통화)",
-                        "name": "mode",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "초대 코드 ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "Switching Protocols",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_handler.InviteCodeRedemptionListResponse"
                         }
                     },
                     "400": {
-                        "description": "잘못된 파라미터",
+                        "description": "잘못된 초대 코드 ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "인증 실패",
+                    "403": {
+                        "description": "관리자 권한 없음",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "초대 코드 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "PishingSimulator_SecurityProject_internal_models.Record": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "file_path": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "scenario": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "PishingSimulator_SecurityProject_internal_models.UserProfile": {
-            "type": "object",
-            "properties": {
-                "age": {
-                    "type": "integer"
-                },
-                "gender": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
         },
-        "internal_handler.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "에러 원인 및 설명"
-                }
-            }
-        },
-        "internal_handler.HistoryResponse": {
-            "type": "object",
-            "properties": {
-                "history": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Record"
+        "/api/admin/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "조직 목록 조회 (관리자)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.OrganizationListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "훈련을 진행할 고객사(조직)를 생성합니다. 생성된 조직은 모든 활성 시나리오를 사용할 수 있습니다.\n조직 관리자는 ` + "`" + `/api/admin/users/{id}/organization` + "`" + `으로 조직을 지정한 뒤 ` + "`" + `/api/admin/users/{id}/role` + "`" + `로 ` + "`" + `org_admin` + "`" + ` 역할을 부여합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "조직 생성 (관리자)",
+                "parameters": [
+                    {
+                        "description": "조직 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.CreateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 존재하는 조직 이름",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/organizations/{id}/mfa-policy": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "조직에서 2단계 인증이 필요한 역할을 지정합니다. 지정한 역할의 구성원은 2단계 인증으로 로그인해야 보호된 API를 사용할 수 있으며, 등록하지 않은 구성원은 ` + "`" + `/api/mfa` + "`" + `로 등록한 뒤 다시 로그인해야 합니다.\n` + "`" + `trainer` + "`" + `, ` + "`" + `org_admin` + "`" + `, ` + "`" + `admin` + "`" + `만 지정할 수 있으며 빈 배열이면 필수 역할이 없습니다. 조직 관리자가 추가로 지정한 역할(` + "`" + `org_mfa_required_roles` + "`" + `)은 변경하지 않습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "조직 2단계 인증 정책 변경 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "조직 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "2단계 인증 필수 역할 목록",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateOrganizationMFAPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 지정할 수 없는 역할",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "조직 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/organizations/{id}/scenarios": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "조직에서 사용할 수 있는 시나리오를 지정합니다. ` + "`" + `scenarios` + "`" + `가 null이면 모든 활성 시나리오, 빈 배열이면 사용할 수 있는 시나리오가 없습니다.\n조직 관리자는 이 범위 안에서만 구성원이 사용할 시나리오를 선택할 수 있습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "조직 허용 시나리오 변경 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "조직 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "허용 시나리오 키 목록",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateOrganizationScenariosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 존재하지 않는 시나리오",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "조직 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/scenarios": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "비활성화된 시나리오를 포함한 전체 시나리오 목록을 반환합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "시나리오 전체 조회 (관리자)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.AdminScenarioListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 조회 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "새로운 시나리오를 등록합니다. 키는 소문자, 숫자, 밑줄만 사용할 수 있습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "시나리오 생성 (관리자)",
+                "parameters": [
+                    {
+                        "description": "시나리오 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.CreateScenarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Scenario"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 존재하는 키",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/scenarios/{key}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "시나리오 정보와 LLM 페르소나 설정을 수정합니다. ` + "`" + `temperature` + "`" + `, ` + "`" + `enabled` + "`" + `를 생략하면 기존 값을 유지합니다.\n시나리오 팩 파일에서 로드된 시나리오는 파일이 변경되면 파일 내용으로 다시 덮어써집니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "시나리오 수정 (관리자)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "시나리오 키",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "수정할 시나리오 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateScenarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Scenario"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "시나리오 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "시나리오를 비활성화합니다. 기존 통화 기록 보존을 위해 실제로 삭제하지는 않습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "시나리오 비활성화 (관리자)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "시나리오 키",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "시나리오 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "사용자 목록을 사용자명 순으로 반환합니다. 역할, 조직, 그룹으로 필터링할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "사용자 목록 조회 (관리자)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "역할 필터 (trainee, trainer, org_admin, admin)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "조직 ID 필터",
+                        "name": "org_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "그룹 ID 필터",
+                        "name": "group_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 필터",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users/{id}/group": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "사용자를 그룹에 배정합니다. ` + "`" + `group_id` + "`" + `가 null이면 그룹에서 제외합니다. 그룹은 사용자와 같은 조직이어야 합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "사용자 그룹 지정 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "배정할 그룹",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateUserGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청, 존재하지 않는 그룹 또는 다른 조직의 그룹",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users/{id}/lockout": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "연속 로그인 실패로 잠기거나 대기 중인 사용자의 실패 기록을 초기화하여 바로 로그인할 수 있도록 합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "사용자 로그인 잠금 해제 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 사용자 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users/{id}/mfa": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "인증 앱과 복구 코드를 모두 잃어버린 사용자의 2단계 인증을 해제합니다. 조직 정책상 필수인 사용자는 다음 로그인 후 다시 등록해야 합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "사용자 2단계 인증 초기화 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 사용자 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users/{id}/organization": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "사용자를 조직에 소속시킵니다. ` + "`" + `org_id` + "`" + `가 null이면 조직에서 제외하며, 조직이 바뀌면 기존 그룹 배정은 해제됩니다.\n이전 조직에서 진행한 기록은 새 조직의 강사, 조직 관리자에게 공개되지 않습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "사용자 조직 지정 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "지정할 조직",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateUserOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 존재하지 않는 조직",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users/{id}/password-reset": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "비밀번호를 잊어버린 사용자에게 전달할 1회용 재설정 토큰을 발급합니다. 사용자는 ` + "`" + `/password/reset` + "`" + `에 토큰과 새 비밀번호를 보내 비밀번호를 변경합니다.\n토큰은 24시간 동안 한 번만 사용할 수 있으며, 다시 발급하면 이전 토큰은 폐기됩니다. 토큰 원문은 DB에 저장되지 않으며 이 응답에서만 확인할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "비밀번호 재설정 토큰 발급 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.PasswordResetTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 사용자 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "사용자의 역할(trainee, trainer, org_admin, admin)을 변경합니다. 역할별 API 접근은 DB의 역할로 확인하므로 변경된 역할은 해당 사용자의 기존 토큰에도 바로 적용됩니다.\n관리자 계정이 없어지는 것을 막기 위해 자신의 역할은 변경할 수 없습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "사용자 역할 변경 (관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "변경할 역할",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "사용자의 과거 시뮬레이션(통화/채팅) 기록 목록을 최신순으로 반환합니다.\n각 기록은 모드(` + "`" + `text` + "`" + `/` + "`" + `voice` + "`" + `), 시나리오, 진행 시간(` + "`" + `duration_ms` + "`" + `), 결과를 포함하며, 대화 내용은 ` + "`" + `/api/history/{id}/transcript` + "`" + `로 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "사용자 통화 기록 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "모드 필터 (text, voice)",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "history: [기록 배열]",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.HistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 모드",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 조회 실패 등 서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/history/audio/{filename}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "특정 통화 기록의 오디오 파일(.mp3)을 재생합니다. 기록이 남아 있는 본인 녹음 파일만 재생할 수 있습니다.\n기록 ID로 조회하는 ` + "`" + `GET /api/history/{id}/audio` + "`" + ` 사용을 권장합니다.\n\u003cbr\u003e **[인증]** Header에 ` + "`" + `Authorization: Bearer ...` + "`" + `를 넣으세요. ` + "`" + `\u003caudio\u003e` + "`" + ` 요소처럼 Header를 사용할 수 없으면 ` + "`" + `POST /api/history/{id}/audio-url` + "`" + `로 발급받은 URL을 사용하세요.",
+                "produces": [
+                    "audio/mpeg"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "녹음된 오디오 파일 스트리밍",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "오디오 파일명 (예: session_uuid.mp3)",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "오디오 바이너리 데이터",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "해당 파일을 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/history/{id}/audio": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "음성 세션 기록의 녹음 파일(.mp3)을 반환합니다. 텍스트 세션 기록은 녹음이 없으므로 404를 반환합니다.\n본인 기록 외에 강사는 같은 그룹 훈련생의 기록, 조직 관리자는 같은 조직 사용자의 기록(조직 소속 중 진행한 기록), 관리자는 모든 기록을 조회할 수 있습니다.\nAuthorization 헤더 대신 ` + "`" + `POST /api/history/{id}/audio-url` + "`" + `로 발급받은 1회용 티켓(` + "`" + `ticket` + "`" + `)으로 인증할 수 있습니다.",
+                "produces": [
+                    "audio/mpeg"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "세션 녹음 파일 재생",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "기록 ID (GET /api/history의 id)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1회용 재생 티켓 (Header 사용 불가 시)",
+                        "name": "ticket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "오디오 바이너리 데이터",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 기록 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패 또는 유효하지 않은 티켓",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "기록 또는 녹음 파일 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/history/{id}/audio-url": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "` + "`" + `\u003caudio\u003e` + "`" + ` 요소처럼 Authorization 헤더를 보낼 수 없는 곳에서 사용할 녹음 재생 URL을 발급합니다.\nURL의 티켓은 요청한 사용자와 기록에 묶이며 60초 안에 한 번만 사용할 수 있습니다. 다시 재생하려면 새 URL을 발급받으세요.\n조회 권한은 ` + "`" + `/api/history/{id}/audio` + "`" + `와 같습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "녹음 재생 URL 발급",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "기록 ID (GET /api/history의 id)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.AudioURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 기록 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "기록 또는 녹음 파일 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/history/{id}/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "대화 기록을 자동 평가한 리포트를 반환합니다. 주민등록번호·카드번호·계좌번호·인증번호·비밀번호·주소 유출, 송금 동의는 감점, 발신자 재확인(콜백)은 가점 항목입니다.\n각 항목에는 근거 발화(민감 정보는 마스킹)가 포함됩니다. 평가되지 않았거나 평가 규칙이 갱신된 기록은 조회 시 다시 평가합니다.\n본인 기록 외에 강사는 같은 그룹 훈련생의 기록, 조직 관리자는 같은 조직 사용자의 기록(조직 소속 중 진행한 기록), 관리자는 모든 기록을 조회할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "세션 평가 리포트 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "기록 ID (GET /api/history의 id)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.EvaluationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 기록 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "기록 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/history/{id}/transcript": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "특정 시뮬레이션 기록의 턴별 대화 내용(발화자, 텍스트, 세션 시작 기준 시각, STT 신뢰도)을 순서대로 반환합니다.\n본인 기록 외에 강사는 같은 그룹 훈련생의 기록, 조직 관리자는 같은 조직 사용자의 기록(조직 소속 중 진행한 기록), 관리자는 모든 기록을 조회할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "세션 대화 기록 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "기록 ID (GET /api/history의 id)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.TranscriptResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 기록 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "기록 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "요청한 사용자가 받은 대기 중인 조직 초대를 최신순으로 반환합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "받은 조직 초대 조회",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.InvitationListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/invitations/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "받은 초대를 수락하여 조직에 소속됩니다. 초대에 지정된 역할과 그룹이 적용되며, 변경된 역할은 다시 로그인한 뒤 적용됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "조직 초대 수락",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "초대 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 초대 ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "대기 중인 초대 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 조직에 소속됨",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mfa": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "2단계 인증 사용 여부, 조직 정책상 필수 여부, 남은 복구 코드 개수를 반환합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "2단계 인증 상태 조회",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.MFAStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "인증 앱의 6자리 코드 또는 복구 코드를 확인하고 2단계 인증을 해제합니다. 조직 정책상 필수인 역할은 해제할 수 없습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "2단계 인증 해제",
+                "parameters": [
+                    {
+                        "description": "인증 코드 또는 복구 코드",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 2단계 인증 미사용",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패 또는 잘못된 코드",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 정책상 필수",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "코드 확인 실패가 반복되어 일시적으로 제한됨",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "TOTP 비밀 키를 발급합니다. ` + "`" + `otpauth_url` + "`" + `을 QR 코드로 표시하거나 ` + "`" + `secret` + "`" + `을 인증 앱에 입력한 뒤 ` + "`" + `/api/mfa/verify` + "`" + `로 코드를 확인하면 사용이 시작됩니다.\n확인 전에 다시 요청하면 새 비밀 키로 대체됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "2단계 인증 등록 시작",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.MFAEnrollResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 2단계 인증 사용 중",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mfa/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "인증 앱의 6자리 코드로 등록을 확인하고 2단계 인증 사용을 시작합니다. 인증 앱을 잃어버렸을 때 사용할 복구 코드 10개를 반환하며, 복구 코드는 이 응답에서만 확인할 수 있습니다.\n이후 로그인부터 인증 코드가 필요하며, 조직 정책상 2단계 인증이 필요한 기능은 다시 로그인한 뒤 사용할 수 있습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "2단계 인증 등록 확인",
+                "parameters": [
+                    {
+                        "description": "인증 앱의 6자리 코드",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청, 등록 시작 전 또는 잘못된 코드",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 2단계 인증 사용 중",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/org": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "소속 조직 조회 (조직 관리자)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Organization"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/org/groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "조직 그룹 목록 조회 (조직 관리자)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.GroupListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "소속 조직에 훈련 그룹(팀, 기수)을 생성합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "조직 그룹 생성 (조직 관리자)",
+                "parameters": [
+                    {
+                        "description": "그룹 정보 (org_id는 무시됨)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.CreateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "같은 조직에 이미 존재하는 그룹 이름",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/org/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "소속 조직이 보낸 초대를 최신순으로 반환합니다. 수락된 초대는 ` + "`" + `accepted_at` + "`" + `을 포함합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "조직 초대 목록 조회 (조직 관리자)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.InvitationListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "조직에 소속되지 않은 기존 사용자를 소속 조직으로 초대합니다. 사용자가 ` + "`" + `/api/invitations/{id}/accept` + "`" + `로 수락하면 지정한 역할과 그룹이 적용됩니다.\n변경된 역할은 사용자가 다시 로그인한 뒤 발급되는 토큰부터 적용됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "조직 초대 (조직 관리자)",
+                "parameters": [
+                    {
+                        "description": "초대할 사용자, 역할, 그룹",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.CreateInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.OrganizationInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청, 역할 또는 조직에 없는 그룹",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 조직에 소속된 사용자 또는 대기 중인 초대 있음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/org/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "소속 조직의 사용자 목록을 사용자명 순으로 반환합니다. 역할, 그룹으로 필터링할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "조직 구성원 목록 조회 (조직 관리자)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "역할 필터 (trainee, trainer, org_admin)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "그룹 ID 필터",
+                        "name": "group_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 필터",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/org/members/{id}/group": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "소속 조직의 사용자를 조직의 그룹에 배정합니다. ` + "`" + `group_id` + "`" + `가 null이면 그룹에서 제외합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "조직 구성원 그룹 지정 (조직 관리자)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "배정할 그룹",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateUserGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 조직에 없는 그룹",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "조직에 없는 사용자",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/org/mfa-policy": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "소속 조직에서 관리자가 지정한 역할(` + "`" + `mfa_required_roles` + "`" + `) 외에 2단계 인증이 필요한 역할을 추가로 지정합니다(` + "`" + `org_mfa_required_roles` + "`" + `). 관리자가 지정한 역할은 해제할 수 없습니다.\n지정한 역할의 구성원은 2단계 인증으로 로그인해야 보호된 API를 사용할 수 있습니다.\n` + "`" + `org_admin` + "`" + `을 지정하면 요청한 조직 관리자도 2단계 인증으로 다시 로그인해야 조직 관리 API를 사용할 수 있습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "조직 2단계 인증 정책 변경 (조직 관리자)",
+                "parameters": [
+                    {
+                        "description": "2단계 인증 필수 역할 목록",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateOrganizationMFAPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 지정할 수 없는 역할",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/org/scenarios": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "관리자가 조직에 허용한 시나리오(` + "`" + `scenarios` + "`" + `) 중 구성원이 사용할 시나리오(` + "`" + `enabled_scenarios` + "`" + `)를 선택합니다. ` + "`" + `scenarios` + "`" + `가 null이면 허용된 시나리오 전체, 빈 배열이면 사용할 수 있는 시나리오가 없습니다.\n선택하지 않은 시나리오는 구성원의 시나리오 목록과 진행 상황에서 제외되며 시뮬레이션을 시작할 수 없습니다. 허용 범위 밖의 시나리오는 선택할 수 없습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organization"
+                ],
+                "summary": "조직 사용 시나리오 선택 (조직 관리자)",
+                "parameters": [
+                    {
+                        "description": "허용 시나리오 키 목록",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateOrganizationScenariosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 존재하지 않는 시나리오",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "조직 관리자 권한 없음 또는 관리자가 허용하지 않은 시나리오",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "현재 비밀번호를 확인하고 새 비밀번호로 변경합니다. 새 비밀번호는 최소 길이(기본 10자)를 만족해야 하며 사용자명과 같거나 유출된 비밀번호 목록에 있으면 사용할 수 없습니다.\n변경하면 다른 기기를 포함한 기존 로그인 세션(액세스 토큰, 리프레시 토큰)이 모두 무효화되고, 이 요청에는 새 토큰을 발급합니다.\n잘못된 현재 비밀번호는 로그인 실패 횟수에 포함됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "비밀번호 변경",
+                "parameters": [
+                    {
+                        "description": "현재 비밀번호와 새 비밀번호",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.LoginSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 비밀번호 정책 위반",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패 또는 잘못된 현재 비밀번호",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "로그인 실패로 인한 대기 또는 계정 잠금",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "본인의 계정 정보와 프로필, LLM 공유 항목(` + "`" + `llm_shared_fields` + "`" + `)을 조회합니다.\n시뮬레이션의 사기범 역할 LLM에는 공유 항목으로 선택한 프로필 항목만 전달됩니다. 선택한 적이 없으면 name, age, gender를 공유합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "프로필 조회 (Profile)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 토큰 누락 또는 만료",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "본인의 프로필과 LLM 공유 항목을 수정합니다. 보낸 항목만 변경되며, 텍스트 항목은 빈 문자열, ` + "`" + `has_children` + "`" + `은 null로 삭제합니다.\n추가 항목(occupation, bank, region, has_children)은 ` + "`" + `llm_shared_fields` + "`" + `에 포함해야 시뮬레이션에 사용됩니다. 빈 배열이면 어떤 항목도 공유하지 않습니다.\n공유 항목: name, age, gender, occupation, bank, region, has_children. 텍스트 항목은 최대 50자입니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "프로필 수정",
+                "parameters": [
+                    {
+                        "description": "변경할 프로필 항목",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 항목 값",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 토큰 누락 또는 만료",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/progress": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "커리큘럼 모듈 순서대로 시나리오별 진행 상태(` + "`" + `completed` + "`" + `, ` + "`" + `in_progress` + "`" + `, ` + "`" + `available` + "`" + `, ` + "`" + `locked` + "`" + `)와 시도 횟수, 점수를 반환합니다.\n시나리오는 평가를 통과한(치명 항목 없음) 세션의 점수가 모듈의 ` + "`" + `pass_score` + "`" + ` 이상이면 완료되며, 이전 모듈의 시나리오를 모두 완료해야 다음 모듈이 열립니다.\n커리큘럼에 포함되지 않은 시나리오는 ` + "`" + `electives` + "`" + `에 표시되며 항상 시작할 수 있습니다. 소속 조직에서 허용되지 않은 시나리오는 제외됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "훈련 진행 상황 조회",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ProgressResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/scenarios": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "시뮬레이션에 사용할 수 있는 활성 시나리오 목록을 반환합니다.\n클라이언트는 ` + "`" + `key` + "`" + `를 ` + "`" + `/ws/simulation` + "`" + `의 ` + "`" + `scenario` + "`" + ` 파라미터로, ` + "`" + `modes` + "`" + ` 중 하나를 ` + "`" + `mode` + "`" + ` 파라미터로 사용합니다.\n인증 헤더(선택)를 보내면 소속 조직에서 허용된 시나리오만 반환합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Simulation"
+                ],
+                "summary": "시나리오 목록 조회",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ScenarioListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 조회 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer/trainees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "강사는 같은 그룹의 훈련생, 조직 관리자는 같은 조직의 훈련생, 관리자는 전체 훈련생 목록을 조회합니다. 그룹(조직 관리자는 조직)이 지정되지 않은 경우 빈 목록을 받습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainer"
+                ],
+                "summary": "훈련생 목록 조회 (강사)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.TraineeListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "강사/조직 관리자/관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer/trainees/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "훈련생의 시뮬레이션 기록 목록을 최신순으로 반환합니다. 대화 기록, 평가 리포트, 녹음은 ` + "`" + `/api/history/{id}/...` + "`" + `로 조회합니다.\n강사는 같은 그룹의 훈련생, 조직 관리자는 같은 조직의 사용자만 조회할 수 있으며, 그 외 사용자는 404를 반환합니다.\n관리자가 아니면 훈련생이 현재 조직에 소속된 동안 진행한 기록만 반환합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainer"
+                ],
+                "summary": "훈련생 기록 조회 (강사)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "훈련생 사용자 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "모드 필터 (text, voice)",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.HistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 사용자 ID 또는 모드",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "강사/조직 관리자/관리자 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "사용자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "DB 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ws-ticket": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "` + "`" + `/ws/simulation` + "`" + ` 연결에 사용할 1회용 티켓을 발급합니다. 티켓은 요청한 사용자와 시나리오에 묶이며 30초 안에 한 번만 사용할 수 있습니다.\nJWT를 URL에 넣는 대신 ` + "`" + `ticket` + "`" + ` 쿼리로 연결하세요. (예: ` + "`" + `/ws/simulation?ticket=...\u0026scenario=loan_scam\u0026mode=text` + "`" + `)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API (Protected)"
+                ],
+                "summary": "WebSocket 연결 티켓 발급",
+                "parameters": [
+                    {
+                        "description": "연결할 시나리오",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.WSTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.WSTicketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 시나리오 키",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/providers": {
+            "get": {
+                "description": "로그인 화면에 표시할 SSO(회사 계정 로그인) 공급자 목록을 반환합니다. 설정된 공급자가 없으면 빈 목록입니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SSO"
+                ],
+                "summary": "SSO 공급자 목록",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SSOProviderListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/token": {
+            "post": {
+                "description": "SSO 로그인 후 프론트엔드로 전달된 1회용 ` + "`" + `ticket` + "`" + `(60초)으로 액세스 토큰과 리프레시 토큰을 발급받습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SSO"
+                ],
+                "summary": "SSO 로그인 토큰 발급",
+                "parameters": [
+                    {
+                        "description": "SSO 로그인 티켓",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SSOTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.LoginSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "유효하지 않거나 만료된 티켓",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/callback": {
+            "get": {
+                "description": "IdP가 로그인 후 브라우저를 이동시키는 주소입니다. (IdP에 redirect URI로 등록)\nIdP 계정과 연결된 계정이 없으면 새 계정을 만들고, IdP 그룹에 매핑된 역할과 조직을 로그인할 때마다 적용합니다.\n공급자에 ` + "`" + `post_login_redirect` + "`" + `가 설정되어 있으면 1회용 ` + "`" + `ticket` + "`" + `을 붙여 프론트엔드로 이동하며, 프론트엔드는 ` + "`" + `/auth/oidc/token` + "`" + `으로 토큰을 발급받습니다. 설정이 없으면 토큰을 바로 응답합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SSO"
+                ],
+                "summary": "SSO 로그인 콜백",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SSO 공급자 ID",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "인증 코드",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "로그인 요청 state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IdP 오류 코드",
+                        "name": "error",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.LoginSuccessResponse"
+                        }
+                    },
+                    "302": {
+                        "description": "프론트엔드로 이동 (?ticket=)"
+                    },
+                    "400": {
+                        "description": "유효하지 않거나 만료된 state",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "IdP 로그인 실패 또는 ID 토큰 검증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "IdP 그룹에 매핑된 역할 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "공급자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "같은 사용자명의 계정이 이미 있음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/login": {
+            "get": {
+                "description": "브라우저를 회사 IdP의 로그인 페이지로 이동시킵니다. (OpenID Connect 인증 코드 흐름, PKCE S256)\n로그인을 시작한 브라우저를 확인하기 위한 쿠키를 설정하므로 API 호출이 아닌 브라우저 이동(링크, location)으로 사용하세요.",
+                "tags": [
+                    "SSO"
+                ],
+                "summary": "SSO 로그인 시작",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SSO 공급자 ID",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "IdP 로그인 페이지로 이동"
+                    },
+                    "404": {
+                        "description": "공급자 없음",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "IdP 설정 조회 실패",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "사용자명과 비밀번호로 로그인하고 JWT 액세스 토큰과 리프레시 토큰을 발급받습니다.\n액세스 토큰은 짧게 유지되므로(기본 15분) 만료되면 ` + "`" + `/token/refresh` + "`" + `로 재발급받습니다.\n2단계 인증을 사용하는 계정은 토큰 대신 202와 함께 ` + "`" + `mfa_token` + "`" + `을 반환하며, ` + "`" + `/login/mfa` + "`" + `에 인증 코드와 함께 보내 토큰을 발급받습니다.\n계정별로 연속 실패 3회 이후에는 실패할 때마다 다음 시도까지 대기 시간(1초부터 두 배씩 최대 30초)이 생기고, 10회 실패하면 15분 동안 잠깁니다. 대기 또는 잠금 중에는 429와 ` + "`" + `Retry-After` + "`" + ` 헤더를 반환합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "로그인 (Login)",
+                "parameters": [
+                    {
+                        "description": "로그인 요청 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.LoginSuccessResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "2단계 인증 필요",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.MFAChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패 (자격 증명 오류)",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "로그인 실패로 인한 대기 또는 계정 잠금",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/mfa": {
+            "post": {
+                "description": "` + "`" + `/login` + "`" + `이 반환한 ` + "`" + `mfa_token` + "`" + `과 인증 앱의 6자리 코드(또는 복구 코드)로 토큰을 발급받습니다. 각 코드와 복구 코드는 한 번만 사용할 수 있습니다.\n잘못된 코드는 ` + "`" + `/login` + "`" + `의 비밀번호 실패와 같이 계정별 실패 횟수에 포함되어 대기 시간과 잠금이 적용됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "2단계 인증 로그인",
+                "parameters": [
+                    {
+                        "description": "mfa_token과 인증 코드",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.LoginMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.LoginSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "유효하지 않은 mfa_token 또는 인증 코드",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "인증 실패로 인한 대기 또는 계정 잠금",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "리프레시 토큰이 속한 로그인 세션을 폐기합니다. ` + "`" + `Authorization` + "`" + ` 헤더에 액세스 토큰을 함께 보내면 해당 액세스 토큰도 만료 전에 즉시 폐기됩니다.\n이미 폐기되었거나 알 수 없는 리프레시 토큰이어도 성공으로 응답합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "로그아웃",
+                "parameters": [
+                    {
+                        "description": "리프레시 토큰",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/reset": {
+            "post": {
+                "description": "관리자에게 받은 재설정 토큰으로 비밀번호를 변경합니다. 기존 로그인 세션은 모두 무효화되고 로그인 실패로 인한 잠금도 해제되며, 새 비밀번호로 다시 로그인해야 합니다.\n새 비밀번호 정책은 ` + "`" + `/api/password` + "`" + `와 같습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "비밀번호 재설정",
+                "parameters": [
+                    {
+                        "description": "재설정 토큰과 새 비밀번호",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청 또는 비밀번호 정책 위반",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "유효하지 않거나 만료된 재설정 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/signup": {
+            "post": {
+                "description": "새로운 사용자 계정을 생성합니다.\n비밀번호는 최소 길이(기본 10자)를 만족해야 하며 사용자명과 같거나 유출된 비밀번호 목록에 있으면 사용할 수 없습니다.\n` + "`" + `X-Invite-Code` + "`" + ` 헤더로 초대 코드를 보내면 코드에 지정된 조직, 역할, 그룹이 적용됩니다. (SIGNUP_INVITE_REQUIRED=true이면 필수)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "회원가입 (Signup)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "초대 코드 (예: K7QX-M2PA-9FZD-W4TR)",
+                        "name": "X-Invite-Code",
+                        "in": "header"
+                    },
+                    {
+                        "description": "회원가입 요청 정보",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "초대 코드 누락 또는 유효하지 않은 초대 코드",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "리프레시 토큰으로 새 액세스 토큰과 새 리프레시 토큰을 발급받습니다. 사용한 리프레시 토큰은 더 이상 쓸 수 없습니다.\n이미 사용된 리프레시 토큰이 다시 들어오면 탈취로 간주하여 해당 로그인 세션의 리프레시 토큰을 모두 폐기합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "액세스 토큰 재발급",
+                "parameters": [
+                    {
+                        "description": "리프레시 토큰",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.LoginSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "유효하지 않거나 재사용된 리프레시 토큰",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 오류",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/simulation": {
+            "get": {
+                "description": "지정된 시나리오와 모드로 실시간 시뮬레이션을 위한 WebSocket 연결을 시작합니다.\n\u003cbr\u003e\n**[중요]** 이것은 표준 HTTP API가 아닙니다. ` + "`" + `ws://` + "`" + ` 또는 ` + "`" + `wss://` + "`" + ` 스킴을 사용해야 합니다.\n**인증:** WebSocket 연결 시에는 HTTP Header를 사용할 수 없으므로, ` + "`" + `POST /api/ws-ticket` + "`" + `으로 발급받은 1회용 티켓을 **Query Parameter(` + "`" + `ticket` + "`" + `)**로 전달합니다. 티켓은 발급 시 지정한 시나리오에만 사용할 수 있습니다.\nJWT를 ` + "`" + `token` + "`" + ` 쿼리로 전달하는 방식은 토큰이 프록시 로그 등에 남으므로 기본적으로 거부되며, 서버에 ` + "`" + `WS_TOKEN_QUERY_COMPAT=true` + "`" + `를 설정한 경우에만 호환용으로 허용됩니다.\n\u003cbr\u003e\n**프로토콜:** 모든 텍스트 프레임은 ` + "`" + `{\"v\": 1, \"type\": \"...\", \"ts\": \"...\", \"data\": {...}}` + "`" + ` 형식의 JSON 봉투입니다. (handler.WSEnvelope)\n- 서버 → 클라이언트: ` + "`" + `session.started` + "`" + `, ` + "`" + `assistant.utterance` + "`" + `, ` + "`" + `assistant.audio` + "`" + `, ` + "`" + `user.transcript` + "`" + `, ` + "`" + `stt.interim` + "`" + `, ` + "`" + `coach.hint` + "`" + `, ` + "`" + `error` + "`" + `, ` + "`" + `session.ended` + "`" + `\n- 클라이언트 → 서버: ` + "`" + `user.message` + "`" + ` (텍스트 모드, ` + "`" + `data.text` + "`" + `), ` + "`" + `session.end` + "`" + ` (세션 종료)\n- 음성 모드의 오디오는 바이너리 프레임으로 주고받으며, 서버 오디오는 형식(` + "`" + `container` + "`" + `, ` + "`" + `encoding` + "`" + `, ` + "`" + `sample_rate_hz` + "`" + `, ` + "`" + `bytes` + "`" + `)을 담은 ` + "`" + `assistant.audio` + "`" + ` 메시지 직후에 전송됩니다.\n- 코치 모드(` + "`" + `coach=true` + "`" + `, 텍스트 모드 전용)에서는 위험 신호가 있는 사기범 발화 직후 ` + "`" + `coach.hint` + "`" + `(` + "`" + `hint_id` + "`" + `, ` + "`" + `message` + "`" + `)가 전송되며, 표시된 힌트는 평가 리포트의 ` + "`" + `hints_shown` + "`" + `에 기록됩니다.\n- ` + "`" + `session.ended` + "`" + `는 종료 사유(` + "`" + `reason` + "`" + `), 결과(` + "`" + `outcome` + "`" + `), 저장된 기록 ID(` + "`" + `record_id` + "`" + `)를 포함하며 이후 연결이 닫힙니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Simulation (WebSocket)"
+                ],
+                "summary": "보이스피싱 시뮬레이션 시작 (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "1회용 연결 티켓 (POST /api/ws-ticket으로 발급, 권장)",
+                        "name": "ticket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer 토큰 (WS_TOKEN_QUERY_COMPAT=true인 서버에서만 사용 가능, 사용 중단 예정)",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "시나리오 키 (GET /api/scenarios 목록의 key, 예: loan_scam)",
+                        "name": "scenario",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "모드 선택 (text: 텍스트 채팅, voice: 실시간 음성 통화)",
+                        "name": "mode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "코치 모드 (초보 훈련생용 실시간 힌트, 텍스트 모드 전용)",
+                        "name": "coach",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 파라미터",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "인증 실패",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "조직에서 허용되지 않은 시나리오 또는 선수 과정 미완료 (ENFORCE_PREREQUISITES=true인 경우)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "PishingSimulator_SecurityProject_internal_auth.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string",
+                    "example": "EdDSA"
+                },
+                "crv": {
+                    "type": "string",
+                    "example": "Ed25519"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string",
+                    "example": "2025-03"
+                },
+                "kty": {
+                    "type": "string",
+                    "example": "OKP"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "type": "string",
+                    "example": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_auth.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_auth.JWK"
+                    }
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_curriculum.ModuleStatus": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "basics"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "기초: 생활 속 사칭"
+                },
+                "pass_score": {
+                    "type": "integer",
+                    "example": 70
+                },
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_curriculum.ScenarioStatus"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "in_progress"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_curriculum.ScenarioStatus": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "best_score": {
+                    "description": "평가를 통과한 세션 중 최고 점수",
+                    "type": "integer",
+                    "example": 65
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "easy"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "delivery_notification"
+                },
+                "last_record_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "last_score": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Delivery Notification"
+                },
+                "status": {
+                    "description": "completed | in_progress | available | locked",
+                    "type": "string",
+                    "example": "in_progress"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_curriculum.Summary": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "completed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "in_progress": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "locked": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.CoachHint": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "otp_request"
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "인증번호",
+                        "OTP"
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "실제 기관은 전화로 인증번호(OTP)를 묻지 않습니다."
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "credential_request"
+                    ]
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.DialogueBranch": {
+            "type": "object",
+            "properties": {
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "next": {
+                    "type": "string"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.DialogueNode": {
+            "type": "object",
+            "properties": {
+                "branches": {
+                    "description": "키워드 분기, 위에서부터 순서대로 검사",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.DialogueBranch"
+                    }
+                },
+                "end": {
+                    "description": "진입 시 세션 종료",
+                    "type": "boolean"
+                },
+                "fallback": {
+                    "description": "일치하는 분기가 없을 때의 발화",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lines": {
+                    "description": "진입 시 발화 ({name}은 사용자 이름으로 치환)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_fallbacks": {
+                    "description": "기본값 2",
+                    "type": "integer"
+                },
+                "next": {
+                    "description": "폴백이 MaxFallbacks회 반복되면 이동할 노드",
+                    "type": "string"
+                },
+                "step": {
+                    "description": "진입 시 보고할 NextStep 값",
+                    "type": "string"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.DialogueScript": {
+            "type": "object",
+            "properties": {
+                "nodes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.DialogueNode"
+                    }
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.EvaluationReport": {
+            "type": "object",
+            "properties": {
+                "coach": {
+                    "description": "코치 모드 세션 여부",
+                    "type": "boolean"
+                },
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Finding"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "hints_shown": {
+                    "description": "세션 중 표시된 코칭 힌트 (표시 순서)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.ShownHint"
+                    }
+                },
+                "outcome": {
+                    "type": "string",
+                    "example": "resisted"
+                },
+                "passed": {
+                    "type": "boolean"
+                },
+                "record_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "score": {
+                    "description": "0 ~ 100",
+                    "type": "integer",
+                    "example": 70
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.Evidence": {
+            "type": "object",
+            "properties": {
+                "quote": {
+                    "type": "string",
+                    "example": "주민번호는 90****-*****34 입니다"
+                },
+                "start_ms": {
+                    "type": "integer",
+                    "example": 15200
+                },
+                "turn_seq": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.Finding": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "resident_registration_number"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "주민등록번호를 알려주었습니다."
+                },
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Evidence"
+                    }
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "risk"
+                },
+                "points": {
+                    "description": "점수 반영값 (감점은 음수)",
+                    "type": "integer",
+                    "example": -30
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.Group": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "2025 상반기 신입사원"
+                },
+                "org_id": {
+                    "description": "그룹이 속한 조직",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.InviteCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "생성 응답에만 포함",
+                    "type": "string",
+                    "example": "K7QX-M2PA-9FZD-W4TR"
+                },
+                "code_prefix": {
+                    "description": "목록에서 코드를 구분하기 위한 앞 4자리",
+                    "type": "string",
+                    "example": "K7QX"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "org_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "trainee"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "uses": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.InviteCodeRedemption": {
+            "type": "object",
+            "properties": {
+                "client_ip": {
+                    "type": "string",
+                    "example": "203.0.113.10"
+                },
+                "code_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "redeemed_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "username": {
+                    "type": "string",
+                    "example": "gildong"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.Organization": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled_scenarios": {
+                    "description": "조직 관리자가 허용 시나리오 중 선택한 시나리오, null이면 허용 시나리오 전체",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "loan_scam"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "mfa_required_roles": {
+                    "description": "관리자가 지정한 2단계 인증(TOTP) 필수 역할 (조직 관리자가 해제할 수 없음)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "org_admin"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "OO은행"
+                },
+                "org_mfa_required_roles": {
+                    "description": "조직 관리자가 추가로 지정한 2단계 인증 필수 역할",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "trainer"
+                    ]
+                },
+                "scenarios": {
+                    "description": "관리자가 허용한 시나리오 키, null이면 모든 활성 시나리오",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "loan_scam",
+                        "institution_impersonation"
+                    ]
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.OrganizationInvitation": {
+            "type": "object",
+            "properties": {
+                "accepted_at": {
+                    "description": "대기 중이면 생략",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "description": "수락 시 배정되는 그룹",
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "invited_by": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "org_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "org_name": {
+                    "type": "string",
+                    "example": "OO은행"
+                },
+                "role": {
+                    "description": "수락 시 지정되는 역할",
+                    "type": "string",
+                    "example": "trainee"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "gildong"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.Record": {
+            "type": "object",
+            "properties": {
+                "coach": {
+                    "description": "코치 모드(힌트 표시) 세션 여부",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "description": "세션 진행 시간",
+                    "type": "integer",
+                    "example": 185000
+                },
+                "file_path": {
+                    "description": "음성 모드의 녹음 파일 (텍스트 모드는 빈 값)",
+                    "type": "string"
+                },
+                "final_state": {
+                    "type": "string",
+                    "example": "user_hung_up"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "text | voice",
+                    "type": "string",
+                    "example": "voice"
+                },
+                "org_id": {
+                    "description": "세션 당시 사용자의 소속 조직",
+                    "type": "integer",
+                    "example": 1
+                },
+                "outcome": {
+                    "type": "string",
+                    "example": "resisted"
+                },
+                "scenario": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "평가 리포트 점수 (평가 전이면 생략)",
+                    "type": "integer",
+                    "example": 70
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.Scenario": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "A scenario where the user is targeted with a loan scam call."
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "easy"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "estimated_minutes": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "forbidden_topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hints": {
+                    "description": "코치 모드 힌트 (없으면 공통 힌트 사용)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.CoachHint"
+                    }
+                },
+                "key": {
+                    "type": "string",
+                    "example": "loan_scam"
+                },
+                "modes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "text",
+                        "voice"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Loan Scam"
+                },
+                "opening_line": {
+                    "type": "string"
+                },
+                "persona": {
+                    "description": "LLM 페르소나 설정 (훈련생용 목록에는 노출하지 않음)",
+                    "type": "string"
+                },
+                "script": {
+                    "description": "오프라인 대화 엔진용 대화 트리 (없으면 엔진 기본 트리 사용)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.DialogueScript"
+                        }
+                    ]
+                },
+                "source": {
+                    "description": "시나리오 출처 (builtin, admin, 또는 시나리오 팩 파일 경로)",
+                    "type": "string"
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "voice": {
+                    "type": "string"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.ShownHint": {
+            "type": "object",
+            "properties": {
+                "hint_id": {
+                    "type": "string",
+                    "example": "otp_request"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "실제 기관은 전화로 인증번호(OTP)를 묻지 않습니다."
+                },
+                "start_ms": {
+                    "type": "integer",
+                    "example": 21000
+                },
+                "turn_seq": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.TranscriptTurn": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "description": "음성 모드 사용자 발화의 STT 신뢰도",
+                    "type": "number",
+                    "example": 0.92
+                },
+                "end_ms": {
+                    "type": "integer",
+                    "example": 6900
+                },
+                "hint_id": {
+                    "description": "코치 힌트 턴의 힌트 ID",
+                    "type": "string",
+                    "example": "otp_request"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "record_id": {
+                    "type": "integer"
+                },
+                "seq": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "speaker": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "start_ms": {
+                    "type": "integer",
+                    "example": 5200
+                },
+                "text": {
+                    "type": "string",
+                    "example": "누구세요?"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.User": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "description": "소속 그룹 (없으면 생략)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "llm_shared_fields": {
+                    "description": "LLM 대화 엔진에 전달할 프로필 항목 (사용자가 /api/profile에서 선택, 지정하지 않았으면 DefaultLLMSharedFields)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "age",
+                        "occupation"
+                    ]
+                },
+                "mfa_enabled": {
+                    "description": "TOTP 2단계 인증 사용 여부",
+                    "type": "boolean"
+                },
+                "org_id": {
+                    "description": "소속 조직 (없으면 생략)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "profile": {
+                    "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.UserProfile"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "trainee"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "PishingSimulator_SecurityProject_internal_models.UserProfile": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "0이면 미입력 (SSO로 생성된 계정)",
+                    "type": "integer",
+                    "example": 34
+                },
+                "bank": {
+                    "description": "주로 사용하는 은행",
+                    "type": "string",
+                    "example": "국민은행"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "male"
+                },
+                "has_children": {
+                    "description": "자녀 유무, 미입력이면 생략",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "홍길동"
+                },
+                "occupation": {
+                    "type": "string",
+                    "example": "회사원"
+                },
+                "region": {
+                    "description": "거주 지역",
+                    "type": "string",
+                    "example": "서울 마포구"
+                }
+            }
+        },
+        "internal_handler.AdminScenarioListResponse": {
+            "type": "object",
+            "properties": {
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Scenario"
+                    }
+                }
+            }
+        },
+        "internal_handler.AudioURLResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "유효 기간 (초)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/history/12/audio?ticket=b1Xo3k..."
+                }
+            }
+        },
+        "internal_handler.ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "correct-horse-battery"
+                }
+            }
+        },
+        "internal_handler.CreateGroupRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "2025 상반기 신입사원"
+                },
+                "org_id": {
+                    "description": "그룹이 속할 조직 (생략 시 조직 없음)",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "internal_handler.CreateInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "description": "수락 시 배정할 조직 그룹 (선택)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "role": {
+                    "description": "trainee, trainer, org_admin (생략 시 trainee)",
+                    "type": "string",
+                    "example": "trainee"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "gildong"
+                }
+            }
+        },
+        "internal_handler.CreateInviteCodeRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "만료 시각 (생략 시 7일 후)",
+                    "type": "string",
+                    "example": "2025-03-31T23:59:59Z"
+                },
+                "group_id": {
+                    "description": "가입자가 배정될 그룹 (선택, 조직의 그룹)",
+                    "type": "integer",
+                    "example": 3
+                },
+                "max_uses": {
+                    "description": "최대 사용 횟수 (생략 시 1)",
+                    "type": "integer",
+                    "example": 30
+                },
+                "org_id": {
+                    "description": "가입자가 소속될 조직 (선택)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "role": {
+                    "description": "trainee, trainer, org_admin (생략 시 trainee)",
+                    "type": "string",
+                    "example": "trainee"
+                }
+            }
+        },
+        "internal_handler.CreateOrganizationRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "OO은행"
+                }
+            }
+        },
+        "internal_handler.CreateScenarioRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "A scenario where the user receives a fake card delivery call."
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "medium"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "estimated_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "forbidden_topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "real company names"
+                    ]
+                },
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "obtain card number",
+                        "obtain OTP"
+                    ]
+                },
+                "hints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.CoachHint"
+                    }
+                },
+                "key": {
+                    "type": "string",
+                    "example": "card_delivery_scam"
+                },
+                "modes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "text",
+                        "voice"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Card Delivery Scam"
+                },
+                "opening_line": {
+                    "type": "string",
+                    "example": "안녕하세요, OO카드 배송팀입니다."
+                },
+                "persona": {
+                    "type": "string",
+                    "example": "카드사 배송 담당 직원을 사칭하는 30대 남성"
+                },
+                "script": {
+                    "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.DialogueScript"
+                },
+                "temperature": {
+                    "description": "생략하면 생성 시 0.7, 수정 시 기존 값 유지",
+                    "type": "number",
+                    "example": 0.7
+                },
+                "voice": {
+                    "type": "string",
+                    "example": "ko-KR-Wavenet-C"
+                }
+            }
+        },
+        "internal_handler.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "에러 원인 및 설명"
+                }
+            }
+        },
+        "internal_handler.GroupListResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Group"
+                    }
+                }
+            }
+        },
+        "internal_handler.HistoryResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Record"
+                    }
+                }
+            }
+        },
+        "internal_handler.InvitationListResponse": {
+            "type": "object",
+            "properties": {
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.OrganizationInvitation"
+                    }
+                }
+            }
+        },
+        "internal_handler.InviteCodeListResponse": {
+            "type": "object",
+            "properties": {
+                "invite_codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.InviteCode"
+                    }
+                }
+            }
+        },
+        "internal_handler.InviteCodeRedemptionListResponse": {
+            "type": "object",
+            "properties": {
+                "redemptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.InviteCodeRedemption"
+                    }
+                }
+            }
+        },
+        "internal_handler.LoginMFARequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "인증 앱의 6자리 코드 또는 복구 코드",
+                    "type": "string",
+                    "example": "492039"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjUtMDMifQ..."
+                }
+            }
+        },
+        "internal_handler.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "my_user"
+                }
+            }
+        },
+        "internal_handler.LoginSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "액세스 토큰 유효 기간 (초)",
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "description": "액세스 토큰 재발급용 (1회용)",
+                    "type": "string",
+                    "example": "q3Zr9m2K..."
+                },
+                "token": {
+                    "description": "액세스 토큰",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "internal_handler.MFAChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "mfa_token 유효 기간 (초)",
+                    "type": "integer",
+                    "example": 300
+                },
+                "mfa_required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mfa_token": {
+                    "description": "/login/mfa에 코드와 함께 전송",
+                    "type": "string",
+                    "example": "eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjUtMDMifQ..."
+                }
+            }
+        },
+        "internal_handler.MFACodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "492039"
+                }
+            }
+        },
+        "internal_handler.MFAEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_url": {
+                    "type": "string",
+                    "example": "otpauth://totp/PishingSimulator:gildong?secret=..."
+                },
+                "secret": {
+                    "description": "인증 앱 수동 입력용",
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "internal_handler.MFARecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "7KQ2M-XP4RZ",
+                        "M2PA9-FZDW4"
+                    ]
+                }
+            }
+        },
+        "internal_handler.MFAStatusResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "recovery_codes_remaining": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "required": {
+                    "description": "조직 정책상 필수 여부",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "internal_handler.OrganizationListResponse": {
+            "type": "object",
+            "properties": {
+                "organizations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.Organization"
                     }
                 }
             }
         },
-        "internal_handler.LoginRequest": {
+        "internal_handler.PasswordResetTokenResponse": {
             "type": "object",
             "properties": {
-                "password": {
+                "expires_in": {
+                    "description": "유효 기간 (초)",
+                    "type": "integer",
+                    "example": 86400
+                },
+                "reset_token": {
+                    "description": "사용자에게 전달, 이 응답에서만 확인 가능",
                     "type": "string",
-                    "example": "password123"
+                    "example": "q8Zt2w..."
+                }
+            }
+        },
+        "internal_handler.ProgressResponse": {
+            "type": "object",
+            "properties": {
+                "electives": {
+                    "description": "커리큘럼에 포함되지 않은 시나리오 (항상 시작 가능)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_curriculum.ScenarioStatus"
+                    }
                 },
-                "username": {
+                "enforce_prerequisites": {
+                    "description": "true이면 locked 시나리오는 시작할 수 없음",
+                    "type": "boolean"
+                },
+                "modules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_curriculum.ModuleStatus"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_curriculum.Summary"
+                }
+            }
+        },
+        "internal_handler.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
                     "type": "string",
-                    "example": "my_user"
+                    "example": "q3Zr9m2K..."
                 }
             }
         },
-        "internal_handler.LoginSuccessResponse": {
+        "internal_handler.ResetPasswordRequest": {
             "type": "object",
             "properties": {
-                "token": {
+                "new_password": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "correct-horse-battery"
+                },
+                "reset_token": {
+                    "type": "string",
+                    "example": "q8Zt2w..."
+                }
+            }
+        },
+        "internal_handler.SSOProviderInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "acme"
+                },
+                "login_url": {
+                    "type": "string",
+                    "example": "/auth/oidc/acme/login"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acme 임직원 로그인"
+                }
+            }
+        },
+        "internal_handler.SSOProviderListResponse": {
+            "type": "object",
+            "properties": {
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler.SSOProviderInfo"
+                    }
+                }
+            }
+        },
+        "internal_handler.SSOTokenRequest": {
+            "type": "object",
+            "properties": {
+                "ticket": {
+                    "type": "string",
+                    "example": "b1Xo3k..."
+                }
+            }
+        },
+        "internal_handler.ScenarioListResponse": {
+            "type": "object",
+            "properties": {
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler.ScenarioSummary"
+                    }
+                }
+            }
+        },
+        "internal_handler.ScenarioSummary": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "A scenario where the user is targeted with a loan scam call."
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "medium"
+                },
+                "estimated_minutes": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "key": {
+                    "type": "string",
+                    "example": "loan_scam"
+                },
+                "modes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "text",
+                        "voice"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Loan Scam"
                 }
             }
         },
@@ -407,6 +4779,252 @@ const docTemplate = `{
                     "example": "User created successfully"
                 }
             }
+        },
+        "internal_handler.TraineeListResponse": {
+            "type": "object",
+            "properties": {
+                "trainees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                    }
+                }
+            }
+        },
+        "internal_handler.TranscriptResponse": {
+            "type": "object",
+            "properties": {
+                "record_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "turns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.TranscriptTurn"
+                    }
+                }
+            }
+        },
+        "internal_handler.UpdateOrganizationMFAPolicyRequest": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "trainer",
+                        "org_admin"
+                    ]
+                }
+            }
+        },
+        "internal_handler.UpdateOrganizationScenariosRequest": {
+            "type": "object",
+            "properties": {
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "loan_scam",
+                        "institution_impersonation"
+                    ]
+                }
+            }
+        },
+        "internal_handler.UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "0이면 미입력 (SSO로 생성된 계정)",
+                    "type": "integer",
+                    "example": 34
+                },
+                "bank": {
+                    "description": "주로 사용하는 은행",
+                    "type": "string",
+                    "example": "국민은행"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "male"
+                },
+                "has_children": {
+                    "description": "자녀 유무, 미입력이면 생략",
+                    "type": "boolean"
+                },
+                "llm_shared_fields": {
+                    "description": "LLM에 전달할 항목, 생략하면 유지",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "age",
+                        "occupation"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "홍길동"
+                },
+                "occupation": {
+                    "type": "string",
+                    "example": "회사원"
+                },
+                "region": {
+                    "description": "거주 지역",
+                    "type": "string",
+                    "example": "서울 마포구"
+                }
+            }
+        },
+        "internal_handler.UpdateScenarioRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "A scenario where the user receives a fake card delivery call."
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "medium"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "estimated_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "forbidden_topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "real company names"
+                    ]
+                },
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "obtain card number",
+                        "obtain OTP"
+                    ]
+                },
+                "hints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.CoachHint"
+                    }
+                },
+                "modes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "text",
+                        "voice"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Card Delivery Scam"
+                },
+                "opening_line": {
+                    "type": "string",
+                    "example": "안녕하세요, OO카드 배송팀입니다."
+                },
+                "persona": {
+                    "type": "string",
+                    "example": "카드사 배송 담당 직원을 사칭하는 30대 남성"
+                },
+                "script": {
+                    "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.DialogueScript"
+                },
+                "temperature": {
+                    "description": "생략하면 생성 시 0.7, 수정 시 기존 값 유지",
+                    "type": "number",
+                    "example": 0.7
+                },
+                "voice": {
+                    "type": "string",
+                    "example": "ko-KR-Wavenet-C"
+                }
+            }
+        },
+        "internal_handler.UpdateUserGroupRequest": {
+            "type": "object",
+            "properties": {
+                "group_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "internal_handler.UpdateUserOrganizationRequest": {
+            "type": "object",
+            "properties": {
+                "org_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "internal_handler.UpdateUserRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "trainer"
+                }
+            }
+        },
+        "internal_handler.UserListResponse": {
+            "type": "object",
+            "properties": {
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PishingSimulator_SecurityProject_internal_models.User"
+                    }
+                }
+            }
+        },
+        "internal_handler.WSTicketRequest": {
+            "type": "object",
+            "properties": {
+                "scenario": {
+                    "description": "연결할 시나리오 키",
+                    "type": "string",
+                    "example": "loan_scam"
+                }
+            }
+        },
+        "internal_handler.WSTicketResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "유효 기간 (초)",
+                    "type": "integer",
+                    "example": 30
+                },
+                "ticket": {
+                    "type": "string",
+                    "example": "b1Xo3k..."
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,288 @@
+/* JWT 서명 키 관리 (RS256/EdDSA, kid별 다중 키, 키 교체, JWKS 공개) */
+
+package auth
+
+import (
+	"cmp"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// RSA 키 최소 길이 (비트)
+const minRSAKeyBits = 2048
+
+// 서명 키가 준비되지 않은 경우 (Init 호출 전)
+var ErrNoSigningKey = errors.New("no signing key configured")
+
+// kid로 구분되는 서명 키, 공개 키만 있는 키는 검증에만 사용
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// 검증용 키 목록과 서명에 사용할 키
+type keySet struct {
+	keys   map[string]*signingKey
+	active *signingKey
+}
+
+var (
+	keysMu    sync.RWMutex
+	keys      *keySet
+	keyDir    string
+	activeKID string
+)
+
+// JWKS 응답의 공개 키 (RFC 7517, RSA는 n/e, Ed25519는 crv/x)
+type JWK struct {
+	Kty string `json:"kty" example:"OKP"`
+	Kid string `json:"kid" example:"2025-03"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"EdDSA"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty" example:"Ed25519"`
+	X   string `json:"x,omitempty" example:"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"`
+}
+
+// /.well-known/jwks.json 응답
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// 인증 설정 초기화, .env 로드 이후 main에서 호출
+// 토큰 유효 기간, 로그인 제한, 비밀번호 정책 설정을 읽고 서명 키를 로드
+// JWT_KEY_DIR의 <kid>.pem 파일을 서명 키로 사용하며, 설정하지 않으면 운영 모드(APP_ENV=production)에서는 오류
+// 개발 환경에서는 재시작 시 바뀌는 임시 Ed25519 키를 생성
+func Init() error {
+	accessTokenTTL = durationFromEnv("ACCESS_TOKEN_TTL", accessTokenTTL)
+	refreshTokenTTL = durationFromEnv("REFRESH_TOKEN_TTL", refreshTokenTTL)
+	loginLockoutThreshold = intFromEnv("LOGIN_LOCKOUT_THRESHOLD", loginLockoutThreshold)
+	loginLockoutDuration = durationFromEnv("LOGIN_LOCKOUT_DURATION", loginLockoutDuration)
+	passwordMinLength = intFromEnv("PASSWORD_MIN_LENGTH", passwordMinLength)
+	breachedFile := os.Getenv("PASSWORD_BREACHED_FILE")
+	if err := loadBreachedPasswords(cmp.Or(breachedFile, "breached-passwords.txt"), breachedFile != ""); err != nil {
+		return err
+	}
+	if os.Getenv("JWT_SECRET_KEY") != "" {
+		log.Println("Warning: JWT_SECRET_KEY is no longer used. Configure signing keys with JWT_KEY_DIR.")
+	}
+
+	keyDir = os.Getenv("JWT_KEY_DIR")
+	activeKID = os.Getenv("JWT_ACTIVE_KID")
+	if keyDir != "" {
+		return ReloadKeys()
+	}
+	if os.Getenv("APP_ENV") == "production" {
+		return errors.New("JWT_KEY_DIR must be set in production (APP_ENV=production)")
+	}
+
+	set, err := ephemeralKeySet()
+	if err != nil {
+		return err
+	}
+	setKeys(set)
+	log.Printf("Warning: JWT_KEY_DIR is not set. Using ephemeral signing key %s, tokens are invalidated on restart.", set.active.kid)
+	return nil
+}
+
+// JWT_KEY_DIR의 키를 다시 읽어 교체, 오류가 있으면 기존 키를 유지
+func ReloadKeys() error {
+	set, err := loadKeySet(keyDir, activeKID)
+	if err != nil {
+		return err
+	}
+	if previous := currentKeys(); previous == nil || previous.active.kid != set.active.kid || len(previous.keys) != len(set.keys) {
+		log.Printf("ReloadKeys(): Loaded %d key(s) from %s, signing with %s", len(set.keys), keyDir, set.active.kid)
+	}
+	setKeys(set)
+	return nil
+}
+
+// 키 디렉토리를 주기적으로 다시 읽음 (새 키 파일 추가, 이전 키 삭제로 재시작 없이 키 교체)
+func WatchKeys(ctx context.Context, interval time.Duration) {
+	if keyDir == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ReloadKeys(); err != nil {
+				log.Printf("auth.WatchKeys(): %v", err)
+			}
+		}
+	}
+}
+
+// 검증에 사용하는 모든 키의 공개 키 (kid순)
+func PublicJWKS() JWKSet {
+	set := currentKeys()
+	jwks := JWKSet{Keys: []JWK{}}
+	if set == nil {
+		return jwks
+	}
+	for _, key := range set.keys {
+		jwk := JWK{Kid: key.kid, Use: "sig", Alg: key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].Kid < jwks.Keys[j].Kid })
+	return jwks
+}
+
+func currentKeys() *keySet {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	return keys
+}
+
+func setKeys(set *keySet) {
+	keysMu.Lock()
+	keys = set
+	keysMu.Unlock()
+}
+
+// 키 디렉토리의 *.pem 파일 로드 (파일명이 kid)
+// 서명 키는 activeKID, 지정하지 않으면 개인 키 중 kid가 가장 뒤인 키 (예: 2025-01, 2025-04 중 2025-04)
+func loadKeySet(dir, activeKID string) (*keySet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key directory %s: %v", dir, err)
+	}
+
+	set := &keySet{keys: map[string]*signingKey{}}
+	var kids []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".pem") {
+			continue
+		}
+		kid := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		key, err := parseKeyFile(filepath.Join(dir, entry.Name()), kid)
+		if err != nil {
+			return nil, err
+		}
+		set.keys[kid] = key
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	if activeKID != "" {
+		key, ok := set.keys[activeKID]
+		if !ok || key.private == nil {
+			return nil, fmt.Errorf("JWT_ACTIVE_KID %s has no private key in %s", activeKID, dir)
+		}
+		set.active = key
+		return set, nil
+	}
+	for i := len(kids) - 1; i >= 0; i-- {
+		if key := set.keys[kids[i]]; key.private != nil {
+			set.active = key
+			return set, nil
+		}
+	}
+	return nil, fmt.Errorf("no private key found in %s", dir)
+}
+
+// PEM 파일 파싱 (PKCS#8/PKCS#1 개인 키, PKIX 공개 키)
+func parseKeyFile(path, kid string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM type %s", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	key, err := newSigningKey(kid, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return key, nil
+}
+
+// 키 종류에 따라 서명 방식 결정 (RSA: RS256, Ed25519: EdDSA)
+func newSigningKey(kid string, parsed any) (*signingKey, error) {
+	key := &signingKey{kid: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T (RSA or Ed25519 only)", parsed)
+	}
+	if public, ok := key.public.(*rsa.PublicKey); ok && public.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+	}
+	return key, nil
+}
+
+// 개발용 임시 Ed25519 키 (kid: dev-<무작위>)
+func ephemeralKeySet() (*keySet, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	key, err := newSigningKey("dev-"+hex.EncodeToString(suffix), private)
+	if err != nil {
+		return nil, err
+	}
+	return &keySet{keys: map[string]*signingKey{key.kid: key}, active: key}, nil
+}
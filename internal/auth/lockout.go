@@ -0,0 +1,63 @@
+/* 계정별 로그인 실패 제한 정책 (실패 횟수에 따른 대기 시간, 일시 잠금) */
+
+package auth
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// 대기 없이 허용하는 연속 실패 횟수, 이후 실패마다 대기 시간을 두 배로 늘림
+const (
+	loginFreeFailures = 3
+	loginMaxDelay     = 30 * time.Second
+)
+
+// 연속 실패 loginLockoutThreshold회에 loginLockoutDuration 동안 잠금 (LOGIN_LOCKOUT_THRESHOLD, LOGIN_LOCKOUT_DURATION으로 변경 가능)
+// 마지막 실패 후 loginLockoutDuration이 지나면 실패 횟수를 다시 셈
+var (
+	loginLockoutThreshold = 10
+	loginLockoutDuration  = 15 * time.Minute
+)
+
+// 연속 실패 횟수를 세는 기간
+func LoginFailureWindow() time.Duration {
+	return loginLockoutDuration
+}
+
+// 잠금 여부 (연속 실패 횟수가 잠금 기준 이상)
+func LoginLocked(failures int) bool {
+	return failures >= loginLockoutThreshold
+}
+
+// 연속 실패 후 다음 로그인 시도까지의 대기 시간 (3회까지 없음, 이후 1초부터 두 배씩 최대 30초, 잠금 기준 이상이면 잠금 기간)
+func LoginDelay(failures int) time.Duration {
+	switch {
+	case LoginLocked(failures):
+		return loginLockoutDuration
+	case failures < loginFreeFailures:
+		return 0
+	}
+	// 잠금 기준을 크게 설정해도 시프트가 넘치지 않도록 최대 대기 시간 도달 이후는 바로 반환
+	shift := failures - loginFreeFailures
+	if shift >= 5 {
+		return loginMaxDelay
+	}
+	return min(time.Second<<shift, loginMaxDelay)
+}
+
+// 환경 변수의 양의 정수 값, 없거나 잘못된 값이면 기본값
+func intFromEnv(name string, fallback int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		log.Printf("Warning: Invalid %s %q. Using default %d.", name, value, fallback)
+		return fallback
+	}
+	return number
+}
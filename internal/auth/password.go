@@ -0,0 +1,99 @@
+/* 비밀번호 정책 (길이, 유출된 비밀번호 목록) */
+
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// bcrypt가 처리할 수 있는 최대 길이 (바이트)
+const passwordMaxBytes = 72
+
+var (
+	ErrPasswordTooShort = errors.New("password is too short")
+	ErrPasswordTooLong  = errors.New("password is too long")
+	ErrPasswordBreached = errors.New("password has appeared in a data breach")
+	ErrPasswordUsername = errors.New("password must not be the username")
+)
+
+// 최소 길이 (PASSWORD_MIN_LENGTH로 변경 가능)
+var passwordMinLength = 10
+
+// 유출된 비밀번호의 SHA-1 해시 (대문자 hex), PASSWORD_BREACHED_FILE에서 로드
+var breachedPasswords map[string]struct{}
+
+// 비밀번호 정책 검사, 정책 위반 사유를 오류로 반환
+func ValidatePassword(password, username string) error {
+	if utf8.RuneCountInString(password) < passwordMinLength {
+		return ErrPasswordTooShort
+	}
+	if len(password) > passwordMaxBytes {
+		return ErrPasswordTooLong
+	}
+	if strings.EqualFold(password, username) {
+		return ErrPasswordUsername
+	}
+	if _, found := breachedPasswords[passwordHash(password)]; found {
+		return ErrPasswordBreached
+	}
+	return nil
+}
+
+// 최소 길이 (오류 메시지 안내용)
+func PasswordMinLength() int {
+	return passwordMinLength
+}
+
+// 유출된 비밀번호 목록 로드, 한 줄에 하나씩 비밀번호 원문 또는 SHA-1 해시 (Have I Been Pwned 형식의 "해시:횟수" 포함)
+// path를 지정하지 않았고 기본 파일이 없으면 목록 없이 길이만 검사
+func loadBreachedPasswords(path string, required bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to open breached password list: %v", err)
+	}
+	defer file.Close()
+
+	hashes := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if hash, _, _ := strings.Cut(line, ":"); isSHA1Hex(hash) {
+			hashes[strings.ToUpper(hash)] = struct{}{}
+		} else {
+			hashes[passwordHash(line)] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read breached password list: %v", err)
+	}
+	breachedPasswords = hashes
+	log.Printf("Loaded %d breached passwords from %s", len(hashes), path)
+	return nil
+}
+
+func passwordHash(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func isSHA1Hex(value string) bool {
+	if len(value) != sha1.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
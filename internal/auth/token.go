@@ -3,70 +3,160 @@
 package auth
 
 import (
+	"errors"
 	"log"
 	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
-var jwtKey []byte
+// 액세스 토큰은 짧게 유지하고 리프레시 토큰으로 재발급 (ACCESS_TOKEN_TTL, REFRESH_TOKEN_TTL로 변경 가능)
+var (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 14 * 24 * time.Hour
+)
+
+// 로그아웃 등으로 폐기된 토큰 (jti가 폐기 목록에 있거나 비밀번호 변경 전에 발급됨)
+var ErrTokenRevoked = errors.New("token has been revoked")
 
-// JWT 키 초기화, 런타임에 자동 호출
-func init() {
-	jwtKey = []byte(os.Getenv("JWT_SECRET_KEY"))
-	log.Printf("JWT Key: %s", jwtKey)
-	if len(jwtKey) == 0 {
-		jwtKey = []byte("default_secret_key") // 기본 키 설정 (권장하지 않음)
-		log.Println("Warning: JWT_SECRET_KEY environment variable is not set. Using default key.")
+// 토큰 폐기 여부 조회 함수, storage 패키지가 DB 초기화 시 등록
+var revocationLookup func(jti, username string, issuedAt time.Time) (bool, error)
+
+// 환경 변수의 기간 값 (예: 15m, 336h), 없거나 잘못된 값이면 기본값
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Warning: Invalid %s %q. Using default %s.", name, value, fallback)
+		return fallback
 	}
+	return duration
+}
+
+// 액세스 토큰 유효 기간
+func AccessTokenTTL() time.Duration {
+	return accessTokenTTL
+}
+
+// 리프레시 토큰 유효 기간
+func RefreshTokenTTL() time.Duration {
+	return refreshTokenTTL
 }
 
-// Claims 구조체 정의, JWT 페이로드에 사용자명 포함
+// 토큰 폐기 여부 조회 함수 등록 (jti 폐기 목록, 사용자의 세션 무효화 시각)
+func RegisterRevocationLookup(lookup func(jti, username string, issuedAt time.Time) (bool, error)) {
+	revocationLookup = lookup
+}
+
+// 토큰 종류 (sub), 2단계 인증 대기 토큰을 액세스 토큰으로 사용할 수 없도록 구분
+const (
+	accessTokenSubject  = "user_auth_token"
+	mfaChallengeSubject = "mfa_challenge"
+)
+
+// 비밀번호 확인 후 2단계 인증 코드 입력까지 허용하는 시간
+const mfaChallengeTTL = 5 * time.Minute
+
+// Claims 구조체 정의, JWT 페이로드에 사용자명과 역할 포함
 type Claims struct {
 	Username string `json:"username"`
+	Role     string `json:"role"`          // 역할 도입 이전에 발급된 토큰은 빈 값 (trainee로 취급)
+	MFA      bool   `json:"mfa,omitempty"` // 2단계 인증(TOTP)을 거쳐 로그인한 경우
 	jwt.RegisteredClaims
 }
 
-// JWT 토큰 생성
-func GenerateToken(username string) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
-	claims := &Claims{
-		Username: username,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Issuer:    "PishingSimulator-api",
-			Subject:   "user_auth_token",
-		},
+// JWT 액세스 토큰 생성, 폐기 처리를 위해 토큰마다 고유한 jti 부여
+func GenerateToken(username string, role string, mfa bool) (string, error) {
+	return signToken(&Claims{Username: username, Role: role, MFA: mfa}, accessTokenSubject, accessTokenTTL)
+}
+
+// 2단계 인증 대기 토큰 생성 (비밀번호 확인 후 발급, /login/mfa에서 코드와 함께 사용)
+func GenerateMFAChallenge(username string) (string, error) {
+	return signToken(&Claims{Username: username}, mfaChallengeSubject, mfaChallengeTTL)
+}
+
+// 2단계 인증 대기 토큰 유효 기간
+func MFAChallengeTTL() time.Duration {
+	return mfaChallengeTTL
+}
+
+func signToken(claims *Claims, subject string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Issuer:    "PishingSimulator-api",
+		Subject:   subject,
 	}
 
-	// 토큰 문자열 생성 및 서명
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
+	// 현재 서명 키로 서명, 검증 측이 키를 찾을 수 있도록 헤더에 kid 포함
+	set := currentKeys()
+	if set == nil {
+		return "", ErrNoSigningKey
+	}
+	token := jwt.NewWithClaims(set.active.method, claims)
+	token.Header["kid"] = set.active.kid
+	tokenString, err := token.SignedString(set.active.private)
 	if err != nil {
 		return "", err
 	}
 	return tokenString, nil
 }
 
-// JWT 토큰 검증
+// JWT 액세스 토큰 검증 (서명, 만료, 폐기 여부)
 func ValidateToken(tokenString string) (*Claims, error) {
+	return parseToken(tokenString, accessTokenSubject)
+}
+
+// 2단계 인증 대기 토큰 검증
+func ValidateMFAChallenge(tokenString string) (*Claims, error) {
+	return parseToken(tokenString, mfaChallengeSubject)
+}
+
+func parseToken(tokenString, subject string) (*Claims, error) {
 	claims := &Claims{}
 	// 토큰 파싱 및 검증
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		// alg: none 공격를 위한 서명 방법 검증
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrTokenMalformed
+		set := currentKeys()
+		if set == nil {
+			return nil, ErrNoSigningKey
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, ok := set.keys[kid]
+		if !ok {
+			return nil, jwt.ErrTokenUnverifiable
 		}
-		return jwtKey, nil
+		// alg: none 및 알고리즘 혼동 공격 방지를 위해 키 종류에 맞는 서명 방법만 허용
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return key.public, nil
 	})
 	if err != nil {
 		return nil, err
 	}
 	// 만약을 위한 토큰 유효성 재검사
-	if !token.Valid {
+	if !token.Valid || claims.Subject != subject {
 		return nil, jwt.ErrTokenInvalidClaims
 	}
+	if revocationLookup != nil {
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+		revoked, err := revocationLookup(claims.ID, claims.Username, issuedAt)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
 	return claims, nil
 }
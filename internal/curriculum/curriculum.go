@@ -0,0 +1,58 @@
+/**
+* Name: 			curriculum.go
+* Description: 		훈련 커리큘럼 로드 및 현재 커리큘럼 관리
+* Workflow: 		커리큘럼 파일(YAML/JSON)이 있으면 로드 및 검증, 없으면 기본 커리큘럼 사용
+ */
+
+package curriculum
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	mu      sync.RWMutex
+	current = models.DefaultCurriculum()
+)
+
+// 현재 적용 중인 커리큘럼
+func Current() models.Curriculum {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+func SetCurrent(c models.Curriculum) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = c
+}
+
+// 커리큘럼 파일 로드 및 검증, 파일이 없으면 os.ErrNotExist
+func Load(path string) (models.Curriculum, error) {
+	var c models.Curriculum
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(data, &c)
+	} else {
+		err = yaml.UnmarshalStrict(data, &c)
+	}
+	if err != nil {
+		return c, fmt.Errorf("parse error: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		return c, err
+	}
+	return c, nil
+}
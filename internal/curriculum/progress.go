@@ -0,0 +1,165 @@
+/**
+* Name: 			progress.go
+* Description: 		사용자 진행 상황 계산
+* Workflow: 		모듈 순서대로 시나리오별 통과 여부 판정, 이전 모듈 미완료 시 잠금, 커리큘럼에 없는 시나리오는 선택 과정으로 분류
+ */
+
+package curriculum
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+)
+
+// 시나리오 진행 상황
+type ScenarioStatus struct {
+	Key          string `json:"key" example:"delivery_notification"`
+	Name         string `json:"name" example:"Delivery Notification"`
+	Difficulty   string `json:"difficulty" example:"easy"`
+	Status       string `json:"status" example:"in_progress"` // completed | in_progress | available | locked
+	Attempts     int    `json:"attempts" example:"2"`
+	BestScore    *int   `json:"best_score,omitempty" example:"65"` // 평가를 통과한 세션 중 최고 점수
+	LastScore    *int   `json:"last_score,omitempty" example:"40"`
+	LastRecordID *int   `json:"last_record_id,omitempty" example:"12"`
+}
+
+// 모듈 진행 상황
+type ModuleStatus struct {
+	Key         string           `json:"key" example:"basics"`
+	Name        string           `json:"name" example:"기초: 생활 속 사칭"`
+	Description string           `json:"description,omitempty"`
+	PassScore   int              `json:"pass_score" example:"70"`
+	Status      string           `json:"status" example:"in_progress"`
+	Scenarios   []ScenarioStatus `json:"scenarios"`
+}
+
+// 상태별 시나리오 수
+type Summary struct {
+	Completed  int `json:"completed" example:"1"`
+	InProgress int `json:"in_progress" example:"1"`
+	Available  int `json:"available" example:"1"`
+	Locked     int `json:"locked" example:"2"`
+}
+
+// 사용자 진행 상황 전체
+type Overview struct {
+	Modules   []ModuleStatus   `json:"modules"`
+	Electives []ScenarioStatus `json:"electives"` // 커리큘럼에 포함되지 않은 시나리오 (항상 시작 가능)
+	Summary   Summary          `json:"summary"`
+}
+
+// 활성 시나리오 목록과 사용자 진행 기록으로 진행 상황 계산
+// 비활성이거나 존재하지 않는 시나리오는 모듈 완료 조건에서 제외
+func Build(c models.Curriculum, scenarios []models.Scenario, progress map[string]models.ScenarioProgress) Overview {
+	active := make(map[string]models.Scenario, len(scenarios))
+	for _, s := range scenarios {
+		active[s.Key] = s
+	}
+
+	overview := Overview{Modules: []ModuleStatus{}, Electives: []ScenarioStatus{}}
+	assigned := make(map[string]bool)
+	unlocked := true
+	for _, module := range c.Modules {
+		status := ModuleStatus{
+			Key:         module.Key,
+			Name:        module.Name,
+			Description: module.Description,
+			PassScore:   module.PassScore,
+			Scenarios:   []ScenarioStatus{},
+		}
+		completed, attempted := true, false
+		for _, key := range module.Scenarios {
+			assigned[key] = true
+			scenario, exists := active[key]
+			if !exists {
+				continue
+			}
+			s := newScenarioStatus(scenario, progress[key], module.PassScore, unlocked)
+			if s.Status != models.ProgressCompleted {
+				completed = false
+			}
+			if s.Attempts > 0 {
+				attempted = true
+			}
+			status.Scenarios = append(status.Scenarios, s)
+			overview.Summary.add(s.Status)
+		}
+
+		switch {
+		case completed:
+			status.Status = models.ProgressCompleted
+		case !unlocked:
+			status.Status = models.ProgressLocked
+		case attempted:
+			status.Status = models.ProgressInProgress
+		default:
+			status.Status = models.ProgressAvailable
+		}
+		overview.Modules = append(overview.Modules, status)
+		// 이전 모듈을 모두 완료해야 다음 모듈이 열림
+		unlocked = unlocked && completed
+	}
+
+	for _, scenario := range scenarios {
+		if assigned[scenario.Key] {
+			continue
+		}
+		s := newScenarioStatus(scenario, progress[scenario.Key], 0, true)
+		overview.Electives = append(overview.Electives, s)
+		overview.Summary.add(s.Status)
+	}
+	return overview
+}
+
+// 시나리오의 진행 상태, 진행 상황에 없는 시나리오는 available
+func (o Overview) StatusOf(scenarioKey string) string {
+	for _, module := range o.Modules {
+		for _, s := range module.Scenarios {
+			if s.Key == scenarioKey {
+				return s.Status
+			}
+		}
+	}
+	for _, s := range o.Electives {
+		if s.Key == scenarioKey {
+			return s.Status
+		}
+	}
+	return models.ProgressAvailable
+}
+
+// 통과 기준 점수 이상인 통과 세션이 있으면 완료 (잠긴 모듈이라도 이미 통과한 시나리오는 완료로 표시)
+func newScenarioStatus(scenario models.Scenario, p models.ScenarioProgress, passScore int, unlocked bool) ScenarioStatus {
+	s := ScenarioStatus{
+		Key:          scenario.Key,
+		Name:         scenario.Name,
+		Difficulty:   scenario.Difficulty,
+		Attempts:     p.Attempts,
+		BestScore:    p.BestScore,
+		LastScore:    p.LastScore,
+		LastRecordID: p.LastRecordID,
+	}
+	switch {
+	case p.BestScore != nil && *p.BestScore >= passScore:
+		s.Status = models.ProgressCompleted
+	case !unlocked:
+		s.Status = models.ProgressLocked
+	case p.Attempts > 0:
+		s.Status = models.ProgressInProgress
+	default:
+		s.Status = models.ProgressAvailable
+	}
+	return s
+}
+
+func (s *Summary) add(status string) {
+	switch status {
+	case models.ProgressCompleted:
+		s.Completed++
+	case models.ProgressInProgress:
+		s.InProgress++
+	case models.ProgressAvailable:
+		s.Available++
+	case models.ProgressLocked:
+		s.Locked++
+	}
+}
@@ -0,0 +1,309 @@
+/**
+* Name: 			detectors.go
+* Description: 		평가 항목별 탐지기 정의
+* Workflow: 		pii 탐지(형식/체크섬 검증), 정규식 및 직전 사기범 발화의 요청 내용(문맥)으로 사용자 발화의 정보 유출, 송금 동의, 발신자 확인 여부 판단
+ */
+
+package evaluation
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/pii"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// 비밀번호를 직접 말하는 경우
+	passwordStatementPattern = regexp.MustCompile(`(?:비밀번호|비번|패스워드)\s*(?:는|은|이|가)?\s*(?:요)?\s*([0-9A-Za-z!@#$%^&*]{4,})`)
+	// 비밀번호 요청 문맥에서의 답변 토큰 (숫자 포함 4자 이상)
+	passwordTokenPattern = regexp.MustCompile(`[0-9A-Za-z!@#$%^&*]*[0-9][0-9A-Za-z!@#$%^&*]*`)
+	// 도로명/지번 주소: 시·도, 시·군·구, 로·길·동 + 번지
+	addressPattern = regexp.MustCompile(`[가-힣]+(?:특별시|광역시|특별자치시|특별자치도|시|도)\s*[가-힣]+(?:시|군|구)\s*(?:[가-힣]+(?:구)\s*)?[가-힣0-9]+(?:로|길|동|읍|면|리)\s*\d+(?:-\d+)?(?:\s*번지)?`)
+	// 앞뒤에 다른 숫자가 붙지 않은 신고 번호 (112 경찰, 1332 금융감독원)
+	callbackNumberPattern = regexp.MustCompile(`(?:^|[^0-9])(112|1332)(?:[^0-9]|$)`)
+)
+
+// 직전 사기범 발화의 요청 내용
+var (
+	otpRequestKeywords      = []string{"인증번호", "인증 번호", "otp", "보안카드", "확인번호", "승인번호", "문자로"}
+	passwordRequestKeywords = []string{"비밀번호", "비번", "패스워드", "password"}
+	accountRequestKeywords  = []string{"계좌", "통장"}
+)
+
+// 사용자 발화의 행동 키워드
+var (
+	transferKeywords = []string{"이체했", "이체할게", "이체 할게", "송금했", "송금할게", "보냈어", "보냈습니다", "보낼게", "입금했", "입금할게", "옮겼", "옮길게", "이체 완료", "이체완료"}
+	callbackKeywords = []string{"다시 전화", "다시전화", "대표번호", "끊고 확인", "직접 확인", "직접 전화", "직접 방문", "지점에 확인", "은행에 확인", "은행에 전화", "콜백", "확인해 보고", "확인해보고"}
+)
+
+// 송금 키워드를 부정하는 표현, 키워드와 붙어 있거나 바로 앞뒤 단어일 때만 부정으로 판단
+// 예: "아직 안 보냈어요", "안보냈어요", "이체 완료 안 했어요", "보낼게요? 싫어요"
+var (
+	transferNegationWords    = []string{"안", "못"} // 키워드 바로 앞 또는 뒤 단어
+	transferNegationSuffixes = []string{"않", "싫"} // 키워드 뒤에 이어지는 부분
+)
+
+// 기본 탐지기 목록
+func DefaultDetectors() []Detector {
+	return []Detector{
+		{
+			Category:    models.FindingResidentNumber,
+			Kind:        models.FindingKindRisk,
+			Points:      -30,
+			Critical:    true,
+			Sensitive:   true,
+			Description: "주민등록번호를 알려주었습니다.",
+			Find:        findPII(pii.KindResidentNumber),
+		},
+		{
+			Category:    models.FindingCardNumber,
+			Kind:        models.FindingKindRisk,
+			Points:      -30,
+			Critical:    true,
+			Sensitive:   true,
+			Description: "카드번호를 알려주었습니다.",
+			Find:        findPII(pii.KindCardNumber),
+		},
+		{
+			Category:    models.FindingAccountNumber,
+			Kind:        models.FindingKindRisk,
+			Points:      -20,
+			Sensitive:   true,
+			Description: "계좌번호를 알려주었습니다.",
+			Find:        findAccountNumber,
+		},
+		{
+			Category:    models.FindingPhoneNumber,
+			Kind:        models.FindingKindRisk,
+			Points:      -5,
+			Sensitive:   true,
+			Description: "전화번호를 알려주었습니다.",
+			Find:        findPII(pii.KindPhoneNumber),
+		},
+		{
+			Category:    models.FindingOTP,
+			Kind:        models.FindingKindRisk,
+			Points:      -30,
+			Critical:    true,
+			Sensitive:   true,
+			Description: "인증번호(OTP)를 알려주었습니다.",
+			Find:        findNumberAfterRequest(otpRequestKeywords, 4, 8),
+		},
+		{
+			Category:    models.FindingPassword,
+			Kind:        models.FindingKindRisk,
+			Points:      -30,
+			Critical:    true,
+			Sensitive:   true,
+			Description: "비밀번호를 알려주었습니다.",
+			Find:        findPassword,
+		},
+		{
+			Category:    models.FindingAddress,
+			Kind:        models.FindingKindRisk,
+			Points:      -10,
+			Sensitive:   true,
+			Description: "집 주소를 알려주었습니다.",
+			Find:        findPattern(addressPattern),
+		},
+		{
+			Category:    models.FindingMoneyTransfer,
+			Kind:        models.FindingKindRisk,
+			Points:      -40,
+			Critical:    true,
+			Description: "송금(이체)에 동의하거나 송금했다고 답했습니다.",
+			Find:        findMoneyTransfer,
+		},
+		{
+			Category:    models.FindingCallbackVerification,
+			Kind:        models.FindingKindGood,
+			Points:      10,
+			Description: "공식 번호로 다시 전화하는 등 발신자를 직접 확인하려 했습니다.",
+			Find:        findCallbackVerification,
+		},
+	}
+}
+
+// 정규식의 첫 번째 캡처 그룹 구간 반환
+func findPattern(pattern *regexp.Regexp) func(conv *Conversation, i int) [][2]int {
+	return func(conv *Conversation, i int) [][2]int {
+		return matchGroups(pattern, conv.Turns[i].Text)
+	}
+}
+
+// pii 탐지 결과 중 해당 종류의 구간 반환
+func findPII(kind string) func(conv *Conversation, i int) [][2]int {
+	return func(conv *Conversation, i int) [][2]int {
+		var spans [][2]int
+		for _, m := range conv.PII(i) {
+			if m.Kind == kind {
+				spans = append(spans, [2]int{m.Start, m.End})
+			}
+		}
+		return spans
+	}
+}
+
+// 직전 사기범 발화가 해당 정보를 요청한 경우에만 형식 미상 숫자열(한글 숫자 포함) 검사
+func findNumberAfterRequest(requestKeywords []string, minDigits, maxDigits int) func(conv *Conversation, i int) [][2]int {
+	return func(conv *Conversation, i int) [][2]int {
+		if !containsAny(conv.PrecedingAssistant(i), requestKeywords) {
+			return nil
+		}
+		return numberSpans(conv.PII(i), minDigits, maxDigits)
+	}
+}
+
+func numberSpans(matches []pii.Match, minDigits, maxDigits int) [][2]int {
+	var spans [][2]int
+	for _, m := range matches {
+		if m.Kind == pii.KindNumber && len(m.Digits) >= minDigits && len(m.Digits) <= maxDigits {
+			spans = append(spans, [2]int{m.Start, m.End})
+		}
+	}
+	return spans
+}
+
+// 발화 전체를 근거로 하는 키워드 탐지
+func findKeywords(keywords []string) func(conv *Conversation, i int) [][2]int {
+	return func(conv *Conversation, i int) [][2]int {
+		text := conv.Turns[i].Text
+		if !containsAny(text, keywords) {
+			return nil
+		}
+		return [][2]int{{0, len(text)}}
+	}
+}
+
+// 은행별 형식과 일치하는 계좌번호, 계좌 요청 문맥에서는 붙여 말한 10~14자리 숫자도 포함
+func findAccountNumber(conv *Conversation, i int) [][2]int {
+	spans := findPII(pii.KindAccountNumber)(conv, i)
+	if containsAny(conv.PrecedingAssistant(i), accountRequestKeywords) {
+		spans = append(spans, numberSpans(conv.PII(i), 10, 14)...)
+	}
+	return spans
+}
+
+func findPassword(conv *Conversation, i int) [][2]int {
+	text := conv.Turns[i].Text
+	if spans := matchGroups(passwordStatementPattern, text); len(spans) > 0 {
+		return spans
+	}
+	if !containsAny(conv.PrecedingAssistant(i), passwordRequestKeywords) {
+		return nil
+	}
+	var spans [][2]int
+	for _, loc := range passwordTokenPattern.FindAllStringIndex(text, -1) {
+		if loc[1]-loc[0] >= 4 {
+			spans = append(spans, [2]int{loc[0], loc[1]})
+		}
+	}
+	return spans
+}
+
+// 발신자 확인 키워드 또는 신고 번호(112, 1332)를 말한 발화
+// 신고 번호는 단독 숫자일 때만 인정하고, 전화번호 등 더 긴 숫자열의 일부는 제외 ("010-1123-4567")
+func findCallbackVerification(conv *Conversation, i int) [][2]int {
+	if spans := findKeywords(callbackKeywords)(conv, i); len(spans) > 0 {
+		return spans
+	}
+	matches := conv.PII(i)
+	var spans [][2]int
+	for _, span := range matchGroups(callbackNumberPattern, conv.Turns[i].Text) {
+		if !withinLongerNumber(matches, span) {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// span이 다른 숫자를 포함한 pii 탐지 구간 안에 있는지 검사
+func withinLongerNumber(matches []pii.Match, span [2]int) bool {
+	for _, m := range matches {
+		if m.Start <= span[0] && span[1] <= m.End && len(m.Digits) > span[1]-span[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// 부정되지 않은 송금 완료/동의 발화
+func findMoneyTransfer(conv *Conversation, i int) [][2]int {
+	text := strings.ToLower(conv.Turns[i].Text)
+	for _, keyword := range transferKeywords {
+		for offset := 0; ; {
+			start := strings.Index(text[offset:], keyword)
+			if start < 0 {
+				break
+			}
+			start += offset
+			offset = start + len(keyword)
+			if !transferNegated(text, start, offset) {
+				return [][2]int{{0, len(text)}}
+			}
+		}
+	}
+	return nil
+}
+
+// text[start:end]의 송금 키워드가 부정 표현과 함께 쓰였는지 검사 (다른 절의 "안", "싫"은 무시)
+func transferNegated(text string, start, end int) bool {
+	before := strings.Fields(text[:start])
+	after := strings.Fields(text[end:])
+	// 키워드와 같은 단어에 붙은 앞부분 ("안보냈어요")
+	if r, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && !unicode.IsSpace(r) && len(before) > 0 {
+		glued := before[len(before)-1]
+		if slices.ContainsFunc(transferNegationWords, func(word string) bool { return strings.HasSuffix(glued, word) }) {
+			return true
+		}
+		before = before[:len(before)-1]
+	}
+	if len(before) > 0 && slices.Contains(transferNegationWords, trimPunct(before[len(before)-1])) {
+		return true
+	}
+	// 키워드와 같은 단어에 붙은 뒷부분 ("보냈지않아요")
+	if r, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && !unicode.IsSpace(r) && len(after) > 0 {
+		if containsAny(after[0], transferNegationSuffixes) {
+			return true
+		}
+		after = after[1:]
+	}
+	if len(after) > 0 {
+		next := trimPunct(after[0])
+		if slices.Contains(transferNegationWords, next) || slices.ContainsFunc(transferNegationSuffixes, func(suffix string) bool { return strings.HasPrefix(next, suffix) }) {
+			return true
+		}
+	}
+	return false
+}
+
+func trimPunct(word string) string {
+	return strings.TrimFunc(word, func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) })
+}
+
+func matchGroups(pattern *regexp.Regexp, text string) [][2]int {
+	var spans [][2]int
+	for _, loc := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		if len(loc) >= 4 && loc[2] >= 0 {
+			spans = append(spans, [2]int{loc[2], loc[3]})
+		} else {
+			spans = append(spans, [2]int{loc[0], loc[1]})
+		}
+	}
+	return spans
+}
+
+// 소문자로 변환한 텍스트에 키워드가 포함되는지 검사
+func containsAny(text string, keywords []string) bool {
+	lower := strings.ToLower(text)
+	for _, keyword := range keywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
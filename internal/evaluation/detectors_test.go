@@ -0,0 +1,80 @@
+package evaluation
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"slices"
+	"testing"
+)
+
+// 직전 사기범 발화와 사용자 발화로 대화를 만들고 해당 항목 탐지기가 찾은 구간의 원문 반환
+func detect(t *testing.T, category, assistant, user string) []string {
+	t.Helper()
+	var detector *Detector
+	for _, d := range DefaultDetectors() {
+		if d.Category == category {
+			detector = &d
+			break
+		}
+	}
+	if detector == nil {
+		t.Fatalf("no detector for %s", category)
+	}
+	conv := &Conversation{Turns: []models.TranscriptTurn{
+		{Speaker: models.SpeakerAssistant, Text: assistant},
+		{Speaker: models.SpeakerUser, Text: user},
+	}}
+	var result []string
+	for _, span := range detector.Find(conv, 1) {
+		result = append(result, user[span[0]:span[1]])
+	}
+	return result
+}
+
+func TestDetectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		category  string
+		assistant string
+		user      string
+		want      []string
+	}{
+		// 발신자 확인
+		{"callback keyword", models.FindingCallbackVerification, "", "끊고 대표번호로 다시 전화할게요",
+			[]string{"끊고 대표번호로 다시 전화할게요"}},
+		{"police number", models.FindingCallbackVerification, "", "112에 신고할게요", []string{"112"}},
+		{"fss number", models.FindingCallbackVerification, "", "금감원 1332에 물어볼게요", []string{"1332"}},
+		{"callback number in sentence", models.FindingCallbackVerification, "", "그럼 112로 확인해 볼게요.", []string{"112"}},
+		{"phone number containing 112", models.FindingCallbackVerification, "", "제 번호는 010-1123-4567이에요", nil},
+		{"number split around 112", models.FindingCallbackVerification, "", "010-112-3456이요", nil},
+		{"number starting with 1332", models.FindingCallbackVerification, "", "13325번 버스 타요", nil},
+		{"otp ending with 112", models.FindingCallbackVerification, "인증번호 불러 주세요", "482112", nil},
+
+		// 송금
+		{"transferred", models.FindingMoneyTransfer, "", "방금 보냈어요", []string{"방금 보냈어요"}},
+		{"agrees to transfer", models.FindingMoneyTransfer, "", "지금 이체할게요", []string{"지금 이체할게요"}},
+		{"negation in another clause", models.FindingMoneyTransfer, "", "안 늦게 바로 보낼게요", []string{"안 늦게 바로 보낼게요"}},
+		{"dislike in another clause", models.FindingMoneyTransfer, "", "싫은 소리 듣기 전에 송금했어요", []string{"싫은 소리 듣기 전에 송금했어요"}},
+		{"not yet sent", models.FindingMoneyTransfer, "", "아직 안 보냈어요", nil},
+		{"negation glued to keyword", models.FindingMoneyTransfer, "", "안보냈어요", nil},
+		{"could not send", models.FindingMoneyTransfer, "", "못 보냈는데요", nil},
+		{"negation after keyword", models.FindingMoneyTransfer, "", "이체 완료 안 했어요", nil},
+		{"refuses after keyword", models.FindingMoneyTransfer, "", "보낼게요? 싫어요", nil},
+		{"no transfer keyword", models.FindingMoneyTransfer, "", "계좌가 어디예요?", nil},
+
+		// 정보 유출
+		{"otp after request", models.FindingOTP, "문자로 온 인증번호 불러 주세요", "482913이요", []string{"482913"}},
+		{"number without request", models.FindingOTP, "잠시만 기다려 주세요", "482913이요", nil},
+		{"phone number", models.FindingPhoneNumber, "", "010-1234-5678이요", []string{"010-1234-5678"}},
+		{"account after request", models.FindingAccountNumber, "계좌번호 알려 주세요", "1234567890123이요", []string{"1234567890123"}},
+		{"password statement", models.FindingPassword, "", "비밀번호는 1234예요", []string{"1234"}},
+		{"password after request", models.FindingPassword, "비밀번호 네 자리 말씀해 주세요", "0000이요", []string{"0000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detect(t, tt.category, tt.assistant, tt.user)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("%s(%q)\n got  %q\n want %q", tt.category, tt.user, got, tt.want)
+			}
+		})
+	}
+}
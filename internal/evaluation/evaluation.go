@@ -0,0 +1,136 @@
+/**
+* Name: 			evaluation.go
+* Description: 		세션 대화 기록 기반 자동 평가
+* Workflow: 		사용자 발화마다 탐지기 실행, 항목별 근거 수집 및 마스킹, 점수 산정 후 리포트 생성
+ */
+
+package evaluation
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/pii"
+	"time"
+)
+
+// 리포트 형식 버전, 탐지 규칙이나 점수 산정 방식이 바뀌면 증가
+const ReportVersion = 3
+
+// 근거 인용 시 마스킹하지 않고 남기는 앞뒤 글자 수
+const evidenceKeep = 2
+
+// 감점 없이 시작하는 기본 점수
+const baseScore = 100
+
+// 대화 기록의 한 사용자 발화에서 항목을 찾는 탐지기
+type Detector struct {
+	Category    string
+	Kind        string
+	Points      int    // 항목 발견 시 점수 반영값 (여러 번 발견되어도 한 번만 반영)
+	Critical    bool   // 발견 시 통과 불가
+	Sensitive   bool   // 근거 인용 시 일치 구간 마스킹
+	Description string // 리포트에 표시할 설명
+
+	// i번째 턴(사용자 발화)에서 일치하는 구간 목록 반환, [start, end) 바이트 오프셋
+	Find func(conv *Conversation, i int) [][2]int
+}
+
+// 탐지기가 참고하는 대화 기록
+type Conversation struct {
+	Turns []models.TranscriptTurn
+
+	pii map[int][]pii.Match // 턴별 pii 탐지 결과 캐시
+}
+
+// i번째 턴의 pii 탐지 결과, 탐지기 간 공유
+func (c *Conversation) PII(i int) []pii.Match {
+	if c.pii == nil {
+		c.pii = make(map[int][]pii.Match)
+	}
+	matches, ok := c.pii[i]
+	if !ok {
+		matches = pii.Find(c.Turns[i].Text)
+		c.pii[i] = matches
+	}
+	return matches
+}
+
+// i번째 턴 직전의 사기범 발화, 없으면 빈 문자열
+func (c *Conversation) PrecedingAssistant(i int) string {
+	for j := i - 1; j >= 0; j-- {
+		if c.Turns[j].Speaker == models.SpeakerAssistant {
+			return c.Turns[j].Text
+		}
+	}
+	return ""
+}
+
+// 기본 탐지기로 평가
+func Evaluate(record models.Record, turns []models.TranscriptTurn) models.EvaluationReport {
+	return EvaluateWith(DefaultDetectors(), record, turns)
+}
+
+// 지정한 탐지기 목록으로 대화 기록을 평가하고 리포트 생성
+func EvaluateWith(detectors []Detector, record models.Record, turns []models.TranscriptTurn) models.EvaluationReport {
+	conv := &Conversation{Turns: turns}
+	report := models.EvaluationReport{
+		RecordID:    record.ID,
+		Version:     ReportVersion,
+		Score:       baseScore,
+		Passed:      true,
+		Outcome:     record.Outcome,
+		Findings:    []models.Finding{},
+		Coach:       record.Coach,
+		HintsShown:  shownHints(turns),
+		GeneratedAt: time.Now(),
+	}
+
+	for _, detector := range detectors {
+		var evidence []models.Evidence
+		for i, turn := range turns {
+			if turn.Speaker != models.SpeakerUser {
+				continue
+			}
+			spans := detector.Find(conv, i)
+			if len(spans) == 0 {
+				continue
+			}
+			quote := turn.Text
+			if detector.Sensitive {
+				quote = pii.MaskSpans(quote, spans, evidenceKeep)
+			}
+			evidence = append(evidence, models.Evidence{TurnSeq: turn.Seq, StartMs: turn.StartMs, Quote: quote})
+		}
+		if len(evidence) == 0 {
+			continue
+		}
+
+		report.Findings = append(report.Findings, models.Finding{
+			Category:    detector.Category,
+			Kind:        detector.Kind,
+			Points:      detector.Points,
+			Description: detector.Description,
+			Evidence:    evidence,
+		})
+		report.Score += detector.Points
+		if detector.Critical {
+			report.Passed = false
+		}
+	}
+
+	report.Score = min(max(report.Score, 0), baseScore)
+	if record.Outcome == models.OutcomeScamSucceeded {
+		report.Passed = false
+	}
+	return report
+}
+
+// 코치 모드에서 표시된 힌트 목록 (점수에는 반영하지 않음)
+func shownHints(turns []models.TranscriptTurn) []models.ShownHint {
+	hints := []models.ShownHint{}
+	for _, turn := range turns {
+		if turn.Speaker == models.SpeakerCoach {
+			hints = append(hints, models.ShownHint{HintID: turn.HintID, TurnSeq: turn.Seq, StartMs: turn.StartMs, Message: turn.Text})
+		}
+	}
+	return hints
+}
@@ -2,8 +2,9 @@ package handler
 
 import (
 	"PishingSimulator_SecurityProject/internal/archiver"
+	"PishingSimulator_SecurityProject/internal/llm"
 	"PishingSimulator_SecurityProject/internal/models"
-	"PishingSimulator_SecurityProject/internal/storage"
+	"PishingSimulator_SecurityProject/internal/session"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -12,29 +13,31 @@ import (
 	"log"
 	"os"
 	"sync"
+	"sync/atomic"
 
-	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
-func manageAudioSession(conn *websocket.Conn, user models.User, parentCtx context.Context, scenarioKey string) {
+func manageAudioSession(conn *websocket.Conn, user models.User, engine llm.ConversationEngine, parentCtx context.Context, scenario models.Scenario, sessionID string) {
 	defer conn.Close()
 	log.Printf("Audio session started for user: %s", user.Username)
 
-	sessionID := uuid.New().String()
-
 	// context
 	ctx, cancel := context.WithCancel(parentCtx)
 	defer cancel()
 
 	sessionStartTime := time.Now()
+	state := session.NewStateMachine()
+	transcript := session.NewTranscript(sessionStartTime)
+	var clientEnded atomic.Bool
+	var orchestrateErr error
 
 	// WaitGroup for goroutines
 	var wg sync.WaitGroup
 	wg.Add(4)
 
 	clientChan := make(chan []byte, 128)
-	serverChan := make(chan []byte, 128)
+	serverChan := make(chan outboundMessage, 128)
 	archiveC2SChan := make(chan []byte, 128)
 	archiveS2CChan := make(chan archiver.ArchiveS2CJob, 128)
 
@@ -49,7 +52,9 @@ func manageAudioSession(conn *websocket.Conn, user models.User, parentCtx contex
 	go func() {
 		defer wg.Done()
 		defer cancel()
-		clientReadPump(conn, user.Username, clientChan, ctx)
+		if clientReadPump(conn, user.Username, clientChan, ctx) {
+			clientEnded.Store(true)
+		}
 	}()
 
 	// Server -> Client, 쓰기 전담
@@ -63,10 +68,13 @@ func manageAudioSession(conn *websocket.Conn, user models.User, parentCtx contex
 	go func() {
 		defer wg.Done()
 		defer cancel()
-		orchestrateAudioSession(
+		orchestrateErr = orchestrateAudioSession(
 			user,
-			scenarioKey,
-			sessionStartTime,
+			engine,
+			scenario,
+			sessionID,
+			state,
+			transcript,
 			clientChan,
 			serverChan,
 			archiveC2SChan,
@@ -84,6 +92,22 @@ func manageAudioSession(conn *websocket.Conn, user models.User, parentCtx contex
 	}()
 
 	wg.Wait()
+	duration := transcript.Elapsed()
+
+	// 종료 사유 판정, 오류로 중단된 경우 클라이언트에 먼저 알림
+	ended := SessionEndedData{Reason: EndReasonDisconnected, Outcome: state.Outcome(), FinalState: string(state.State())}
+	switch {
+	case state.Done():
+		ended.Reason = EndReasonCompleted
+	case clientEnded.Load():
+		ended.Reason = EndReasonClientEnded
+	case orchestrateErr != nil:
+		ended.Reason = EndReasonError
+		writeEnvelope(conn, MsgError, fatalErrorData(orchestrateErr))
+	}
+	defer func() {
+		endSession(conn, ended)
+	}()
 
 	/* 세션 종료 후 오디오 병합 */
 	log.Printf("Audio Session ended for user %s, Archiving audio files...", user.Username)
@@ -100,67 +124,89 @@ func manageAudioSession(conn *websocket.Conn, user models.User, parentCtx contex
 		return
 	}
 
-	userID, err := storage.GetUserIDByUsername(user.Username)
-	if err != nil {
-		log.Printf("manageAudioSession(): Failed to get user ID for archiving: %v", err)
-		return
-	}
-
-	if err := storage.CreateRecords(userID, scenarioKey, finalFilePath); err != nil {
-		log.Printf("manageVoiceSession(): Failed to save Record to database: %v", err)
-	} else {
-		log.Printf("manageVoiceSession(): Successfully saved Record metadata to DB for user: %s, path: %s", user.Username, finalFilePath)
-	}
+	ended.RecordID = saveSessionRecord(user.Username, scenario, models.ModeVoice, false, finalFilePath, duration, state, transcript)
 }
 
-func clientReadPump(conn *websocket.Conn, username string, clientChan chan<- []byte, ctx context.Context) {
+// 클라이언트 오디오 수신, 클라이언트가 session.end를 보내 종료한 경우 true 반환
+func clientReadPump(conn *websocket.Conn, username string, clientChan chan<- []byte, ctx context.Context) bool {
 	log.Printf("clientReadPump(): started for user: %s", username)
 	defer close(clientChan)
 	for {
 		select {
 		case <-ctx.Done():
 			log.Printf("clientReadPump(): Canceled with %s", username)
-			return
+			return false
 		default:
 		}
 		messageType, message, err := conn.ReadMessage()
 		if err != nil {
 			log.Printf("clientReadPump(): Error reading message from user %s: %v", username, err)
-			return
+			return false
 		}
 
-		if messageType != websocket.BinaryMessage {
-			log.Printf("clientReadPump(): Unsupported message type from user %s: %d", username, messageType)
-			continue
-		} else {
+		if messageType == websocket.BinaryMessage {
 			// log.Printf("clientReadPump(): Received audio message from user %s: %d bytes", username, len(message))
 			clientChan <- message
+			continue
 		}
 
+		// 텍스트 프레임은 제어 메시지로 처리
+		envelope, err := parseClientMessage(message)
+		if err != nil {
+			log.Printf("clientReadPump(): Invalid control message from user %s: %v", username, err)
+			continue
+		}
+		if envelope.Type == MsgSessionEnd {
+			log.Printf("clientReadPump(): Session ended by user %s", username)
+			return true
+		}
+		log.Printf("clientReadPump(): Unsupported message type from user %s: %s", username, envelope.Type)
 	}
 }
 
-func clientWritePump(conn *websocket.Conn, username string, clientAudioOutChan <-chan []byte, ctx context.Context) {
+// 클라이언트 송신 전담, 세션 종료 시 남은 메시지를 전송하고 읽기 대기 중인 clientReadPump를 깨움
+// 연결 종료(session.ended, Close 프레임)는 기록 저장 후 manageAudioSession에서 처리
+func clientWritePump(conn *websocket.Conn, username string, clientOutChan <-chan outboundMessage, ctx context.Context) {
 	log.Printf("clientWritePump(): started for user: %s", username)
+	defer conn.SetReadDeadline(time.Now())
 	for {
 		select {
 		case <-ctx.Done():
 			log.Printf("clientWritePump(): %s", username)
-			conn.WriteMessage(websocket.CloseMessage, []byte{})
+			flushPendingMessages(conn, username, clientOutChan)
 			return
 
-		case audioData, ok := <-clientAudioOutChan:
+		case msg, ok := <-clientOutChan:
 			if !ok {
-				log.Printf("clientWritePump(): audio out Chan closed for user: %s", username)
-				conn.WriteMessage(websocket.CloseMessage, []byte{})
+				log.Printf("clientWritePump(): out Chan closed for user: %s", username)
 				return
 			}
 
-			if err := conn.WriteMessage(websocket.BinaryMessage, audioData); err != nil {
-				log.Printf("clientWritePump(): Error sending audio to user %s: %v", username, err)
+			if err := writeOutbound(conn, msg); err != nil {
+				log.Printf("clientWritePump(): Error sending message to user %s: %v", username, err)
 				return
 			}
-			log.Printf("clientWritePump(): Sent audio to user %s: %d bytes", username, len(audioData))
+			if msg.Audio != nil {
+				log.Printf("clientWritePump(): Sent audio to user %s: %d bytes", username, len(msg.Audio))
+			}
+		}
+	}
+}
+
+// 세션 종료 시 이미 큐에 들어온 메시지(마지막 발화 등)를 전송
+func flushPendingMessages(conn *websocket.Conn, username string, clientOutChan <-chan outboundMessage) {
+	for {
+		select {
+		case msg, ok := <-clientOutChan:
+			if !ok {
+				return
+			}
+			if err := writeOutbound(conn, msg); err != nil {
+				log.Printf("flushPendingMessages(): Error sending message to user %s: %v", username, err)
+				return
+			}
+		default:
+			return
 		}
 	}
 }
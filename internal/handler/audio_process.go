@@ -4,28 +4,31 @@ import (
 	"PishingSimulator_SecurityProject/internal/archiver"
 	"PishingSimulator_SecurityProject/internal/llm"
 	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/pii"
+	"PishingSimulator_SecurityProject/internal/session"
 	"strings"
 	"sync"
 	"time"
 
 	"context"
 	"log"
-
-	"github.com/google/uuid"
 )
 
 func orchestrateAudioSession(
 	user models.User,
-	scenarioKey string, // [추가] 시나리오 키
-	sessionStartTime time.Time,
+	engine llm.ConversationEngine,
+	scenario models.Scenario,
+	sessionID string,
+	state *session.StateMachine,
+	transcript *session.Transcript,
 	clientChan <-chan []byte,
-	serverChan chan<- []byte,
+	serverChan chan<- outboundMessage,
 	archiveC2SChan chan<- []byte,
 	archiveS2CChan chan<- archiver.ArchiveS2CJob,
 	parentCtx context.Context,
-) {
+) error {
 	username := user.Username
-	llmSessionID := uuid.New().String() // [추가] LLM 세션 ID 생성
+	llmSessionID := sessionID
 	log.Printf("orchestrateAudioSession(): started for user: %s, session: %s", username, llmSessionID)
 
 	// 채널 정리
@@ -37,14 +40,14 @@ func orchestrateAudioSession(
 	sttRecognizer, err := llm.NewStreamingRecognizer(parentCtx)
 	if err != nil {
 		log.Printf("orchestrateAudioSession(): Failed to create STT: %v", err)
-		return
+		return &sessionError{Code: ErrCodeSTTFailed, Message: "Speech recognition is unavailable.", Err: err}
 	}
 
-	ttsClient, err := llm.NewTTSClient(parentCtx)
+	ttsClient, err := llm.NewTTSClient(parentCtx, scenario.Voice)
 	if err != nil {
 		log.Printf("orchestrateAudioSession(): Failed to create TTS: %v", err)
 		sttRecognizer.Close() // TTS 실패 시 STT도 닫고 종료
-		return
+		return &sessionError{Code: ErrCodeTTSFailed, Message: "Speech synthesis is unavailable.", Err: err}
 	}
 
 	// 2. 리소스 정리 (defer)
@@ -57,39 +60,83 @@ func orchestrateAudioSession(
 			log.Printf("orchestrateAudioSession(): Error closing TTS: %v", err)
 		}
 		// [추가] LLM 세션 정리 요청
-		llm.ClearSession(llmSessionID)
+		engine.ClearSession(llmSessionID)
 	}()
 
+	// 응답 고루틴(초기 인사, 발화별 LLM/TTS)이 모두 끝난 뒤에 채널을 닫음 (닫힌 채널 전송 방지)
+	// 세션 종료 시 ctx를 먼저 취소하여 진행 중인 LLM/TTS 호출을 중단시킨 후 대기
+	var responders sync.WaitGroup
+	defer responders.Wait()
+	ctx, cancelResponders := context.WithCancel(parentCtx)
+	defer cancelResponders()
+
+	// 세션이 끝나면 전송하지 않고 false 반환
+	send := func(msg outboundMessage) bool {
+		select {
+		case serverChan <- msg:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	// 연결 종료로 인한 오류(ctx 취소)는 클라이언트에 알리지 않음
+	sendError := func(code, message string) {
+		if ctx.Err() == nil {
+			send(outboundMessage{Type: MsgError, Data: ErrorData{Code: code, Message: message}})
+		}
+	}
+	archiveS2C := func(job archiver.ArchiveS2CJob) {
+		select {
+		case archiveS2CChan <- job:
+		case <-ctx.Done():
+		}
+	}
+
 	// 상태 관리 (말하는 중에는 듣지 않음 - Half Duplex 유사 동작)
 	var isListening = true
 	var stateMutex sync.Mutex
 	var lastFinalText string = ""
+	// 현재 발화의 첫 중간 결과 수신 시각 (대화 기록의 발화 시작 시각)
+	var utteranceStart time.Duration = -1
 
 	// 3. 초기 인사말 처리 (LLM InitSession)
+	responders.Add(1)
 	go func() {
+		defer responders.Done()
 		// [변경] 하드코딩된 텍스트 대신 LLM 서버에 초기화 요청
 		log.Printf("orchestrateAudioSession(): Initializing LLM session...")
-		initialUtterance, err := llm.InitSession(llmSessionID, scenarioKey, user.Profile, parentCtx)
+		initialUtterance, err := engine.InitSession(llmSessionID, scenario, user.LLMProfile(), ctx)
 		if err != nil {
 			log.Printf("orchestrateAudioSession(): Failed to init LLM session: %v", err)
+			sendError(ErrCodeInitFailed, "Error initializing session.")
 			return
 		}
 
 		log.Printf("orchestrateAudioSession(): LLM Init -> %s", initialUtterance)
+		if !send(outboundMessage{Type: MsgAssistantUtterance, Data: AssistantUtteranceData{Text: initialUtterance, Step: string(state.State())}}) {
+			return
+		}
 
 		// TTS 변환 및 전송
 		responseAudio, err := ttsClient.ConvertTextToAudio(initialUtterance)
 		if err == nil {
-			startTime := time.Since(sessionStartTime)
-			archiveS2CChan <- archiver.ArchiveS2CJob{Data: responseAudio, StartTime: startTime}
-			serverChan <- responseAudio
+			startTime := transcript.Elapsed()
+			transcript.Add(models.SpeakerAssistant, initialUtterance, startTime, startTime+llm.AudioDuration(responseAudio), nil)
+			archiveS2C(archiver.ArchiveS2CJob{Data: responseAudio, StartTime: startTime})
+			send(audioMessage(responseAudio))
 		} else {
 			log.Printf("orchestrateAudioSession(): Failed to convert initial TTS: %v", err)
+			startTime := transcript.Elapsed()
+			transcript.Add(models.SpeakerAssistant, initialUtterance, startTime, startTime, nil)
+			sendError(ErrCodeTTSFailed, "Error synthesizing speech.")
 		}
 	}()
 
-	sttResultChan := make(chan string, 10)
+	sttResultChan := make(chan llm.STTResult, 10)
 	sttErrChan := make(chan error, 1)
+	// 시나리오 종료 단계 도달 시 닫힘 (종료 후에도 Apply가 계속 done을 반환하므로 한 번만 닫음)
+	scenarioDone := make(chan struct{})
+	var scenarioDoneOnce sync.Once
 
 	// STT 수신 고루틴 시작
 	go sttRecognizer.ReceiveTranslatedText(sttResultChan, sttErrChan)
@@ -97,19 +144,27 @@ func orchestrateAudioSession(
 	// 4. 메인 루프
 	for {
 		select {
-		case <-parentCtx.Done():
+		case <-ctx.Done():
 			log.Printf("orchestrateAudioSession(): Context Canceled with %s", username)
-			return
+			return nil
+
+		case <-scenarioDone:
+			log.Printf("orchestrateAudioSession(): Scenario concluded for %s: %s (%s)", username, state.State(), state.Outcome())
+			return nil
 
 		// [오디오 수신] Client -> Server
 		case audioChunk, ok := <-clientChan:
 			if !ok {
 				log.Printf("orchestrateAudioSession(): Client audio channel closed for: %s", username)
-				return
+				return nil
 			}
 
 			// 무조건 아카이빙
-			archiveC2SChan <- audioChunk
+			select {
+			case archiveC2SChan <- audioChunk:
+			case <-ctx.Done():
+				return nil
+			}
 
 			// 상태에 따라 STT 전송 여부 결정
 			stateMutex.Lock()
@@ -123,14 +178,30 @@ func orchestrateAudioSession(
 			}
 
 		// [텍스트 수신] STT -> Logic
-		case userText := <-sttResultChan:
-			sttFinalTime := time.Since(sessionStartTime)
+		case sttResult := <-sttResultChan:
+			sttFinalTime := transcript.Elapsed()
+			userText := sttResult.Text
 			cleanedText := strings.TrimSpace(userText)
 
+			// 중간 결과는 자막용으로만 전달 (듣기 모드일 때만)
+			if !sttResult.IsFinal {
+				stateMutex.Lock()
+				currentListeningState := isListening
+				stateMutex.Unlock()
+				if currentListeningState && cleanedText != "" {
+					if utteranceStart < 0 {
+						utteranceStart = sttFinalTime
+					}
+					send(outboundMessage{Type: MsgSTTInterim, Data: TranscriptData{Text: cleanedText}})
+				}
+				continue
+			}
+
 			stateMutex.Lock()
 			// 듣기 모드가 아니거나, 빈 텍스트거나, 중복된 텍스트면 무시
 			if !isListening || cleanedText == "" || cleanedText == lastFinalText {
 				stateMutex.Unlock()
+				utteranceStart = -1
 				continue
 			}
 
@@ -139,16 +210,32 @@ func orchestrateAudioSession(
 			lastFinalText = userText
 			stateMutex.Unlock()
 
-			log.Printf("orchestrateAudioSession(): STT [FINAL] -> %s", userText)
+			log.Printf("orchestrateAudioSession(): STT [FINAL] -> %s", pii.Redact(userText))
+			send(outboundMessage{Type: MsgUserTranscript, Data: TranscriptData{Text: cleanedText}})
+
+			turnStart := utteranceStart
+			if turnStart < 0 {
+				turnStart = sttFinalTime
+			}
+			utteranceStart = -1
+			var confidence *float64
+			if sttResult.Confidence > 0 {
+				c := float64(sttResult.Confidence)
+				confidence = &c
+			}
+			transcript.Add(models.SpeakerUser, cleanedText, turnStart, sttFinalTime, confidence)
 
 			// [변경] 별도 고루틴에서 LLM 호출 -> TTS -> 전송 수행
+			responders.Add(1)
 			go func(textInput string, sttTimestamp time.Duration) {
+				defer responders.Done()
 				// A. LLM Chat 호출
-				log.Printf("orchestrateAudioSession(): Calling LLM for: %s", textInput)
-				chatResp, err := llm.Chat(llmSessionID, textInput, parentCtx)
+				log.Printf("orchestrateAudioSession(): Calling LLM for: %s", pii.Redact(textInput))
+				chatResp, err := engine.Chat(llmSessionID, textInput, ctx)
 
 				if err != nil {
 					log.Printf("orchestrateAudioSession(): LLM Chat Error: %v", err)
+					sendError(ErrCodeLLMFailed, "Error processing your message.")
 					// 에러 발생 시 다시 듣기 모드로 복구해야 함
 					stateMutex.Lock()
 					isListening = true
@@ -157,16 +244,27 @@ func orchestrateAudioSession(
 				}
 
 				aiText := chatResp.Utterance
-				log.Printf("orchestrateAudioSession(): LLM Response -> %s", aiText)
+				log.Printf("orchestrateAudioSession(): LLM Response -> %s (next: %s)", aiText, chatResp.NextStep)
+				currentState, done := state.Apply(chatResp.NextStep)
+				send(outboundMessage{Type: MsgAssistantUtterance, Data: AssistantUtteranceData{Text: aiText, Step: string(currentState)}})
 
 				// B. TTS 변환
 				responseAudio, err := ttsClient.ConvertTextToAudio(aiText)
 				if err != nil {
 					log.Printf("orchestrateAudioSession(): TTS Error: %v", err)
+					transcript.Add(models.SpeakerAssistant, aiText, sttTimestamp, sttTimestamp, nil)
+					sendError(ErrCodeTTSFailed, "Error synthesizing speech.")
 				} else {
-					// C. 전송 및 아카이빙
-					archiveS2CChan <- archiver.ArchiveS2CJob{Data: responseAudio, StartTime: sttTimestamp}
-					serverChan <- responseAudio
+					// C. 전송 및 아카이빙 (아카이브에서 응답은 사용자 발화 확정 시각에 배치됨)
+					transcript.Add(models.SpeakerAssistant, aiText, sttTimestamp, sttTimestamp+llm.AudioDuration(responseAudio), nil)
+					archiveS2C(archiver.ArchiveS2CJob{Data: responseAudio, StartTime: sttTimestamp})
+					send(audioMessage(responseAudio))
+				}
+
+				// 시나리오가 종료 단계에 도달하면 더 이상 듣지 않고 세션 종료
+				if done {
+					scenarioDoneOnce.Do(func() { close(scenarioDone) })
+					return
 				}
 
 				// D. 처리 완료 후 다시 듣기 모드 활성화
@@ -179,7 +277,7 @@ func orchestrateAudioSession(
 
 		case err := <-sttErrChan:
 			log.Printf("orchestrateAudioSession(): STT stream error: %v", err)
-			return
+			return &sessionError{Code: ErrCodeSTTFailed, Message: "Speech recognition stopped unexpectedly.", Err: err}
 		}
 	}
 }
@@ -203,7 +301,7 @@ func orchestrateVoiceEchoTest(user models.User, sessionStartTime time.Time,
 		return
 	}
 
-	ttsClient, err := llm.NewTTSClient(parentCtx)
+	ttsClient, err := llm.NewTTSClient(parentCtx, "")
 	if err != nil {
 		return
 	}
@@ -237,7 +335,7 @@ func orchestrateVoiceEchoTest(user models.User, sessionStartTime time.Time,
 		}
 	}()
 
-	sttResultChan := make(chan string, 10)
+	sttResultChan := make(chan llm.STTResult, 10)
 	sttErrChan := make(chan error, 1)
 
 	// STT 응답 수신을 위한 별도 Goroutine 시작
@@ -268,10 +366,22 @@ func orchestrateVoiceEchoTest(user models.User, sessionStartTime time.Time,
 				}
 			}
 
-		case userText := <-sttResultChan:
+		case sttResult := <-sttResultChan:
 			sttFinalTime := time.Since(sessionStartTime)
+			userText := sttResult.Text
 			cleanedText := strings.TrimSpace(userText)
 
+			// 중간 결과는 자막용으로만 전달 (듣기 모드일 때만)
+			if !sttResult.IsFinal {
+				stateMutex.Lock()
+				currentListeningState := isListening
+				stateMutex.Unlock()
+				if currentListeningState && cleanedText != "" {
+					serverChan <- outboundMessage{Type: MsgSTTInterim, Data: TranscriptData{Text: cleanedText}}
+				}
+				continue
+			}
+
 			stateMutex.Lock()
 			if !isListening || cleanedText == "" || cleanedText == lastFinalText {
 				continue
@@ -280,12 +390,12 @@ func orchestrateVoiceEchoTest(user models.User, sessionStartTime time.Time,
 			isListening = false
 			lastFinalText = userText
 			stateMutex.Unlock()
-			log.Printf("orchestrateVoiceSession(): STT [FINAL] -> %s", userText)
+			log.Printf("orchestrateVoiceSession(): STT [FINAL] -> %s", pii.Redact(userText))
 			log.Printf("... (State change: NOW RESPONDING. Discarding audio input)")
 
 			// [수정] TTS 변환 및 전송을 *별도 Goroutine*에서 처리
 			go func(textToSpeak string, sttTimestamp time.Duration) {
-				log.Printf("orchestrateVoiceSession(): Calling TTS for: %s", textToSpeak)
+				log.Printf("orchestrateVoiceSession(): Calling TTS for: %s", pii.Redact(textToSpeak))
 				responseAudio, err := ttsClient.ConvertTextToAudio(textToSpeak)
 
 				if err != nil {
@@ -321,13 +431,24 @@ func archiveAudioConversation(username string, archiver *archiver.Archiver, c2sI
 		select {
 		case <-ctx.Done():
 			log.Printf("runArchivingLogic(): Canceled for %s", username)
-			return
-		case chunk := <-c2sIn:
-			/*
-				if !ok {
-					// archiver.WriteC2S(chunk)
+			// 시나리오 종료로 취소된 경우 마지막 발화가 누락되지 않도록 남은 응답 기록
+			for {
+				select {
+				case job, ok := <-s2cIn:
+					if !ok {
+						return
+					}
+					archiver.WriteS2C(job)
+				default:
 					return
-				}*/
+				}
+			}
+		case chunk, ok := <-c2sIn:
+			if !ok {
+				// 닫힌 채널은 select에서 제외하고 남은 응답 기록은 계속 처리
+				c2sIn = nil
+				continue
+			}
 			archiver.WriteC2S(chunk)
 		case job, ok := <-s2cIn:
 			if !ok {
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/archiver"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// 세션 종료 시 orchestrateAudioSession이 채널을 닫은 뒤 컨텍스트가 취소되어도 기록 고루틴이 종료되어야 함
+func TestArchiveAudioConversationReturnsAfterCloseAndCancel(t *testing.T) {
+	// select가 준비된 case를 무작위로 고르므로 취소 처리 경로를 지나도록 여러 번 반복
+	for i := range 20 {
+		sessionID := fmt.Sprintf("archive-close-cancel-%d", i)
+		a, err := archiver.NewArchiver(sessionID)
+		if err != nil {
+			t.Fatalf("NewArchiver: %v", err)
+		}
+
+		c2s := make(chan []byte, 4)
+		s2c := make(chan archiver.ArchiveS2CJob, 4)
+		s2c <- archiver.ArchiveS2CJob{Data: []byte{1, 2}, StartTime: time.Second}
+		close(c2s)
+		close(s2c)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		done := make(chan struct{})
+		go func() {
+			archiveAudioConversation("tester", a, c2s, s2c, ctx)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("archiveAudioConversation did not return after channels were closed and context canceled")
+		}
+
+		// 닫히기 전에 보낸 응답만 기록되고 빈 청크는 기록되지 않아야 함
+		chunks, err := filepath.Glob(filepath.Join("data", "temp_recordings", sessionID+"_tts_chunk_*.raw"))
+		if err != nil {
+			t.Fatalf("Glob: %v", err)
+		}
+		if len(chunks) != 1 {
+			t.Errorf("%s: archived %d TTS chunks, want 1", sessionID, len(chunks))
+		}
+	}
+}
+
+func TestArchiveAudioConversationDrainsAfterClientChannelCloses(t *testing.T) {
+	sessionID := "archive-c2s-closed"
+	a, err := archiver.NewArchiver(sessionID)
+	if err != nil {
+		t.Fatalf("NewArchiver: %v", err)
+	}
+
+	c2s := make(chan []byte)
+	s2c := make(chan archiver.ArchiveS2CJob)
+	close(c2s)
+	done := make(chan struct{})
+	go func() {
+		archiveAudioConversation("tester", a, c2s, s2c, context.Background())
+		close(done)
+	}()
+
+	s2c <- archiver.ArchiveS2CJob{Data: []byte{1}, StartTime: time.Second}
+	close(s2c)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("archiveAudioConversation did not return after both channels were closed")
+	}
+}
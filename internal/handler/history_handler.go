@@ -0,0 +1,183 @@
+/**
+* Name: 			history_handler.go
+* Description: 		시뮬레이션 기록 상세 조회 핸들러
+* Workflow: 		기록 ID 검증, 조회 권한 확인(본인, 같은 그룹 강사, 같은 조직 조직 관리자, 관리자), 대화 기록, 평가 리포트, 녹음 파일 조회
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/evaluation"
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 대화 기록 응답 (Wrapper)
+type TranscriptResponse struct {
+	RecordID int                     `json:"record_id" example:"12"`
+	Turns    []models.TranscriptTurn `json:"turns"`
+}
+
+// GetTranscript godoc
+// @Summary      세션 대화 기록 조회
+// @Description  특정 시뮬레이션 기록의 턴별 대화 내용(발화자, 텍스트, 세션 시작 기준 시각, STT 신뢰도)을 순서대로 반환합니다.
+// @Description  본인 기록 외에 강사는 같은 그룹 훈련생의 기록, 조직 관리자는 같은 조직 사용자의 기록(조직 소속 중 진행한 기록), 관리자는 모든 기록을 조회할 수 있습니다.
+// @Tags         API (Protected)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "기록 ID (GET /api/history의 id)"
+// @Success      200  {object}  handler.TranscriptResponse
+// @Failure      400  {object}  handler.ErrorResponse "잘못된 기록 ID"
+// @Failure      401  {object}  handler.ErrorResponse "인증 실패"
+// @Failure      404  {object}  handler.ErrorResponse "기록 없음"
+// @Failure      500  {object}  handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/history/{id}/transcript [get]
+func GetTranscript(c *gin.Context) {
+	record, ok := loadAccessibleRecord(c)
+	if !ok {
+		return
+	}
+
+	turns, err := storage.GetTranscriptByRecordID(record.ID)
+	if err != nil {
+		log.Printf("[ERROR] GetTranscriptByRecordID failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch transcript"})
+		return
+	}
+	c.JSON(http.StatusOK, TranscriptResponse{RecordID: record.ID, Turns: turns})
+}
+
+// GetReport godoc
+// @Summary      세션 평가 리포트 조회
+// @Description  대화 기록을 자동 평가한 리포트를 반환합니다. 주민등록번호·카드번호·계좌번호·인증번호·비밀번호·주소 유출, 송금 동의는 감점, 발신자 재확인(콜백)은 가점 항목입니다.
+// @Description  각 항목에는 근거 발화(민감 정보는 마스킹)가 포함됩니다. 평가되지 않았거나 평가 규칙이 갱신된 기록은 조회 시 다시 평가합니다.
+// @Description  본인 기록 외에 강사는 같은 그룹 훈련생의 기록, 조직 관리자는 같은 조직 사용자의 기록(조직 소속 중 진행한 기록), 관리자는 모든 기록을 조회할 수 있습니다.
+// @Tags         API (Protected)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "기록 ID (GET /api/history의 id)"
+// @Success      200  {object}  models.EvaluationReport
+// @Failure      400  {object}  handler.ErrorResponse "잘못된 기록 ID"
+// @Failure      401  {object}  handler.ErrorResponse "인증 실패"
+// @Failure      404  {object}  handler.ErrorResponse "기록 없음"
+// @Failure      500  {object}  handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/history/{id}/report [get]
+func GetReport(c *gin.Context) {
+	record, ok := loadAccessibleRecord(c)
+	if !ok {
+		return
+	}
+
+	report, err := storage.GetRecordReport(record.ID)
+	if err != nil {
+		log.Printf("[ERROR] GetRecordReport failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch report"})
+		return
+	}
+	if report != nil && report.Version == evaluation.ReportVersion {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	// 평가 전이거나 이전 버전 규칙으로 평가된 기록은 다시 평가
+	turns, err := storage.GetTranscriptByRecordID(record.ID)
+	if err != nil {
+		log.Printf("[ERROR] GetTranscriptByRecordID failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch transcript"})
+		return
+	}
+	evaluated := evaluation.Evaluate(record, turns)
+	if err := storage.SaveRecordReport(evaluated); err != nil {
+		log.Printf("[ERROR] SaveRecordReport failed: %v", err)
+	}
+	c.JSON(http.StatusOK, evaluated)
+}
+
+// GetRecordAudio godoc
+// @Summary      세션 녹음 파일 재생
+// @Description  음성 세션 기록의 녹음 파일(.mp3)을 반환합니다. 텍스트 세션 기록은 녹음이 없으므로 404를 반환합니다.
+// @Description  본인 기록 외에 강사는 같은 그룹 훈련생의 기록, 조직 관리자는 같은 조직 사용자의 기록(조직 소속 중 진행한 기록), 관리자는 모든 기록을 조회할 수 있습니다.
+// @Tags         API (Protected)
+// @Description  Authorization 헤더 대신 `POST /api/history/{id}/audio-url`로 발급받은 1회용 티켓(`ticket`)으로 인증할 수 있습니다.
+// @Produce      audio/mpeg
+// @Security     BearerAuth
+// @Param        id     path   int     true   "기록 ID (GET /api/history의 id)"
+// @Param        ticket query  string  false  "1회용 재생 티켓 (Header 사용 불가 시)"
+// @Success      200  {file}    file "오디오 바이너리 데이터"
+// @Failure      400  {object}  handler.ErrorResponse "잘못된 기록 ID"
+// @Failure      401  {object}  handler.ErrorResponse "인증 실패 또는 유효하지 않은 티켓"
+// @Failure      404  {object}  handler.ErrorResponse "기록 또는 녹음 파일 없음"
+// @Router       /api/history/{id}/audio [get]
+func GetRecordAudio(c *gin.Context) {
+	record, ok := loadAccessibleRecord(c)
+	if !ok {
+		return
+	}
+	if record.FilePath == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
+		return
+	}
+	if _, err := os.Stat(record.FilePath); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
+		return
+	}
+	c.File(record.FilePath)
+}
+
+// 요청한 사용자 조회 (AuthMiddleware가 설정한 username 기준)
+func loadCurrentUser(c *gin.Context) (models.User, bool) {
+	user, err := storage.GetUserByUsername(c.GetString("username"))
+	if err != nil {
+		log.Printf("[ERROR] GetUserByUsername failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return user, false
+	}
+	return user, true
+}
+
+// 조회 권한이 있는 기록만 반환 (본인, 같은 그룹 훈련생의 기록을 조회하는 강사, 같은 조직의 조직 관리자, 관리자)
+// 권한이 없는 기록은 존재 여부를 노출하지 않도록 404로 응답
+func loadAccessibleRecord(c *gin.Context) (models.Record, bool) {
+	recordID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || recordID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid record id"})
+		return models.Record{}, false
+	}
+	record, err := storage.GetRecordByID(recordID)
+	return authorizeRecord(c, record, err)
+}
+
+// 기록 조회 결과(record, err)의 조회 권한 확인, 실패하면 오류 응답 후 false 반환
+func authorizeRecord(c *gin.Context, record models.Record, err error) (models.Record, bool) {
+	viewer, ok := loadCurrentUser(c)
+	if !ok {
+		return models.Record{}, false
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+		} else {
+			log.Printf("[ERROR] Fetching record failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch record"})
+		}
+		return models.Record{}, false
+	}
+	if record.UserID == viewer.ID {
+		return record, true
+	}
+
+	owner, err := storage.GetUserByID(record.UserID)
+	if err != nil || !viewer.CanViewRecord(record, owner) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+		return models.Record{}, false
+	}
+	return record, true
+}
@@ -0,0 +1,187 @@
+/**
+* Name: 			invitation_handler.go
+* Description: 		조직 초대 HTTP 핸들러
+* Workflow: 		조직 관리자가 조직에 소속되지 않은 사용자를 역할, 그룹과 함께 초대, 사용자가 받은 초대 조회 및 수락
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// /api/org/invitations 요청 바디
+type CreateInvitationRequest struct {
+	Username string `json:"username" example:"gildong"`
+	Role     string `json:"role" example:"trainee"` // trainee, trainer, org_admin (생략 시 trainee)
+	GroupID  *int   `json:"group_id" example:"3"`   // 수락 시 배정할 조직 그룹 (선택)
+}
+
+// 초대 목록 응답 (Wrapper)
+type InvitationListResponse struct {
+	Invitations []models.OrganizationInvitation `json:"invitations"`
+}
+
+// CreateInvitation godoc
+// @Summary      조직 초대 (조직 관리자)
+// @Description  조직에 소속되지 않은 기존 사용자를 소속 조직으로 초대합니다. 사용자가 `/api/invitations/{id}/accept`로 수락하면 지정한 역할과 그룹이 적용됩니다.
+// @Description  변경된 역할은 사용자가 다시 로그인한 뒤 발급되는 토큰부터 적용됩니다.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.CreateInvitationRequest true "초대할 사용자, 역할, 그룹"
+// @Success      201 {object} models.OrganizationInvitation
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청, 역할 또는 조직에 없는 그룹"
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "사용자 없음"
+// @Failure      409 {object} handler.ErrorResponse "이미 조직에 소속된 사용자 또는 대기 중인 초대 있음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org/invitations [post]
+func CreateInvitation(c *gin.Context) {
+	var request CreateInvitationRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil || request.Username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if request.Role == "" {
+		request.Role = models.RoleTrainee
+	}
+	if !models.IsInvitableRole(request.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be one of trainee, trainer, org_admin"})
+		return
+	}
+
+	manager, org, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	if !validateGroupAssignment(c, request.GroupID, &org.ID) {
+		return
+	}
+	invitee, err := storage.GetUserByUsername(request.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			log.Printf("[ERROR] GetUserByUsername failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		}
+		return
+	}
+	if invitee.OrgID != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "User already belongs to an organization"})
+		return
+	}
+
+	invitation, err := storage.CreateInvitation(org.ID, invitee.ID, request.Role, request.GroupID, manager.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvitationPending) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Invitation already pending"})
+		} else {
+			log.Printf("[ERROR] CreateInvitation failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListOrganizationInvitations godoc
+// @Summary      조직 초대 목록 조회 (조직 관리자)
+// @Description  소속 조직이 보낸 초대를 최신순으로 반환합니다. 수락된 초대는 `accepted_at`을 포함합니다.
+// @Tags         Organization
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.InvitationListResponse
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org/invitations [get]
+func ListOrganizationInvitations(c *gin.Context) {
+	_, org, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	invitations, err := storage.GetInvitationsByOrg(org.ID)
+	if err != nil {
+		log.Printf("[ERROR] GetInvitationsByOrg failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch invitations"})
+		return
+	}
+	c.JSON(http.StatusOK, InvitationListResponse{Invitations: invitations})
+}
+
+// ListMyInvitations godoc
+// @Summary      받은 조직 초대 조회
+// @Description  요청한 사용자가 받은 대기 중인 조직 초대를 최신순으로 반환합니다.
+// @Tags         API (Protected)
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.InvitationListResponse
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/invitations [get]
+func ListMyInvitations(c *gin.Context) {
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+	invitations, err := storage.GetPendingInvitationsByUserID(user.ID)
+	if err != nil {
+		log.Printf("[ERROR] GetPendingInvitationsByUserID failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch invitations"})
+		return
+	}
+	c.JSON(http.StatusOK, InvitationListResponse{Invitations: invitations})
+}
+
+// AcceptInvitation godoc
+// @Summary      조직 초대 수락
+// @Description  받은 초대를 수락하여 조직에 소속됩니다. 초대에 지정된 역할과 그룹이 적용되며, 변경된 역할은 다시 로그인한 뒤 적용됩니다.
+// @Tags         API (Protected)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "초대 ID"
+// @Success      200 {object} models.User
+// @Failure      400 {object} handler.ErrorResponse "잘못된 초대 ID"
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      404 {object} handler.ErrorResponse "대기 중인 초대 없음"
+// @Failure      409 {object} handler.ErrorResponse "이미 조직에 소속됨"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/invitations/{id}/accept [post]
+func AcceptInvitation(c *gin.Context) {
+	invitationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || invitationID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation id"})
+		return
+	}
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := storage.AcceptInvitation(invitationID, user.ID); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvitationNotPending):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+		case errors.Is(err, storage.ErrAlreadyInOrganization):
+			c.JSON(http.StatusConflict, gin.H{"error": "User already belongs to an organization"})
+		default:
+			log.Printf("[ERROR] AcceptInvitation failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept invitation"})
+		}
+		return
+	}
+	log.Printf("AcceptInvitation(): User %s accepted invitation %d", user.Username, invitationID)
+	respondUpdatedUser(c, user.ID)
+}
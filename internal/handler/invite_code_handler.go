@@ -0,0 +1,233 @@
+/**
+* Name: 			invite_code_handler.go
+* Description: 		회원가입 초대 코드 관리용 HTTP 핸들러 (관리자)
+* Workflow: 		초대 코드 생성(조직, 역할, 그룹, 최대 사용 횟수, 만료 시각 지정), 목록 조회, 회수, 사용 기록 조회
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 초대 코드 기본 유효 기간 및 최대 사용 횟수 제한
+const (
+	defaultInviteCodeTTL = 7 * 24 * time.Hour
+	maxInviteCodeUses    = 10000
+)
+
+// /api/admin/invite-codes 요청 바디
+type CreateInviteCodeRequest struct {
+	OrgID     *int       `json:"org_id" example:"1"`                        // 가입자가 소속될 조직 (선택)
+	Role      string     `json:"role" example:"trainee"`                    // trainee, trainer, org_admin (생략 시 trainee)
+	GroupID   *int       `json:"group_id" example:"3"`                      // 가입자가 배정될 그룹 (선택, 조직의 그룹)
+	MaxUses   int        `json:"max_uses" example:"30"`                     // 최대 사용 횟수 (생략 시 1)
+	ExpiresAt *time.Time `json:"expires_at" example:"2025-03-31T23:59:59Z"` // 만료 시각 (생략 시 7일 후)
+}
+
+// 초대 코드 목록 응답 (Wrapper)
+type InviteCodeListResponse struct {
+	InviteCodes []models.InviteCode `json:"invite_codes"`
+}
+
+// 초대 코드 사용 기록 응답 (Wrapper)
+type InviteCodeRedemptionListResponse struct {
+	Redemptions []models.InviteCodeRedemption `json:"redemptions"`
+}
+
+// AdminListInviteCodes godoc
+// @Summary      초대 코드 목록 조회 (관리자)
+// @Description  초대 코드를 최신순으로 반환합니다. 코드 원문은 저장하지 않으므로 앞 4자리(`code_prefix`)만 표시됩니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        org_id query int false "조직 ID 필터"
+// @Success      200 {object} handler.InviteCodeListResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 필터"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/invite-codes [get]
+func AdminListInviteCodes(c *gin.Context) {
+	orgID, ok := parseIDQuery(c, "org_id", "Invalid organization id")
+	if !ok {
+		return
+	}
+	codes, err := storage.GetInviteCodes(orgID)
+	if err != nil {
+		log.Printf("[ERROR] GetInviteCodes failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch invite codes"})
+		return
+	}
+	c.JSON(http.StatusOK, InviteCodeListResponse{InviteCodes: codes})
+}
+
+// CreateInviteCode godoc
+// @Summary      초대 코드 생성 (관리자)
+// @Description  회원가입 초대 코드를 생성합니다. 가입 시 `X-Invite-Code` 헤더로 코드를 보내면 지정한 조직, 역할, 그룹이 적용됩니다.
+// @Description  코드 원문(`code`)은 이 응답에서만 확인할 수 있습니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.CreateInviteCodeRequest true "초대 코드 설정"
+// @Success      201 {object} models.InviteCode
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청, 역할, 사용 횟수, 만료 시각 또는 존재하지 않는 조직/그룹"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/invite-codes [post]
+func CreateInviteCode(c *gin.Context) {
+	var request CreateInviteCodeRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if request.Role == "" {
+		request.Role = models.RoleTrainee
+	}
+	if !models.IsInvitableRole(request.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be one of trainee, trainer, org_admin"})
+		return
+	}
+	if request.Role == models.RoleOrgAdmin && request.OrgID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "org_admin role requires an organization"})
+		return
+	}
+	if request.MaxUses == 0 {
+		request.MaxUses = 1
+	}
+	if request.MaxUses < 0 || request.MaxUses > maxInviteCodeUses {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_uses must be between 1 and " + strconv.Itoa(maxInviteCodeUses)})
+		return
+	}
+	expiresAt := time.Now().Add(defaultInviteCodeTTL)
+	if request.ExpiresAt != nil {
+		expiresAt = *request.ExpiresAt
+	}
+	if !expiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+		return
+	}
+
+	if request.OrgID != nil {
+		if _, err := storage.GetOrganizationByID(*request.OrgID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Organization not found"})
+			} else {
+				log.Printf("[ERROR] GetOrganizationByID failed: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization"})
+			}
+			return
+		}
+	}
+	if !validateGroupAssignment(c, request.GroupID, request.OrgID) {
+		return
+	}
+	admin, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	code, err := storage.CreateInviteCode(models.InviteCode{
+		OrgID:     request.OrgID,
+		Role:      request.Role,
+		GroupID:   request.GroupID,
+		MaxUses:   request.MaxUses,
+		ExpiresAt: expiresAt,
+		CreatedBy: admin.ID,
+	})
+	if err != nil {
+		log.Printf("[ERROR] CreateInviteCode failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invite code"})
+		return
+	}
+	log.Printf("CreateInviteCode(): %s created invite code %d (role %s, max uses %d)", admin.Username, code.ID, code.Role, code.MaxUses)
+	c.JSON(http.StatusCreated, code)
+}
+
+// RevokeInviteCode godoc
+// @Summary      초대 코드 회수 (관리자)
+// @Description  초대 코드를 더 이상 사용할 수 없도록 회수합니다. 사용 기록 보존을 위해 실제로 삭제하지는 않습니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "초대 코드 ID"
+// @Success      200 {object} handler.SuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 초대 코드 ID"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "초대 코드 없음 또는 이미 회수됨"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/invite-codes/{id} [delete]
+func RevokeInviteCode(c *gin.Context) {
+	codeID, ok := parseInviteCodeIDParam(c)
+	if !ok {
+		return
+	}
+	if err := storage.RevokeInviteCode(codeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invite code not found"})
+		} else {
+			log.Printf("[ERROR] RevokeInviteCode failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke invite code"})
+		}
+		return
+	}
+	log.Printf("RevokeInviteCode(): %s revoked invite code %d", c.GetString("username"), codeID)
+	c.JSON(http.StatusOK, gin.H{"message": "Invite code revoked"})
+}
+
+// GetInviteCodeRedemptions godoc
+// @Summary      초대 코드 사용 기록 조회 (관리자)
+// @Description  초대 코드로 가입한 사용자, 가입 시각, 요청 IP를 최신순으로 반환합니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "초대 코드 ID"
+// @Success      200 {object} handler.InviteCodeRedemptionListResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 초대 코드 ID"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "초대 코드 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/invite-codes/{id}/redemptions [get]
+func GetInviteCodeRedemptions(c *gin.Context) {
+	codeID, ok := parseInviteCodeIDParam(c)
+	if !ok {
+		return
+	}
+	if _, err := storage.GetInviteCodeByID(codeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invite code not found"})
+		} else {
+			log.Printf("[ERROR] GetInviteCodeByID failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get invite code"})
+		}
+		return
+	}
+
+	redemptions, err := storage.GetInviteCodeRedemptions(codeID)
+	if err != nil {
+		log.Printf("[ERROR] GetInviteCodeRedemptions failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch redemptions"})
+		return
+	}
+	c.JSON(http.StatusOK, InviteCodeRedemptionListResponse{Redemptions: redemptions})
+}
+
+func parseInviteCodeIDParam(c *gin.Context) (int, bool) {
+	codeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || codeID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invite code id"})
+		return 0, false
+	}
+	return codeID, true
+}
@@ -0,0 +1,387 @@
+/**
+* Name: 			mfa_handler.go
+* Description: 		TOTP 2단계 인증(MFA) HTTP 핸들러
+* Workflow: 		인증 앱 등록(비밀 키 발급 → 코드 확인 → 복구 코드 발급), 해제, 2단계 로그인(비밀번호 확인 후 발급된 mfa_token과 코드로 토큰 발급), 관리자 초기화
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/auth"
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 로그인 2단계 인증 대기 응답 (202)
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required" example:"true"`
+	MFAToken    string `json:"mfa_token" example:"eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjUtMDMifQ..."` // /login/mfa에 코드와 함께 전송
+	ExpiresIn   int    `json:"expires_in" example:"300"`                                          // mfa_token 유효 기간 (초)
+}
+
+// /login/mfa 요청 바디
+type LoginMFARequest struct {
+	MFAToken string `json:"mfa_token" example:"eyJhbGciOiJFZERTQSIsImtpZCI6IjIwMjUtMDMifQ..."`
+	Code     string `json:"code" example:"492039"` // 인증 앱의 6자리 코드 또는 복구 코드
+}
+
+// /api/mfa/verify, /api/mfa/disable 요청 바디
+type MFACodeRequest struct {
+	Code string `json:"code" example:"492039"`
+}
+
+// 2단계 인증 상태 응답
+type MFAStatusResponse struct {
+	Enabled                bool `json:"enabled" example:"true"`
+	Required               bool `json:"required" example:"true"` // 조직 정책상 필수 여부
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining" example:"10"`
+}
+
+// 인증 앱 등록 응답
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"` // 인증 앱 수동 입력용
+	OTPAuthURL string `json:"otpauth_url" example:"otpauth://totp/PishingSimulator:gildong?secret=..."`
+}
+
+// 복구 코드 응답
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"7KQ2M-XP4RZ,M2PA9-FZDW4"`
+}
+
+// LoginMFA godoc
+// @Summary      2단계 인증 로그인
+// @Description  `/login`이 반환한 `mfa_token`과 인증 앱의 6자리 코드(또는 복구 코드)로 토큰을 발급받습니다. 각 코드와 복구 코드는 한 번만 사용할 수 있습니다.
+// @Description  잘못된 코드는 `/login`의 비밀번호 실패와 같이 계정별 실패 횟수에 포함되어 대기 시간과 잠금이 적용됩니다.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        request body handler.LoginMFARequest true "mfa_token과 인증 코드"
+// @Success      200 {object} handler.LoginSuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} handler.ErrorResponse "유효하지 않은 mfa_token 또는 인증 코드"
+// @Failure      429 {object} handler.ErrorResponse "인증 실패로 인한 대기 또는 계정 잠금"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /login/mfa [post]
+func LoginMFA(c *gin.Context) {
+	var request LoginMFARequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil || request.MFAToken == "" || request.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	claims, err := auth.ValidateMFAChallenge(request.MFAToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired MFA token"})
+		return
+	}
+	user, err := storage.GetUserByUsername(claims.Username)
+	if err != nil || !user.MFAEnabled {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired MFA token"})
+		return
+	}
+
+	// 인증 코드 추측도 비밀번호 실패와 같이 계정별로 제한
+	if !beginLoginAttempt(c, user.Username) {
+		return
+	}
+	ok, err := verifyMFACode(user.ID, request.Code)
+	if err != nil {
+		log.Printf("[ERROR] verifyMFACode failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify MFA code"})
+		return
+	}
+	if !ok {
+		log.Printf("LoginMFA(): Invalid MFA code for user %s from %s", user.Username, c.ClientIP())
+		recordLoginFailure(user.Username)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid MFA code"})
+		return
+	}
+	clearLoginFailures(user.Username)
+
+	// 같은 mfa_token으로 다시 로그인할 수 없도록 폐기
+	if err := storage.RevokeAccessToken(claims.ID, claims.ExpiresAt.Time); err != nil {
+		log.Printf("[ERROR] RevokeAccessToken failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	issueSession(c, user, true)
+}
+
+// GetMFAStatus godoc
+// @Summary      2단계 인증 상태 조회
+// @Description  2단계 인증 사용 여부, 조직 정책상 필수 여부, 남은 복구 코드 개수를 반환합니다.
+// @Tags         MFA
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.MFAStatusResponse
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/mfa [get]
+func GetMFAStatus(c *gin.Context) {
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+	required, err := mfaRequired(user)
+	if err != nil {
+		log.Printf("[ERROR] mfaRequired failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization"})
+		return
+	}
+	remaining, err := storage.CountRecoveryCodes(user.ID)
+	if err != nil {
+		log.Printf("[ERROR] CountRecoveryCodes failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get MFA status"})
+		return
+	}
+	c.JSON(http.StatusOK, MFAStatusResponse{Enabled: user.MFAEnabled, Required: required, RecoveryCodesRemaining: remaining})
+}
+
+// EnrollMFA godoc
+// @Summary      2단계 인증 등록 시작
+// @Description  TOTP 비밀 키를 발급합니다. `otpauth_url`을 QR 코드로 표시하거나 `secret`을 인증 앱에 입력한 뒤 `/api/mfa/verify`로 코드를 확인하면 사용이 시작됩니다.
+// @Description  확인 전에 다시 요청하면 새 비밀 키로 대체됩니다.
+// @Tags         MFA
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.MFAEnrollResponse
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      409 {object} handler.ErrorResponse "이미 2단계 인증 사용 중"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/mfa/enroll [post]
+func EnrollMFA(c *gin.Context) {
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate secret"})
+		return
+	}
+	if err := storage.SetPendingMFASecret(user.ID, secret); err != nil {
+		if errors.Is(err, storage.ErrMFAAlreadyEnabled) {
+			c.JSON(http.StatusConflict, gin.H{"error": "MFA already enabled"})
+		} else {
+			log.Printf("[ERROR] SetPendingMFASecret failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start MFA enrollment"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, MFAEnrollResponse{Secret: secret, OTPAuthURL: auth.TOTPURL(user.Username, secret)})
+}
+
+// VerifyMFA godoc
+// @Summary      2단계 인증 등록 확인
+// @Description  인증 앱의 6자리 코드로 등록을 확인하고 2단계 인증 사용을 시작합니다. 인증 앱을 잃어버렸을 때 사용할 복구 코드 10개를 반환하며, 복구 코드는 이 응답에서만 확인할 수 있습니다.
+// @Description  이후 로그인부터 인증 코드가 필요하며, 조직 정책상 2단계 인증이 필요한 기능은 다시 로그인한 뒤 사용할 수 있습니다.
+// @Tags         MFA
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.MFACodeRequest true "인증 앱의 6자리 코드"
+// @Success      200 {object} handler.MFARecoveryCodesResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청, 등록 시작 전 또는 잘못된 코드"
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      409 {object} handler.ErrorResponse "이미 2단계 인증 사용 중"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/mfa/verify [post]
+func VerifyMFA(c *gin.Context) {
+	request, ok := parseMFACodeRequest(c)
+	if !ok {
+		return
+	}
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+	state, err := storage.GetMFASecret(user.ID)
+	if err != nil {
+		log.Printf("[ERROR] GetMFASecret failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify MFA code"})
+		return
+	}
+	if state.Enabled {
+		c.JSON(http.StatusConflict, gin.H{"error": "MFA already enabled"})
+		return
+	}
+	if state.Secret == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "MFA enrollment not started"})
+		return
+	}
+	step, matched := auth.MatchTOTP(state.Secret, request.Code, time.Now())
+	if !matched {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid MFA code"})
+		return
+	}
+
+	codes, err := storage.EnableMFA(user.ID, step)
+	if err != nil {
+		if errors.Is(err, storage.ErrMFAAlreadyEnabled) {
+			c.JSON(http.StatusConflict, gin.H{"error": "MFA already enabled"})
+		} else {
+			log.Printf("[ERROR] EnableMFA failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable MFA"})
+		}
+		return
+	}
+	log.Printf("VerifyMFA(): User %s enabled MFA", user.Username)
+	c.JSON(http.StatusOK, MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableMFA godoc
+// @Summary      2단계 인증 해제
+// @Description  인증 앱의 6자리 코드 또는 복구 코드를 확인하고 2단계 인증을 해제합니다. 조직 정책상 필수인 역할은 해제할 수 없습니다.
+// @Tags         MFA
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.MFACodeRequest true "인증 코드 또는 복구 코드"
+// @Success      200 {object} handler.SuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 2단계 인증 미사용"
+// @Failure      401 {object} handler.ErrorResponse "인증 실패 또는 잘못된 코드"
+// @Failure      403 {object} handler.ErrorResponse "조직 정책상 필수"
+// @Failure      429 {object} handler.ErrorResponse "코드 확인 실패가 반복되어 일시적으로 제한됨"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/mfa/disable [post]
+func DisableMFA(c *gin.Context) {
+	request, ok := parseMFACodeRequest(c)
+	if !ok {
+		return
+	}
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+	if !user.MFAEnabled {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "MFA is not enabled"})
+		return
+	}
+	required, err := mfaRequired(user)
+	if err != nil {
+		log.Printf("[ERROR] mfaRequired failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization"})
+		return
+	}
+	if required {
+		c.JSON(http.StatusForbidden, gin.H{"error": "MFA is required by your organization"})
+		return
+	}
+
+	// 탈취된 토큰으로 코드를 추측하지 못하도록 LoginMFA와 같이 계정별로 제한
+	if !beginLoginAttempt(c, user.Username) {
+		return
+	}
+	matched, err := verifyMFACode(user.ID, request.Code)
+	if err != nil {
+		log.Printf("[ERROR] verifyMFACode failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify MFA code"})
+		return
+	}
+	if !matched {
+		log.Printf("DisableMFA(): Invalid MFA code for user %s from %s", user.Username, c.ClientIP())
+		recordLoginFailure(user.Username)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid MFA code"})
+		return
+	}
+	clearLoginFailures(user.Username)
+	if err := storage.DisableMFA(user.ID); err != nil {
+		log.Printf("[ERROR] DisableMFA failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable MFA"})
+		return
+	}
+	log.Printf("DisableMFA(): User %s disabled MFA", user.Username)
+	c.JSON(http.StatusOK, gin.H{"message": "MFA disabled"})
+}
+
+// AdminResetMFA godoc
+// @Summary      사용자 2단계 인증 초기화 (관리자)
+// @Description  인증 앱과 복구 코드를 모두 잃어버린 사용자의 2단계 인증을 해제합니다. 조직 정책상 필수인 사용자는 다음 로그인 후 다시 등록해야 합니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "사용자 ID"
+// @Success      200 {object} handler.SuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 사용자 ID"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "사용자 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/users/{id}/mfa [delete]
+func AdminResetMFA(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+	user, err := storage.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			log.Printf("[ERROR] GetUserByID failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		}
+		return
+	}
+	if err := storage.DisableMFA(user.ID); err != nil {
+		log.Printf("[ERROR] DisableMFA failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset MFA"})
+		return
+	}
+	log.Printf("AdminResetMFA(): %s reset MFA of user %s", c.GetString("username"), user.Username)
+	c.JSON(http.StatusOK, gin.H{"message": "MFA reset"})
+}
+
+// 인증 코드 확인, 6자리 숫자는 TOTP 코드, 그 외 입력은 복구 코드로 확인
+// 사용한 TOTP 시간 단계와 복구 코드는 다시 사용할 수 없음
+func verifyMFACode(userID int, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	state, err := storage.GetMFASecret(userID)
+	if err != nil || !state.Enabled {
+		return false, err
+	}
+	if step, matched := auth.MatchTOTP(state.Secret, code, time.Now()); matched {
+		err := storage.UseTOTPStep(userID, step)
+		if errors.Is(err, storage.ErrTOTPStepUsed) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	err = storage.UseRecoveryCode(userID, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// 사용자 조직의 정책상 현재 역할에 2단계 인증이 필요한지 확인
+func mfaRequired(user models.User) (bool, error) {
+	if user.OrgID == nil {
+		return false, nil
+	}
+	org, err := storage.GetOrganizationByID(*user.OrgID)
+	if err != nil {
+		return false, err
+	}
+	return org.RequiresMFA(user.Role), nil
+}
+
+func parseMFACodeRequest(c *gin.Context) (MFACodeRequest, bool) {
+	var request MFACodeRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil || strings.TrimSpace(request.Code) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return request, false
+	}
+	request.Code = strings.TrimSpace(request.Code)
+	return request, true
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/storage"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDisableMFAThrottlesFailedCodes(t *testing.T) {
+	user := createTestUser(t, "mfa_disable_throttle")
+	if err := storage.SetPendingMFASecret(user.ID, "JBSWY3DPEHPK3PXP"); err != nil {
+		t.Fatalf("SetPendingMFASecret: %v", err)
+	}
+	if _, err := storage.EnableMFA(user.ID, 0); err != nil {
+		t.Fatalf("EnableMFA: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/mfa/disable", func(c *gin.Context) {
+		c.Set("username", user.Username)
+	}, DisableMFA)
+
+	// 3회까지는 바로 다시 시도할 수 있고, 이후에는 대기 시간 동안 429
+	for i, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/mfa/disable", strings.NewReader(`{"code":"000000"}`)))
+		if w.Code != want {
+			t.Fatalf("attempt %d: status = %d (%s), want %d", i+1, w.Code, w.Body, want)
+		}
+	}
+
+	refreshed, err := storage.GetUserByUsername(user.Username)
+	if err != nil || !refreshed.MFAEnabled {
+		t.Errorf("MFA enabled = %v (%v), want still enabled", refreshed.MFAEnabled, err)
+	}
+}
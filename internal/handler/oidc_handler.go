@@ -0,0 +1,281 @@
+/**
+* Name: 			oidc_handler.go
+* Description: 		OIDC(SSO) 로그인 HTTP 핸들러
+* Workflow: 		/auth/oidc/{provider}/login에서 state, nonce, PKCE code_verifier를 저장하고 IdP로 이동 → IdP가 /auth/oidc/{provider}/callback으로 인증 코드 전달 → 토큰 교환 및 ID 토큰 검증 → 계정 생성 또는 연결, 그룹 매핑으로 역할과 조직 갱신 → 앱 JWT 발급
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/oidc"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcLoginStateTTL = 10 * time.Minute // IdP 로그인을 마치기까지의 시간
+	ssoLoginTicketTTL = 60 * time.Second
+	// state를 로그인을 시작한 브라우저에 묶는 쿠키 (다른 사람의 인증 코드로 로그인시키는 login CSRF 방지)
+	oidcStateCookie = "oidc_state"
+)
+
+var (
+	oidcProviders     = map[string]*oidc.Provider{}
+	oidcProviderOrder []string
+)
+
+// SSO 공급자 목록 설정, main에서 설정 파일 로드 후 호출
+func SetOIDCProviders(providers []*oidc.Provider) {
+	oidcProviders = make(map[string]*oidc.Provider, len(providers))
+	oidcProviderOrder = oidcProviderOrder[:0]
+	for _, provider := range providers {
+		oidcProviders[provider.Config().ID] = provider
+		oidcProviderOrder = append(oidcProviderOrder, provider.Config().ID)
+	}
+}
+
+// 로그인 화면에 표시할 SSO 공급자
+type SSOProviderInfo struct {
+	ID       string `json:"id" example:"acme"`
+	Name     string `json:"name" example:"Acme 임직원 로그인"`
+	LoginURL string `json:"login_url" example:"/auth/oidc/acme/login"`
+}
+
+// SSO 공급자 목록 응답
+type SSOProviderListResponse struct {
+	Providers []SSOProviderInfo `json:"providers"`
+}
+
+// /auth/oidc/token 요청 바디
+type SSOTokenRequest struct {
+	Ticket string `json:"ticket" example:"b1Xo3k..."`
+}
+
+// ListSSOProviders godoc
+// @Summary      SSO 공급자 목록
+// @Description  로그인 화면에 표시할 SSO(회사 계정 로그인) 공급자 목록을 반환합니다. 설정된 공급자가 없으면 빈 목록입니다.
+// @Tags         SSO
+// @Produce      json
+// @Success      200 {object} handler.SSOProviderListResponse
+// @Router       /auth/oidc/providers [get]
+func ListSSOProviders(c *gin.Context) {
+	providers := make([]SSOProviderInfo, 0, len(oidcProviderOrder))
+	for _, id := range oidcProviderOrder {
+		providers = append(providers, SSOProviderInfo{ID: id, Name: oidcProviders[id].Config().Name, LoginURL: "/auth/oidc/" + id + "/login"})
+	}
+	c.JSON(http.StatusOK, SSOProviderListResponse{Providers: providers})
+}
+
+// StartSSOLogin godoc
+// @Summary      SSO 로그인 시작
+// @Description  브라우저를 회사 IdP의 로그인 페이지로 이동시킵니다. (OpenID Connect 인증 코드 흐름, PKCE S256)
+// @Description  로그인을 시작한 브라우저를 확인하기 위한 쿠키를 설정하므로 API 호출이 아닌 브라우저 이동(링크, location)으로 사용하세요.
+// @Tags         SSO
+// @Param        provider path string true "SSO 공급자 ID"
+// @Success      302 "IdP 로그인 페이지로 이동"
+// @Failure      404 {object} handler.ErrorResponse "공급자 없음"
+// @Failure      502 {object} handler.ErrorResponse "IdP 설정 조회 실패"
+// @Router       /auth/oidc/{provider}/login [get]
+func StartSSOLogin(c *gin.Context) {
+	provider, ok := loadOIDCProvider(c)
+	if !ok {
+		return
+	}
+	loginState := storage.OIDCLoginState{CodeVerifier: oauth2.GenerateVerifier(), Nonce: oauth2.GenerateVerifier()}
+	state, err := storage.CreateOIDCLoginState(provider.Config().ID, loginState, oidcLoginStateTTL)
+	if err != nil {
+		log.Printf("[ERROR] CreateOIDCLoginState failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start SSO login"})
+		return
+	}
+	authURL, err := provider.AuthCodeURL(c.Request.Context(), state, loginState.Nonce, loginState.CodeVerifier)
+	if err != nil {
+		log.Printf("StartSSOLogin(): %s: %v", provider.Config().ID, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Identity provider is unavailable"})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, int(oidcLoginStateTTL.Seconds()), "/auth/oidc", "", secureCookie(provider), true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// SSOCallback godoc
+// @Summary      SSO 로그인 콜백
+// @Description  IdP가 로그인 후 브라우저를 이동시키는 주소입니다. (IdP에 redirect URI로 등록)
+// @Description  IdP 계정과 연결된 계정이 없으면 새 계정을 만들고, IdP 그룹에 매핑된 역할과 조직을 로그인할 때마다 적용합니다.
+// @Description  공급자에 `post_login_redirect`가 설정되어 있으면 1회용 `ticket`을 붙여 프론트엔드로 이동하며, 프론트엔드는 `/auth/oidc/token`으로 토큰을 발급받습니다. 설정이 없으면 토큰을 바로 응답합니다.
+// @Tags         SSO
+// @Produce      json
+// @Param        provider path  string true  "SSO 공급자 ID"
+// @Param        code     query string false "인증 코드"
+// @Param        state    query string true  "로그인 요청 state"
+// @Param        error    query string false "IdP 오류 코드"
+// @Success      200 {object} handler.LoginSuccessResponse
+// @Success      302 "프론트엔드로 이동 (?ticket=)"
+// @Failure      400 {object} handler.ErrorResponse "유효하지 않거나 만료된 state"
+// @Failure      401 {object} handler.ErrorResponse "IdP 로그인 실패 또는 ID 토큰 검증 실패"
+// @Failure      403 {object} handler.ErrorResponse "IdP 그룹에 매핑된 역할 없음"
+// @Failure      404 {object} handler.ErrorResponse "공급자 없음"
+// @Failure      409 {object} handler.ErrorResponse "같은 사용자명의 계정이 이미 있음"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /auth/oidc/{provider}/callback [get]
+func SSOCallback(c *gin.Context) {
+	provider, ok := loadOIDCProvider(c)
+	if !ok {
+		return
+	}
+	providerID := provider.Config().ID
+
+	state := c.Query("state")
+	cookieState, _ := c.Cookie(oidcStateCookie)
+	c.SetCookie(oidcStateCookie, "", -1, "/auth/oidc", "", secureCookie(provider), true)
+	if state == "" || cookieState != state {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired SSO login state"})
+		return
+	}
+	loginState, err := storage.ConsumeOIDCLoginState(state, providerID)
+	if err != nil {
+		if errors.Is(err, storage.ErrOIDCStateInvalid) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired SSO login state"})
+		} else {
+			log.Printf("[ERROR] ConsumeOIDCLoginState failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete SSO login"})
+		}
+		return
+	}
+	if idpError := c.Query("error"); idpError != "" {
+		log.Printf("SSOCallback(): %s returned error %q: %s", providerID, idpError, c.Query("error_description"))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "SSO login failed: " + idpError})
+		return
+	}
+
+	identity, err := provider.Authenticate(c.Request.Context(), c.Query("code"), loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrNoRoleMapping) {
+			log.Printf("SSOCallback(): %s user %q has no mapped role (groups: %s)", providerID, identity.Username, strings.Join(identity.Groups, ","))
+			c.JSON(http.StatusForbidden, gin.H{"error": "No role is assigned to your account"})
+		} else {
+			log.Printf("SSOCallback(): %s: %v", providerID, err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "SSO login failed"})
+		}
+		return
+	}
+
+	account := storage.SSOAccount{
+		Provider: providerID,
+		Subject:  identity.Subject,
+		Username: identity.Username,
+		Name:     identity.Name,
+		Role:     identity.Role,
+	}
+	if identity.Organization != "" {
+		org, err := storage.GetOrganizationByName(identity.Organization)
+		if err != nil {
+			log.Printf("[ERROR] SSOCallback(): %s organization %q: %v", providerID, identity.Organization, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete SSO login"})
+			return
+		}
+		account.OrgID = &org.ID
+	}
+	user, err := storage.ProvisionSSOUser(account, provider.Config().LinkExistingUsers)
+	if err != nil {
+		if errors.Is(err, storage.ErrUsernameExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already exists"})
+		} else {
+			log.Printf("[ERROR] ProvisionSSOUser failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete SSO login"})
+		}
+		return
+	}
+	log.Printf("SSOCallback(): User %s logged in via %s (role: %s)", user.Username, providerID, user.Role)
+
+	redirect := provider.Config().PostLoginRedirect
+	if redirect == "" {
+		issueSession(c, user, identity.MFA)
+		return
+	}
+	// 토큰이 브라우저 기록, 프록시 로그에 남지 않도록 URL에는 1회용 티켓만 전달
+	resource := ""
+	if identity.MFA {
+		resource = models.TicketResourceMFA
+	}
+	ticket, err := storage.CreateAccessTicket(user.ID, models.TicketPurposeSSOLogin, resource, ssoLoginTicketTTL)
+	if err != nil {
+		log.Printf("[ERROR] CreateAccessTicket failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete SSO login"})
+		return
+	}
+	target, _ := url.Parse(redirect)
+	query := target.Query()
+	query.Set("ticket", ticket)
+	target.RawQuery = query.Encode()
+	c.Redirect(http.StatusFound, target.String())
+}
+
+// ExchangeSSOTicket godoc
+// @Summary      SSO 로그인 토큰 발급
+// @Description  SSO 로그인 후 프론트엔드로 전달된 1회용 `ticket`(60초)으로 액세스 토큰과 리프레시 토큰을 발급받습니다.
+// @Tags         SSO
+// @Accept       json
+// @Produce      json
+// @Param        request body handler.SSOTokenRequest true "SSO 로그인 티켓"
+// @Success      200 {object} handler.LoginSuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} handler.ErrorResponse "유효하지 않거나 만료된 티켓"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /auth/oidc/token [post]
+func ExchangeSSOTicket(c *gin.Context) {
+	var request SSOTokenRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil || request.Ticket == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	userID, resource, err := storage.ConsumeAccessTicketResource(request.Ticket, models.TicketPurposeSSOLogin)
+	if err != nil {
+		if errors.Is(err, storage.ErrAccessTicketInvalid) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired ticket"})
+		} else {
+			log.Printf("[ERROR] ConsumeAccessTicketResource failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check ticket"})
+		}
+		return
+	}
+	user, err := storage.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired ticket"})
+		} else {
+			log.Printf("[ERROR] GetUserByID failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user"})
+		}
+		return
+	}
+	issueSession(c, user, resource == models.TicketResourceMFA)
+}
+
+func loadOIDCProvider(c *gin.Context) (*oidc.Provider, bool) {
+	provider, ok := oidcProviders[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "SSO provider not found"})
+		return nil, false
+	}
+	return provider, true
+}
+
+// 콜백 URL이 https이면 Secure 쿠키 (TLS 종료 프록시 뒤에서도 적용)
+func secureCookie(provider *oidc.Provider) bool {
+	return strings.HasPrefix(provider.Config().RedirectURL, "https://")
+}
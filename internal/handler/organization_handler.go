@@ -0,0 +1,527 @@
+/**
+* Name: 			organization_handler.go
+* Description: 		조직(고객사) 관리용 HTTP 핸들러
+* Workflow: 		조직 생성/조회, 사용자 조직 지정 (관리자), 조직 구성원/그룹/허용 시나리오 관리 (조직 관리자)
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 조직 목록 응답 (Wrapper)
+type OrganizationListResponse struct {
+	Organizations []models.Organization `json:"organizations"`
+}
+
+// /api/admin/organizations 요청 바디
+type CreateOrganizationRequest struct {
+	Name string `json:"name" example:"OO은행"`
+}
+
+// 조직 허용 시나리오 변경 요청 바디, scenarios가 null이면 모든 활성 시나리오 허용
+type UpdateOrganizationScenariosRequest struct {
+	Scenarios []string `json:"scenarios" example:"loan_scam,institution_impersonation"`
+}
+
+// 조직 2단계 인증 정책 변경 요청 바디, roles의 역할은 로그인 시 2단계 인증 필수 (trainer, org_admin, admin만 지정 가능)
+type UpdateOrganizationMFAPolicyRequest struct {
+	Roles []string `json:"roles" example:"trainer,org_admin"`
+}
+
+// /api/admin/users/{id}/organization 요청 바디, org_id가 null이면 조직에서 제외
+type UpdateUserOrganizationRequest struct {
+	OrgID *int `json:"org_id" example:"1"`
+}
+
+// AdminListOrganizations godoc
+// @Summary      조직 목록 조회 (관리자)
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.OrganizationListResponse
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/organizations [get]
+func AdminListOrganizations(c *gin.Context) {
+	orgs, err := storage.GetOrganizations()
+	if err != nil {
+		log.Printf("[ERROR] GetOrganizations failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organizations"})
+		return
+	}
+	c.JSON(http.StatusOK, OrganizationListResponse{Organizations: orgs})
+}
+
+// CreateOrganization godoc
+// @Summary      조직 생성 (관리자)
+// @Description  훈련을 진행할 고객사(조직)를 생성합니다. 생성된 조직은 모든 활성 시나리오를 사용할 수 있습니다.
+// @Description  조직 관리자는 `/api/admin/users/{id}/organization`으로 조직을 지정한 뒤 `/api/admin/users/{id}/role`로 `org_admin` 역할을 부여합니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.CreateOrganizationRequest true "조직 정보"
+// @Success      201 {object} models.Organization
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      409 {object} handler.ErrorResponse "이미 존재하는 조직 이름"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/organizations [post]
+func CreateOrganization(c *gin.Context) {
+	var request CreateOrganizationRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization name cannot be empty"})
+		return
+	}
+
+	org, err := storage.CreateOrganization(name)
+	if err != nil {
+		if errors.Is(err, storage.ErrOrganizationExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Organization name already exists"})
+		} else {
+			log.Printf("[ERROR] CreateOrganization: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, org)
+}
+
+// AdminUpdateOrganizationScenarios godoc
+// @Summary      조직 허용 시나리오 변경 (관리자)
+// @Description  조직에서 사용할 수 있는 시나리오를 지정합니다. `scenarios`가 null이면 모든 활성 시나리오, 빈 배열이면 사용할 수 있는 시나리오가 없습니다.
+// @Description  조직 관리자는 이 범위 안에서만 구성원이 사용할 시나리오를 선택할 수 있습니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                       true "조직 ID"
+// @Param        request body handler.UpdateOrganizationScenariosRequest true "허용 시나리오 키 목록"
+// @Success      200 {object} models.Organization
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 존재하지 않는 시나리오"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "조직 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/organizations/{id}/scenarios [put]
+func AdminUpdateOrganizationScenarios(c *gin.Context) {
+	orgID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || orgID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization id"})
+		return
+	}
+	updateOrganizationScenarios(c, orgID, nil)
+}
+
+// AdminUpdateOrganizationMFAPolicy godoc
+// @Summary      조직 2단계 인증 정책 변경 (관리자)
+// @Description  조직에서 2단계 인증이 필요한 역할을 지정합니다. 지정한 역할의 구성원은 2단계 인증으로 로그인해야 보호된 API를 사용할 수 있으며, 등록하지 않은 구성원은 `/api/mfa`로 등록한 뒤 다시 로그인해야 합니다.
+// @Description  `trainer`, `org_admin`, `admin`만 지정할 수 있으며 빈 배열이면 필수 역할이 없습니다. 조직 관리자가 추가로 지정한 역할(`org_mfa_required_roles`)은 변경하지 않습니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                       true "조직 ID"
+// @Param        request body handler.UpdateOrganizationMFAPolicyRequest true "2단계 인증 필수 역할 목록"
+// @Success      200 {object} models.Organization
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 지정할 수 없는 역할"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "조직 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/organizations/{id}/mfa-policy [put]
+func AdminUpdateOrganizationMFAPolicy(c *gin.Context) {
+	orgID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || orgID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization id"})
+		return
+	}
+	updateOrganizationMFAPolicy(c, orgID, nil)
+}
+
+// UpdateUserOrganization godoc
+// @Summary      사용자 조직 지정 (관리자)
+// @Description  사용자를 조직에 소속시킵니다. `org_id`가 null이면 조직에서 제외하며, 조직이 바뀌면 기존 그룹 배정은 해제됩니다.
+// @Description  이전 조직에서 진행한 기록은 새 조직의 강사, 조직 관리자에게 공개되지 않습니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                                  true "사용자 ID"
+// @Param        request body handler.UpdateUserOrganizationRequest true "지정할 조직"
+// @Success      200 {object} models.User
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 존재하지 않는 조직"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "사용자 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/users/{id}/organization [put]
+func UpdateUserOrganization(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+	var request UpdateUserOrganizationRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if request.OrgID != nil {
+		if _, err := storage.GetOrganizationByID(*request.OrgID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Organization not found"})
+			} else {
+				log.Printf("[ERROR] GetOrganizationByID failed: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization"})
+			}
+			return
+		}
+	}
+
+	if err := storage.UpdateUserOrganization(userID, request.OrgID); err != nil {
+		respondUserUpdateError(c, err)
+		return
+	}
+	respondUpdatedUser(c, userID)
+}
+
+// GetOrganization godoc
+// @Summary      소속 조직 조회 (조직 관리자)
+// @Tags         Organization
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.Organization
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org [get]
+func GetOrganization(c *gin.Context) {
+	_, org, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+// ListOrganizationMembers godoc
+// @Summary      조직 구성원 목록 조회 (조직 관리자)
+// @Description  소속 조직의 사용자 목록을 사용자명 순으로 반환합니다. 역할, 그룹으로 필터링할 수 있습니다.
+// @Tags         Organization
+// @Produce      json
+// @Security     BearerAuth
+// @Param        role     query string false "역할 필터 (trainee, trainer, org_admin)"
+// @Param        group_id query int    false "그룹 ID 필터"
+// @Success      200 {object} handler.UserListResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 필터"
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org/members [get]
+func ListOrganizationMembers(c *gin.Context) {
+	filter, ok := parseUserFilter(c)
+	if !ok {
+		return
+	}
+	_, org, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	filter.OrgID = &org.ID
+
+	users, err := storage.GetUsers(filter)
+	if err != nil {
+		log.Printf("[ERROR] GetUsers failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		return
+	}
+	c.JSON(http.StatusOK, UserListResponse{Users: users})
+}
+
+// UpdateOrganizationMemberGroup godoc
+// @Summary      조직 구성원 그룹 지정 (조직 관리자)
+// @Description  소속 조직의 사용자를 조직의 그룹에 배정합니다. `group_id`가 null이면 그룹에서 제외합니다.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                           true "사용자 ID"
+// @Param        request body handler.UpdateUserGroupRequest true "배정할 그룹"
+// @Success      200 {object} models.User
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 조직에 없는 그룹"
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "조직에 없는 사용자"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org/members/{id}/group [put]
+func UpdateOrganizationMemberGroup(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+	var request UpdateUserGroupRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	manager, _, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	member, err := storage.GetUserByID(userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		respondUserUpdateError(c, err)
+		return
+	}
+	// 다른 조직의 사용자는 존재 여부를 노출하지 않음
+	if err != nil || !manager.SameOrganization(member) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if !validateGroupAssignment(c, request.GroupID, member.OrgID) {
+		return
+	}
+
+	if err := storage.UpdateUserGroup(userID, request.GroupID); err != nil {
+		respondUserUpdateError(c, err)
+		return
+	}
+	respondUpdatedUser(c, userID)
+}
+
+// ListOrganizationGroups godoc
+// @Summary      조직 그룹 목록 조회 (조직 관리자)
+// @Tags         Organization
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.GroupListResponse
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org/groups [get]
+func ListOrganizationGroups(c *gin.Context) {
+	_, org, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	groups, err := storage.GetGroups(&org.ID)
+	if err != nil {
+		log.Printf("[ERROR] GetGroups failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch groups"})
+		return
+	}
+	c.JSON(http.StatusOK, GroupListResponse{Groups: groups})
+}
+
+// CreateOrganizationGroup godoc
+// @Summary      조직 그룹 생성 (조직 관리자)
+// @Description  소속 조직에 훈련 그룹(팀, 기수)을 생성합니다.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.CreateGroupRequest true "그룹 정보 (org_id는 무시됨)"
+// @Success      201 {object} models.Group
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음"
+// @Failure      409 {object} handler.ErrorResponse "같은 조직에 이미 존재하는 그룹 이름"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org/groups [post]
+func CreateOrganizationGroup(c *gin.Context) {
+	var request CreateGroupRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	_, org, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	createGroup(c, request.Name, &org.ID)
+}
+
+// UpdateOrganizationScenarios godoc
+// @Summary      조직 사용 시나리오 선택 (조직 관리자)
+// @Description  관리자가 조직에 허용한 시나리오(`scenarios`) 중 구성원이 사용할 시나리오(`enabled_scenarios`)를 선택합니다. `scenarios`가 null이면 허용된 시나리오 전체, 빈 배열이면 사용할 수 있는 시나리오가 없습니다.
+// @Description  선택하지 않은 시나리오는 구성원의 시나리오 목록과 진행 상황에서 제외되며 시뮬레이션을 시작할 수 없습니다. 허용 범위 밖의 시나리오는 선택할 수 없습니다.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.UpdateOrganizationScenariosRequest true "허용 시나리오 키 목록"
+// @Success      200 {object} models.Organization
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 존재하지 않는 시나리오"
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음 또는 관리자가 허용하지 않은 시나리오"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org/scenarios [put]
+func UpdateOrganizationScenarios(c *gin.Context) {
+	_, org, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	updateOrganizationScenarios(c, org.ID, &org)
+}
+
+// UpdateOrganizationMFAPolicy godoc
+// @Summary      조직 2단계 인증 정책 변경 (조직 관리자)
+// @Description  소속 조직에서 관리자가 지정한 역할(`mfa_required_roles`) 외에 2단계 인증이 필요한 역할을 추가로 지정합니다(`org_mfa_required_roles`). 관리자가 지정한 역할은 해제할 수 없습니다.
+// @Description  지정한 역할의 구성원은 2단계 인증으로 로그인해야 보호된 API를 사용할 수 있습니다.
+// @Description  `org_admin`을 지정하면 요청한 조직 관리자도 2단계 인증으로 다시 로그인해야 조직 관리 API를 사용할 수 있습니다.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.UpdateOrganizationMFAPolicyRequest true "2단계 인증 필수 역할 목록"
+// @Success      200 {object} models.Organization
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 지정할 수 없는 역할"
+// @Failure      403 {object} handler.ErrorResponse "조직 관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/org/mfa-policy [put]
+func UpdateOrganizationMFAPolicy(c *gin.Context) {
+	_, org, ok := loadManagedOrganization(c)
+	if !ok {
+		return
+	}
+	updateOrganizationMFAPolicy(c, org.ID, &org)
+}
+
+// 조직 시나리오 변경 후 변경된 조직 응답 (관리자, 조직 관리자 공용)
+// managed가 nil이면 관리자의 허용 시나리오 변경, 아니면 조직 관리자가 허용 범위 안에서 사용할 시나리오 선택
+func updateOrganizationScenarios(c *gin.Context, orgID int, managed *models.Organization) {
+	var request UpdateOrganizationScenariosRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	for _, key := range request.Scenarios {
+		if _, err := storage.GetScenarioByKey(key); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown scenario: " + key})
+			} else {
+				log.Printf("[ERROR] GetScenarioByKey failed: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get scenario"})
+			}
+			return
+		}
+		if managed != nil && !managed.GrantsScenario(key) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Scenario not allowed for organization: " + key})
+			return
+		}
+	}
+
+	if managed == nil {
+		err = storage.UpdateOrganizationScenarios(orgID, request.Scenarios)
+	} else {
+		err = storage.UpdateOrganizationEnabledScenarios(orgID, request.Scenarios)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			log.Printf("[ERROR] UpdateOrganizationScenarios failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update organization"})
+		}
+		return
+	}
+	org, err := storage.GetOrganizationByID(orgID)
+	if err != nil {
+		log.Printf("[ERROR] GetOrganizationByID failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization"})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+// 조직 2단계 인증 정책 변경 후 변경된 조직 응답 (관리자, 조직 관리자 공용)
+// managed가 nil이 아니면 조직 관리자 요청, 관리자가 지정한 역할 외에 추가 지정만 가능 (해제 불가)
+func updateOrganizationMFAPolicy(c *gin.Context, orgID int, managed *models.Organization) {
+	var request UpdateOrganizationMFAPolicyRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	for _, role := range request.Roles {
+		if !models.IsElevatedRole(role) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role: " + role})
+			return
+		}
+	}
+
+	update := storage.UpdateOrganizationMFAPolicy
+	if managed != nil {
+		update = storage.UpdateOrganizationOrgMFARoles
+	}
+	if err := update(orgID, request.Roles); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		} else {
+			log.Printf("[ERROR] UpdateOrganizationMFAPolicy failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update organization"})
+		}
+		return
+	}
+	org, err := storage.GetOrganizationByID(orgID)
+	if err != nil {
+		log.Printf("[ERROR] GetOrganizationByID failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization"})
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+// 요청한 조직 관리자와 관리 대상 조직 조회
+// 토큰 발급 이후 역할이 바뀌었거나 조직에 소속되지 않은 경우 403
+func loadManagedOrganization(c *gin.Context) (models.User, models.Organization, bool) {
+	manager, ok := loadCurrentUser(c)
+	if !ok {
+		return manager, models.Organization{}, false
+	}
+	if manager.Role != models.RoleOrgAdmin || manager.OrgID == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not an organization admin"})
+		return manager, models.Organization{}, false
+	}
+	org, err := storage.GetOrganizationByID(*manager.OrgID)
+	if err != nil {
+		log.Printf("[ERROR] GetOrganizationByID failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization"})
+		return manager, org, false
+	}
+	return manager, org, true
+}
+
+// 사용자 조직에서 허용된 활성 시나리오 목록 (조직이 없으면 모든 활성 시나리오)
+func availableScenarios(user models.User) ([]models.Scenario, error) {
+	scenarios, err := storage.GetScenarios(false)
+	if err != nil || user.OrgID == nil {
+		return scenarios, err
+	}
+	org, err := storage.GetOrganizationByID(*user.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]models.Scenario, 0, len(scenarios))
+	for _, s := range scenarios {
+		if org.AllowsScenario(s.Key) {
+			available = append(available, s)
+		}
+	}
+	return available, nil
+}
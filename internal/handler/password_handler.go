@@ -0,0 +1,213 @@
+/**
+* Name: 			password_handler.go
+* Description: 		비밀번호 변경 및 재설정 HTTP 핸들러
+* Workflow: 		본인 비밀번호 변경(현재 비밀번호 확인 → 정책 검사 → 변경 후 새 토큰 발급), 관리자의 1회용 재설정 토큰 발급, 재설정 토큰으로 비밀번호 변경, 비밀번호가 바뀌면 기존 토큰과 티켓은 모두 무효화
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/auth"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 관리자가 발급한 재설정 토큰의 유효 기간
+const passwordResetTokenTTL = 24 * time.Hour
+
+// /api/password 요청 바디
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"password123"`
+	NewPassword     string `json:"new_password" example:"correct-horse-battery"`
+}
+
+// /password/reset 요청 바디
+type ResetPasswordRequest struct {
+	ResetToken  string `json:"reset_token" example:"q8Zt2w..."`
+	NewPassword string `json:"new_password" example:"correct-horse-battery"`
+}
+
+// 비밀번호 재설정 토큰 발급 응답
+type PasswordResetTokenResponse struct {
+	ResetToken string `json:"reset_token" example:"q8Zt2w..."` // 사용자에게 전달, 이 응답에서만 확인 가능
+	ExpiresIn  int    `json:"expires_in" example:"86400"`      // 유효 기간 (초)
+}
+
+// ChangePassword godoc
+// @Summary      비밀번호 변경
+// @Description  현재 비밀번호를 확인하고 새 비밀번호로 변경합니다. 새 비밀번호는 최소 길이(기본 10자)를 만족해야 하며 사용자명과 같거나 유출된 비밀번호 목록에 있으면 사용할 수 없습니다.
+// @Description  변경하면 다른 기기를 포함한 기존 로그인 세션(액세스 토큰, 리프레시 토큰)이 모두 무효화되고, 이 요청에는 새 토큰을 발급합니다.
+// @Description  잘못된 현재 비밀번호는 로그인 실패 횟수에 포함됩니다.
+// @Tags         API (Protected)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.ChangePasswordRequest true "현재 비밀번호와 새 비밀번호"
+// @Success      200 {object} handler.LoginSuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 비밀번호 정책 위반"
+// @Failure      401 {object} handler.ErrorResponse "인증 실패 또는 잘못된 현재 비밀번호"
+// @Failure      429 {object} handler.ErrorResponse "로그인 실패로 인한 대기 또는 계정 잠금"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/password [post]
+func ChangePassword(c *gin.Context) {
+	var request ChangePasswordRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil || request.CurrentPassword == "" || request.NewPassword == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	if !beginLoginAttempt(c, user.Username) {
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.CurrentPassword)); err != nil {
+		recordLoginFailure(user.Username)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Current password is incorrect"})
+		return
+	}
+	clearLoginFailures(user.Username)
+
+	if request.NewPassword == request.CurrentPassword {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "New password must be different from the current password"})
+		return
+	}
+	passwordHash, ok := hashNewPassword(c, request.NewPassword, user.Username)
+	if !ok {
+		return
+	}
+	if err := storage.UpdatePassword(user.ID, passwordHash); err != nil {
+		log.Printf("[ERROR] UpdatePassword failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change password"})
+		return
+	}
+	log.Printf("ChangePassword(): User %s changed password", user.Username)
+	issueSession(c, user, c.GetBool("mfa"))
+}
+
+// CreatePasswordResetToken godoc
+// @Summary      비밀번호 재설정 토큰 발급 (관리자)
+// @Description  비밀번호를 잊어버린 사용자에게 전달할 1회용 재설정 토큰을 발급합니다. 사용자는 `/password/reset`에 토큰과 새 비밀번호를 보내 비밀번호를 변경합니다.
+// @Description  토큰은 24시간 동안 한 번만 사용할 수 있으며, 다시 발급하면 이전 토큰은 폐기됩니다. 토큰 원문은 DB에 저장되지 않으며 이 응답에서만 확인할 수 있습니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "사용자 ID"
+// @Success      201 {object} handler.PasswordResetTokenResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 사용자 ID"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "사용자 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/users/{id}/password-reset [post]
+func CreatePasswordResetToken(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+	user, err := storage.GetUserByID(userID)
+	if err != nil {
+		respondUserUpdateError(c, err)
+		return
+	}
+	admin, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	token, err := storage.CreatePasswordResetToken(user.ID, admin.ID, passwordResetTokenTTL)
+	if err != nil {
+		log.Printf("[ERROR] CreatePasswordResetToken failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create reset token"})
+		return
+	}
+	log.Printf("CreatePasswordResetToken(): %s issued password reset token for user %s", admin.Username, user.Username)
+	c.JSON(http.StatusCreated, PasswordResetTokenResponse{ResetToken: token, ExpiresIn: int(passwordResetTokenTTL.Seconds())})
+}
+
+// ResetPassword godoc
+// @Summary      비밀번호 재설정
+// @Description  관리자에게 받은 재설정 토큰으로 비밀번호를 변경합니다. 기존 로그인 세션은 모두 무효화되고 로그인 실패로 인한 잠금도 해제되며, 새 비밀번호로 다시 로그인해야 합니다.
+// @Description  새 비밀번호 정책은 `/api/password`와 같습니다.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        request body handler.ResetPasswordRequest true "재설정 토큰과 새 비밀번호"
+// @Success      200 {object} handler.SuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 비밀번호 정책 위반"
+// @Failure      401 {object} handler.ErrorResponse "유효하지 않거나 만료된 재설정 토큰"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /password/reset [post]
+func ResetPassword(c *gin.Context) {
+	var request ResetPasswordRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil || request.ResetToken == "" || request.NewPassword == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	// 정책 위반으로 토큰이 소모되지 않도록 사용자를 먼저 확인하고 정책 검사
+	user, err := storage.GetPasswordResetTokenUser(request.ResetToken)
+	if err != nil {
+		if errors.Is(err, storage.ErrPasswordResetTokenInvalid) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired reset token"})
+		} else {
+			log.Printf("[ERROR] GetPasswordResetTokenUser failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		}
+		return
+	}
+	passwordHash, ok := hashNewPassword(c, request.NewPassword, user.Username)
+	if !ok {
+		return
+	}
+	if err := storage.ResetPasswordWithToken(request.ResetToken, passwordHash); err != nil {
+		if errors.Is(err, storage.ErrPasswordResetTokenInvalid) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired reset token"})
+		} else {
+			log.Printf("[ERROR] ResetPasswordWithToken failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		}
+		return
+	}
+	clearLoginFailures(user.Username)
+	log.Printf("ResetPassword(): User %s reset password", user.Username)
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+// 새 비밀번호 정책 검사 후 bcrypt 해시 반환, 위반 시 400 응답 후 false
+func hashNewPassword(c *gin.Context, password, username string) (string, bool) {
+	if err := auth.ValidatePassword(password, username); err != nil {
+		var message string
+		switch {
+		case errors.Is(err, auth.ErrPasswordTooShort):
+			message = fmt.Sprintf("Password must be at least %d characters", auth.PasswordMinLength())
+		case errors.Is(err, auth.ErrPasswordTooLong):
+			message = "Password is too long"
+		case errors.Is(err, auth.ErrPasswordUsername):
+			message = "Password must not be the same as the username"
+		case errors.Is(err, auth.ErrPasswordBreached):
+			message = "Password has appeared in a data breach, choose a different password"
+		default:
+			message = "Invalid password"
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return "", false
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to hash password"})
+		return "", false
+	}
+	return string(passwordHash), true
+}
@@ -0,0 +1,93 @@
+/**
+* Name: 			profile_handler.go
+* Description: 		사용자 프로필 조회 및 수정 HTTP 핸들러
+* Workflow: 		본인 프로필(기본 정보, 직업, 주거래 은행, 지역, 자녀 유무)과 LLM 공유 항목 조회 → 보낸 항목만 변경 후 검증하여 저장 → 다음 시뮬레이션부터 공유 항목만 대화 엔진에 전달
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// /api/profile 수정 요청 바디, 보낸 항목만 변경 (빈 문자열, null이면 항목 삭제)
+type UpdateProfileRequest struct {
+	models.UserProfile
+	LLMSharedFields *[]string `json:"llm_shared_fields" example:"name,age,occupation"` // LLM에 전달할 항목, 생략하면 유지
+}
+
+// GetProfile godoc
+// @Summary      프로필 조회 (Profile)
+// @Description  본인의 계정 정보와 프로필, LLM 공유 항목(`llm_shared_fields`)을 조회합니다.
+// @Description  시뮬레이션의 사기범 역할 LLM에는 공유 항목으로 선택한 프로필 항목만 전달됩니다. 선택한 적이 없으면 name, age, gender를 공유합니다.
+// @Tags         API (Protected)
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.User
+// @Failure      401 {object} handler.ErrorResponse "인증 토큰 누락 또는 만료"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/profile [get]
+func GetProfile(c *gin.Context) {
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateProfile godoc
+// @Summary      프로필 수정
+// @Description  본인의 프로필과 LLM 공유 항목을 수정합니다. 보낸 항목만 변경되며, 텍스트 항목은 빈 문자열, `has_children`은 null로 삭제합니다.
+// @Description  추가 항목(occupation, bank, region, has_children)은 `llm_shared_fields`에 포함해야 시뮬레이션에 사용됩니다. 빈 배열이면 어떤 항목도 공유하지 않습니다.
+// @Description  공유 항목: name, age, gender, occupation, bank, region, has_children. 텍스트 항목은 최대 50자입니다.
+// @Tags         API (Protected)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.UpdateProfileRequest true "변경할 프로필 항목"
+// @Success      200 {object} models.User
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 항목 값"
+// @Failure      401 {object} handler.ErrorResponse "인증 토큰 누락 또는 만료"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/profile [patch]
+func UpdateProfile(c *gin.Context) {
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	// 현재 프로필 위에 요청 바디를 덮어써서 보내지 않은 항목은 유지
+	request := UpdateProfileRequest{UserProfile: user.Profile}
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	request.UserProfile.Normalize()
+	if err := request.UserProfile.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.LLMSharedFields != nil {
+		sharedFields, err := models.NormalizeLLMSharedFields(*request.LLMSharedFields)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		user.LLMSharedFields = sharedFields
+	}
+	user.Profile = request.UserProfile
+
+	if err := storage.UpdateUserProfile(user.ID, user.Profile, user.LLMSharedFields); err != nil {
+		log.Printf("[ERROR] UpdateUserProfile failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,70 @@
+/**
+* Name: 			progress_handler.go
+* Description: 		훈련생 진행 상황 조회 핸들러
+* Workflow: 		현재 커리큘럼, 사용자 조직에서 허용된 활성 시나리오, 사용자 진행 기록으로 모듈/시나리오별 상태 계산
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/curriculum"
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 시뮬레이션 시작 시 선수 과정(이전 모듈) 완료 여부 검사, main()에서 설정
+var enforcePrerequisites bool
+
+func SetPrerequisiteEnforcement(enabled bool) {
+	enforcePrerequisites = enabled
+}
+
+// 진행 상황 응답
+type ProgressResponse struct {
+	curriculum.Overview
+	EnforcePrerequisites bool `json:"enforce_prerequisites"` // true이면 locked 시나리오는 시작할 수 없음
+}
+
+// GetProgress godoc
+// @Summary      훈련 진행 상황 조회
+// @Description  커리큘럼 모듈 순서대로 시나리오별 진행 상태(`completed`, `in_progress`, `available`, `locked`)와 시도 횟수, 점수를 반환합니다.
+// @Description  시나리오는 평가를 통과한(치명 항목 없음) 세션의 점수가 모듈의 `pass_score` 이상이면 완료되며, 이전 모듈의 시나리오를 모두 완료해야 다음 모듈이 열립니다.
+// @Description  커리큘럼에 포함되지 않은 시나리오는 `electives`에 표시되며 항상 시작할 수 있습니다. 소속 조직에서 허용되지 않은 시나리오는 제외됩니다.
+// @Tags         API (Protected)
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  handler.ProgressResponse
+// @Failure      401  {object}  handler.ErrorResponse "인증 실패"
+// @Failure      500  {object}  handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/progress [get]
+func GetProgress(c *gin.Context) {
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	overview, err := loadProgress(user)
+	if err != nil {
+		log.Printf("[ERROR] loadProgress failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch progress"})
+		return
+	}
+	c.JSON(http.StatusOK, ProgressResponse{Overview: overview, EnforcePrerequisites: enforcePrerequisites})
+}
+
+// 사용자의 진행 상황 계산 (조직에서 허용되지 않은 시나리오는 제외)
+func loadProgress(user models.User) (curriculum.Overview, error) {
+	scenarios, err := availableScenarios(user)
+	if err != nil {
+		return curriculum.Overview{}, err
+	}
+	progress, err := storage.GetUserProgress(user.ID)
+	if err != nil {
+		return curriculum.Overview{}, err
+	}
+	return curriculum.Build(curriculum.Current(), scenarios, progress), nil
+}
@@ -0,0 +1,280 @@
+/**
+* Name: 			scenario_handler.go
+* Description: 		시나리오 조회 및 관리용 HTTP 핸들러
+* Workflow: 		시나리오 목록 조회, 생성, 수정, 비활성화 (관리자)
+ */
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// /api/admin/scenarios 생성 요청 바디
+type CreateScenarioRequest struct {
+	Key string `json:"key" example:"card_delivery_scam"`
+	UpdateScenarioRequest
+}
+
+// /api/admin/scenarios/{key} 수정 요청 바디
+type UpdateScenarioRequest struct {
+	Name             string   `json:"name" example:"Card Delivery Scam"`
+	Description      string   `json:"description" example:"A scenario where the user receives a fake card delivery call."`
+	Modes            []string `json:"modes" example:"text,voice"`
+	Difficulty       string   `json:"difficulty" example:"medium"`
+	EstimatedMinutes int      `json:"estimated_minutes" example:"10"`
+	Persona          string   `json:"persona" example:"카드사 배송 담당 직원을 사칭하는 30대 남성"`
+	OpeningLine      string   `json:"opening_line" example:"안녕하세요, OO카드 배송팀입니다."`
+	Goals            []string `json:"goals" example:"obtain card number,obtain OTP"`
+	ForbiddenTopics  []string `json:"forbidden_topics" example:"real company names"`
+	Voice            string   `json:"voice" example:"ko-KR-Wavenet-C"`
+	Temperature      *float64 `json:"temperature" example:"0.7"` // 생략하면 생성 시 0.7, 수정 시 기존 값 유지
+	Enabled          *bool    `json:"enabled" example:"true"`
+
+	Script *models.DialogueScript `json:"script"`
+	Hints  []models.CoachHint     `json:"hints"`
+}
+
+// 요청 값을 시나리오에 반영 (temperature, enabled는 지정된 경우에만 변경)
+func (r UpdateScenarioRequest) applyTo(scenario *models.Scenario) {
+	scenario.Name = r.Name
+	scenario.Description = r.Description
+	scenario.Modes = r.Modes
+	scenario.Difficulty = r.Difficulty
+	scenario.EstimatedMinutes = r.EstimatedMinutes
+	scenario.Persona = r.Persona
+	scenario.OpeningLine = r.OpeningLine
+	scenario.Goals = r.Goals
+	scenario.ForbiddenTopics = r.ForbiddenTopics
+	scenario.Voice = r.Voice
+	if r.Temperature != nil {
+		scenario.Temperature = *r.Temperature
+	}
+	scenario.Script = r.Script
+	scenario.Hints = r.Hints
+	if r.Enabled != nil {
+		scenario.Enabled = *r.Enabled
+	}
+	scenario.ApplyDefaults()
+}
+
+// 훈련생용 시나리오 요약 (페르소나 등 LLM 설정은 제외)
+type ScenarioSummary struct {
+	Key              string   `json:"key" example:"loan_scam"`
+	Name             string   `json:"name" example:"Loan Scam"`
+	Description      string   `json:"description" example:"A scenario where the user is targeted with a loan scam call."`
+	Modes            []string `json:"modes" example:"text,voice"`
+	Difficulty       string   `json:"difficulty" example:"medium"`
+	EstimatedMinutes int      `json:"estimated_minutes" example:"8"`
+}
+
+// 시나리오 목록 응답 (Wrapper)
+type ScenarioListResponse struct {
+	Scenarios []ScenarioSummary `json:"scenarios"`
+}
+
+// 관리자용 시나리오 목록 응답 (Wrapper)
+type AdminScenarioListResponse struct {
+	Scenarios []models.Scenario `json:"scenarios"`
+}
+
+// ListScenarios godoc
+// @Summary      시나리오 목록 조회
+// @Description  시뮬레이션에 사용할 수 있는 활성 시나리오 목록을 반환합니다.
+// @Description  클라이언트는 `key`를 `/ws/simulation`의 `scenario` 파라미터로, `modes` 중 하나를 `mode` 파라미터로 사용합니다.
+// @Description  인증 헤더(선택)를 보내면 소속 조직에서 허용된 시나리오만 반환합니다.
+// @Tags         Simulation
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.ScenarioListResponse
+// @Failure      500 {object} handler.ErrorResponse "DB 조회 실패"
+// @Router       /api/scenarios [get]
+func ListScenarios(c *gin.Context) {
+	var scenarios []models.Scenario
+	var err error
+	if c.GetString("username") == "" {
+		scenarios, err = storage.GetScenarios(false)
+	} else {
+		user, ok := loadCurrentUser(c)
+		if !ok {
+			return
+		}
+		scenarios, err = availableScenarios(user)
+	}
+	if err != nil {
+		log.Printf("[ERROR] ListScenarios: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch scenarios"})
+		return
+	}
+
+	summaries := make([]ScenarioSummary, 0, len(scenarios))
+	for _, s := range scenarios {
+		summaries = append(summaries, newScenarioSummary(s))
+	}
+	c.JSON(http.StatusOK, ScenarioListResponse{Scenarios: summaries})
+}
+
+// 공개용 시나리오 요약 (페르소나 등 내부 설정 제외)
+func newScenarioSummary(s models.Scenario) ScenarioSummary {
+	return ScenarioSummary{
+		Key:              s.Key,
+		Name:             s.Name,
+		Description:      s.Description,
+		Modes:            s.Modes,
+		Difficulty:       s.Difficulty,
+		EstimatedMinutes: s.EstimatedMinutes,
+	}
+}
+
+// AdminListScenarios godoc
+// @Summary      시나리오 전체 조회 (관리자)
+// @Description  비활성화된 시나리오를 포함한 전체 시나리오 목록을 반환합니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.AdminScenarioListResponse
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 조회 실패"
+// @Router       /api/admin/scenarios [get]
+func AdminListScenarios(c *gin.Context) {
+	scenarios, err := storage.GetScenarios(true)
+	if err != nil {
+		log.Printf("[ERROR] AdminListScenarios: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch scenarios"})
+		return
+	}
+	c.JSON(http.StatusOK, AdminScenarioListResponse{Scenarios: scenarios})
+}
+
+// CreateScenario godoc
+// @Summary      시나리오 생성 (관리자)
+// @Description  새로운 시나리오를 등록합니다. 키는 소문자, 숫자, 밑줄만 사용할 수 있습니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.CreateScenarioRequest true "시나리오 정보"
+// @Success      201 {object} models.Scenario
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      409 {object} handler.ErrorResponse "이미 존재하는 키"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/scenarios [post]
+func CreateScenario(c *gin.Context) {
+	var request CreateScenarioRequest
+
+	rawData, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := json.Unmarshal(rawData, &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	scenario := models.Scenario{Key: request.Key, Enabled: true, Source: models.ScenarioSourceAdmin, Temperature: models.DefaultTemperature}
+	request.applyTo(&scenario)
+	if err := scenario.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := storage.CreateScenario(scenario); err != nil {
+		if errors.Is(err, storage.ErrScenarioExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Scenario key already exists"})
+		} else {
+			log.Printf("[ERROR] CreateScenario: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create scenario"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, scenario)
+}
+
+// UpdateScenario godoc
+// @Summary      시나리오 수정 (관리자)
+// @Description  시나리오 정보와 LLM 페르소나 설정을 수정합니다. `temperature`, `enabled`를 생략하면 기존 값을 유지합니다.
+// @Description  시나리오 팩 파일에서 로드된 시나리오는 파일이 변경되면 파일 내용으로 다시 덮어써집니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key     path string                         true "시나리오 키"
+// @Param        request body handler.UpdateScenarioRequest true "수정할 시나리오 정보"
+// @Success      200 {object} models.Scenario
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "시나리오 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/scenarios/{key} [put]
+func UpdateScenario(c *gin.Context) {
+	var request UpdateScenarioRequest
+
+	rawData, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := json.Unmarshal(rawData, &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	scenario, err := storage.GetScenarioByKey(c.Param("key"))
+	if err != nil {
+		respondScenarioLookupError(c, err)
+		return
+	}
+
+	request.applyTo(&scenario)
+	if err := scenario.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := storage.UpdateScenario(scenario); err != nil {
+		respondScenarioLookupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scenario)
+}
+
+// DisableScenario godoc
+// @Summary      시나리오 비활성화 (관리자)
+// @Description  시나리오를 비활성화합니다. 기존 통화 기록 보존을 위해 실제로 삭제하지는 않습니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key path string true "시나리오 키"
+// @Success      200 {object} handler.SuccessResponse
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "시나리오 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/scenarios/{key} [delete]
+func DisableScenario(c *gin.Context) {
+	if err := storage.DisableScenario(c.Param("key")); err != nil {
+		respondScenarioLookupError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Scenario disabled"})
+}
+
+func respondScenarioLookupError(c *gin.Context, err error) {
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Scenario not found"})
+		return
+	}
+	log.Printf("[ERROR] scenario storage error: %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+}
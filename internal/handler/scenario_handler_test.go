@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestScenarioTemperatureDefaultsOnlyOnCreate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/admin/scenarios", CreateScenario)
+	router.PUT("/api/admin/scenarios/:key", UpdateScenario)
+
+	send := func(method, path, body string) models.Scenario {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		if w.Code != http.StatusOK && w.Code != http.StatusCreated {
+			t.Fatalf("%s %s = %d (%s)", method, path, w.Code, w.Body)
+		}
+		var scenario models.Scenario
+		if err := json.Unmarshal(w.Body.Bytes(), &scenario); err != nil {
+			t.Fatalf("decoding scenario: %v", err)
+		}
+		return scenario
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   float64
+	}{
+		{"create without temperature", http.MethodPost, "/api/admin/scenarios", `{"key":"temperature_test","name":"Temperature Test"}`, models.DefaultTemperature},
+		{"update with temperature", http.MethodPut, "/api/admin/scenarios/temperature_test", `{"name":"Temperature Test","temperature":0.2}`, 0.2},
+		{"update without temperature keeps value", http.MethodPut, "/api/admin/scenarios/temperature_test", `{"name":"Renamed"}`, 0.2},
+		{"update with zero temperature", http.MethodPut, "/api/admin/scenarios/temperature_test", `{"name":"Renamed","temperature":0}`, 0},
+		{"update without temperature keeps zero", http.MethodPut, "/api/admin/scenarios/temperature_test", `{"name":"Renamed"}`, 0},
+		{"create with zero temperature", http.MethodPost, "/api/admin/scenarios", `{"key":"temperature_zero","name":"Zero","temperature":0}`, 0},
+	}
+	for _, tt := range tests {
+		if got := send(tt.method, tt.path, tt.body); got.Temperature != tt.want {
+			t.Errorf("%s: temperature = %v, want %v", tt.name, got.Temperature, tt.want)
+		}
+	}
+}
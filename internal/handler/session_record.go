@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/evaluation"
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/session"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"log"
+	"time"
+)
+
+// 세션 종료 후 기록(Record)과 대화 기록, 평가, 진행 기록 저장, 저장된 기록 ID 반환 (실패 시 nil)
+func saveSessionRecord(username string, scenario models.Scenario, mode string, coach bool, filePath string, duration time.Duration, state *session.StateMachine, transcript *session.Transcript) *int {
+	user, err := storage.GetUserByUsername(username)
+	if err != nil {
+		log.Printf("saveSessionRecord(): Failed to get user ID for archiving: %v", err)
+		return nil
+	}
+
+	record := models.Record{
+		UserID:     user.ID,
+		OrgID:      user.OrgID,
+		Scenario:   scenario.Key,
+		Mode:       mode,
+		FilePath:   filePath,
+		DurationMs: duration.Milliseconds(),
+		Outcome:    state.Outcome(),
+		FinalState: string(state.State()),
+		Coach:      coach,
+	}
+	recordID, err := storage.CreateRecords(record)
+	if err != nil {
+		log.Printf("saveSessionRecord(): Failed to save Record to database: %v", err)
+		return nil
+	}
+	log.Printf("saveSessionRecord(): Successfully saved %s Record %d for user: %s, path: %s", mode, recordID, username, filePath)
+
+	// 대화 기록, 평가 저장 실패는 기록 자체를 무효화하지 않음
+	turns := transcript.Turns()
+	if err := storage.CreateTranscriptTurns(recordID, turns); err != nil {
+		log.Printf("saveSessionRecord(): Failed to save transcript for Record %d: %v", recordID, err)
+		return &recordID
+	}
+	log.Printf("saveSessionRecord(): Saved %d transcript turn(s) for Record %d", len(turns), recordID)
+
+	record.ID = recordID
+	report := evaluation.Evaluate(record, turns)
+	if err := storage.SaveRecordReport(report); err != nil {
+		log.Printf("saveSessionRecord(): Failed to save report for Record %d: %v", recordID, err)
+		return &recordID
+	}
+	if err := storage.RecordScenarioProgress(user.ID, scenario.Key, recordID, report.Score, report.Passed); err != nil {
+		log.Printf("saveSessionRecord(): Failed to update progress for Record %d: %v", recordID, err)
+	}
+	return &recordID
+}
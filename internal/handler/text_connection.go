@@ -3,39 +3,54 @@ package handler
 import (
 	"PishingSimulator_SecurityProject/internal/llm"
 	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/pii"
+	"PishingSimulator_SecurityProject/internal/session"
 	"context"
+	"encoding/json"
 	"log"
+	"strings"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
-func manageTextSession(conn *websocket.Conn, user models.User, parentCtx context.Context, scenarioKey string) {
+// 텍스트 세션, coach가 nil이 아니면 사기범 발화마다 힌트 조건을 검사해 coach.hint 전송
+func manageTextSession(conn *websocket.Conn, user models.User, engine llm.ConversationEngine, parentCtx context.Context, scenario models.Scenario, sessionID string, coach *session.Coach) {
 	defer conn.Close()
-	log.Printf("manageTextSession(): Text session started for user: %s, %s", user.Username, scenarioKey)
+	log.Printf("manageTextSession(): Text session started for user: %s, %s (coach: %t)", user.Username, scenario.Key, coach != nil)
 
-	llmSessionID := uuid.New().String()
+	llmSessionID := sessionID
+	state := session.NewStateMachine()
+	transcript := session.NewTranscript(time.Now())
+	endReason := EndReasonDisconnected
 
 	// 세션 종료 및 정리
 	defer func() {
 		log.Printf("manageTextSession(): Clearing session: %s", llmSessionID)
-		llm.ClearSession(llmSessionID)
+		engine.ClearSession(llmSessionID)
 	}()
 
 	// LLM 세션 초기화
-	initialUtterance, err := llm.InitSession(llmSessionID, scenarioKey, user.Profile, parentCtx)
+	initialUtterance, err := engine.InitSession(llmSessionID, scenario, user.LLMProfile(), parentCtx)
 	if err != nil {
 		log.Printf("manageTextSession(): LLM InitSession failed for user %s: %v", user.Username, err)
-		conn.WriteMessage(websocket.TextMessage, []byte("Error initializing session."))
+		writeEnvelope(conn, MsgError, ErrorData{Code: ErrCodeInitFailed, Message: "Error initializing session.", Fatal: true})
+		endSession(conn, SessionEndedData{Reason: EndReasonError, Outcome: state.Outcome(), FinalState: string(state.State())})
 		return
 	}
 
 	// 초기 발화 전송
 	log.Printf("manageTextSession(): LLM initial utterance for user %s: %s", user.Username, initialUtterance)
-	if err := conn.WriteMessage(websocket.TextMessage, []byte(initialUtterance)); err != nil {
+	elapsed := transcript.Elapsed()
+	transcript.Add(models.SpeakerAssistant, initialUtterance, elapsed, elapsed, nil)
+	if err := writeEnvelope(conn, MsgAssistantUtterance, AssistantUtteranceData{Text: initialUtterance, Step: string(state.State())}); err != nil {
 		log.Printf("manageTextSession(): Error sending initial utterance to user %s: %v", user.Username, err)
 		return
 	}
+	if err := sendCoachHints(conn, coach, transcript, initialUtterance, state.State()); err != nil {
+		log.Printf("manageTextSession(): Error sending coach hint to user %s: %v", user.Username, err)
+		return
+	}
 
 	// Half Duplex 대화 루프
 ReadLoop:
@@ -49,15 +64,47 @@ ReadLoop:
 		if messageType != websocket.TextMessage {
 			log.Printf("Unsupported message type from user %s: %d", user.Username, messageType)
 			continue
-		} else {
-			userText := string(message)
-			log.Printf("Received text message from user %s: %s", user.Username, userText)
+		}
+
+		envelope, err := parseClientMessage(message)
+		if err != nil {
+			log.Printf("manageTextSession(): Invalid message from user %s: %v", user.Username, err)
+			if err := writeEnvelope(conn, MsgError, ErrorData{Code: ErrCodeInvalidMessage, Message: err.Error()}); err != nil {
+				break ReadLoop
+			}
+			continue
+		}
+
+		switch envelope.Type {
+		case MsgSessionEnd:
+			log.Printf("manageTextSession(): Session ended by user %s", user.Username)
+			endReason = EndReasonClientEnded
+			break ReadLoop
+
+		case MsgUserMessage:
+			var data UserMessageData
+			if err := json.Unmarshal(envelope.Data, &data); err != nil || strings.TrimSpace(data.Text) == "" {
+				if err := writeEnvelope(conn, MsgError, ErrorData{Code: ErrCodeInvalidMessage, Message: "user.message requires data.text"}); err != nil {
+					break ReadLoop
+				}
+				continue
+			}
+			userText := data.Text
+			log.Printf("Received text message from user %s: %s", user.Username, pii.Redact(userText))
+			elapsed := transcript.Elapsed()
+			transcript.Add(models.SpeakerUser, userText, elapsed, elapsed, nil)
+
+			// 수신한 발화를 대화 기록으로 확정
+			if err := writeEnvelope(conn, MsgUserTranscript, TranscriptData{Text: userText}); err != nil {
+				log.Printf("Error sending transcript to user %s: %v", user.Username, err)
+				break ReadLoop
+			}
 
 			// LLM에 API를 호출하고 응답을 받는다.
-			chatResp, err := llm.Chat(llmSessionID, userText, parentCtx)
+			chatResp, err := engine.Chat(llmSessionID, userText, parentCtx)
 			if err != nil {
 				log.Printf("LLM Chat failed for user %s: %v", user.Username, err)
-				if err := conn.WriteMessage(websocket.TextMessage, []byte("Error processing your message.")); err != nil {
+				if err := writeEnvelope(conn, MsgError, ErrorData{Code: ErrCodeLLMFailed, Message: "Error processing your message."}); err != nil {
 					log.Printf("Error sending error message to user %s: %v", user.Username, err)
 					break ReadLoop
 				}
@@ -65,12 +112,54 @@ ReadLoop:
 			}
 
 			// LLM 응답을 클라이언트에 전송한다.
-			log.Printf("LLM response for user %s: %s", user.Username, chatResp.Utterance)
-			if err := conn.WriteMessage(websocket.TextMessage, []byte(chatResp.Utterance)); err != nil {
+			log.Printf("LLM response for user %s: %s (next: %s)", user.Username, chatResp.Utterance, chatResp.NextStep)
+			currentState, done := state.Apply(chatResp.NextStep)
+			elapsed = transcript.Elapsed()
+			transcript.Add(models.SpeakerAssistant, chatResp.Utterance, elapsed, elapsed, nil)
+			if err := writeEnvelope(conn, MsgAssistantUtterance, AssistantUtteranceData{Text: chatResp.Utterance, Step: string(currentState)}); err != nil {
 				log.Printf("Error sending message to user %s: %v", user.Username, err)
 				break ReadLoop
 			}
+			if !done {
+				if err := sendCoachHints(conn, coach, transcript, chatResp.Utterance, currentState); err != nil {
+					log.Printf("Error sending coach hint to user %s: %v", user.Username, err)
+					break ReadLoop
+				}
+			}
+
+			// 시나리오가 종료 단계에 도달하면 세션 종료
+			if done {
+				log.Printf("manageTextSession(): Scenario concluded for user %s: %s", user.Username, currentState)
+				endReason = EndReasonCompleted
+				break ReadLoop
+			}
+
+		default:
+			if err := writeEnvelope(conn, MsgError, ErrorData{Code: ErrCodeInvalidMessage, Message: "unsupported message type: " + envelope.Type}); err != nil {
+				break ReadLoop
+			}
+		}
+	}
+	duration := transcript.Elapsed()
+	log.Printf("Text session ended for user: %s (reason: %s, state: %s, outcome: %s, duration: %s)", user.Username, endReason, state.State(), state.Outcome(), duration)
+
+	// 기록 저장 후 종료 알림 (연결이 끊긴 경우 전송 실패는 무시)
+	recordID := saveSessionRecord(user.Username, scenario, models.ModeText, coach != nil, "", duration, state, transcript)
+	if endReason != EndReasonDisconnected {
+		endSession(conn, SessionEndedData{Reason: endReason, Outcome: state.Outcome(), FinalState: string(state.State()), RecordID: recordID})
+	}
+}
+
+// 사기범 발화에 해당하는 코칭 힌트 전송 및 대화 기록에 추가
+func sendCoachHints(conn *websocket.Conn, coach *session.Coach, transcript *session.Transcript, utterance string, step session.State) error {
+	if coach == nil {
+		return nil
+	}
+	for _, hint := range coach.Check(utterance, step) {
+		transcript.AddHint(hint, transcript.Elapsed())
+		if err := writeEnvelope(conn, MsgCoachHint, CoachHintData{HintID: hint.ID, Message: hint.Message}); err != nil {
+			return err
 		}
 	}
-	log.Printf("Text session ended for user: %s", user.Username)
+	return nil
 }
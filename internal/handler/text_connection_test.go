@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/llm"
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 임시 디렉토리의 DB로 핸들러 테스트 실행
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "handler-test")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	storage.InitDB()
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// 스크립트 엔진으로 텍스트 세션을 여는 테스트 서버에 연결
+func dialTextSession(t *testing.T, engine llm.ConversationEngine, user models.User, scenario models.Scenario) *websocket.Conn {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade: %v", err)
+			return
+		}
+		manageTextSession(conn, user, engine, context.Background(), scenario, "test-"+user.Username, nil)
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func readEnvelope(t *testing.T, conn *websocket.Conn, wantType string, data any) {
+	t.Helper()
+	var envelope WSEnvelope
+	if err := conn.ReadJSON(&envelope); err != nil {
+		t.Fatalf("reading %s: %v", wantType, err)
+	}
+	if envelope.Type != wantType {
+		t.Fatalf("message type = %s (%s), want %s", envelope.Type, envelope.Data, wantType)
+	}
+	if data != nil {
+		if err := json.Unmarshal(envelope.Data, data); err != nil {
+			t.Fatalf("decoding %s: %v", wantType, err)
+		}
+	}
+}
+
+func createTestUser(t *testing.T, username string) models.User {
+	t.Helper()
+	if err := storage.CreateUser(username, "hash", models.UserProfile{Name: "홍길동", Age: 30}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	user, err := storage.GetUserByUsername(username)
+	if err != nil {
+		t.Fatalf("GetUserByUsername: %v", err)
+	}
+	return user
+}
+
+func TestTextSessionCompletesWithScriptedEngine(t *testing.T) {
+	user := createTestUser(t, "text_completed")
+	scenario := models.Scenario{Key: "loan_scam", Name: "Loan Scam", OpeningLine: "안녕하세요, OO은행입니다."}
+	engine := llm.NewScriptedEngine([]string{"인증번호를 불러 주세요.", "처리되었습니다."})
+	conn := dialTextSession(t, engine, user, scenario)
+
+	var utterance AssistantUtteranceData
+	readEnvelope(t, conn, MsgAssistantUtterance, &utterance)
+	if utterance.Text != scenario.OpeningLine {
+		t.Errorf("opening = %q, want %q", utterance.Text, scenario.OpeningLine)
+	}
+
+	turns := []struct {
+		text, reply, step string
+	}{
+		{"네, 말씀하세요.", "인증번호를 불러 주세요.", llm.NextStepPressure},
+		{"123456 입니다.", "처리되었습니다.", llm.NextStepClosing},
+	}
+	for _, turn := range turns {
+		if err := conn.WriteJSON(WSEnvelope{Version: 1, Type: MsgUserMessage, Data: json.RawMessage(`{"text":"` + turn.text + `"}`)}); err != nil {
+			t.Fatalf("WriteJSON: %v", err)
+		}
+		var transcript TranscriptData
+		readEnvelope(t, conn, MsgUserTranscript, &transcript)
+		if transcript.Text != turn.text {
+			t.Errorf("transcript = %q, want %q", transcript.Text, turn.text)
+		}
+		readEnvelope(t, conn, MsgAssistantUtterance, &utterance)
+		if utterance.Text != turn.reply || utterance.Step != turn.step {
+			t.Errorf("reply = (%q, %q), want (%q, %q)", utterance.Text, utterance.Step, turn.reply, turn.step)
+		}
+	}
+
+	var ended SessionEndedData
+	readEnvelope(t, conn, MsgSessionEnded, &ended)
+	if ended.Reason != EndReasonCompleted || ended.Outcome != models.OutcomeResisted || ended.RecordID == nil {
+		t.Fatalf("session.ended = %+v, want completed, resisted with record", ended)
+	}
+	turnsSaved, err := storage.GetTranscriptByRecordID(*ended.RecordID)
+	if err != nil {
+		t.Fatalf("GetTranscriptByRecordID: %v", err)
+	}
+	if len(turnsSaved) != 5 {
+		t.Errorf("saved %d transcript turns, want 5", len(turnsSaved))
+	}
+}
+
+func TestTextSessionRejectsInvalidMessagesAndEndsOnRequest(t *testing.T) {
+	user := createTestUser(t, "text_client_ended")
+	conn := dialTextSession(t, llm.NewScriptedEngine(nil), user, models.Scenario{Key: "loan_scam", Name: "Loan Scam"})
+	readEnvelope(t, conn, MsgAssistantUtterance, nil)
+
+	for _, raw := range []string{
+		`not json`,
+		`{"v":1,"type":"user.message","data":{"text":"   "}}`,
+		`{"v":1,"type":"unknown.type"}`,
+	} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(raw)); err != nil {
+			t.Fatalf("WriteMessage: %v", err)
+		}
+		var data ErrorData
+		readEnvelope(t, conn, MsgError, &data)
+		if data.Code != ErrCodeInvalidMessage {
+			t.Errorf("%s: error code = %s, want %s", raw, data.Code, ErrCodeInvalidMessage)
+		}
+	}
+
+	if err := conn.WriteJSON(WSEnvelope{Version: 1, Type: MsgSessionEnd}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var ended SessionEndedData
+	readEnvelope(t, conn, MsgSessionEnded, &ended)
+	if ended.Reason != EndReasonClientEnded {
+		t.Errorf("reason = %s, want %s", ended.Reason, EndReasonClientEnded)
+	}
+}
@@ -0,0 +1,128 @@
+/**
+* Name: 			ticket_handler.go
+* Description: 		WebSocket 연결 및 녹음 재생용 1회용 티켓 발급 HTTP 핸들러
+* Workflow: 		JWT로 인증된 사용자에게 리소스(시나리오, 기록)에 묶인 짧은 유효 기간의 티켓 발급, JWT 대신 URL 쿼리(?ticket=)로 사용하여 프록시 로그 등에 토큰이 남지 않도록 함
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 티켓 유효 기간, 발급 직후 바로 사용하는 용도이므로 짧게 유지
+const (
+	simulationTicketTTL  = 30 * time.Second
+	recordAudioTicketTTL = 60 * time.Second
+)
+
+// /api/ws-ticket 요청 바디
+type WSTicketRequest struct {
+	Scenario string `json:"scenario" example:"loan_scam"` // 연결할 시나리오 키
+}
+
+// /api/ws-ticket 응답
+type WSTicketResponse struct {
+	Ticket    string `json:"ticket" example:"b1Xo3k..."`
+	ExpiresIn int    `json:"expires_in" example:"30"` // 유효 기간 (초)
+}
+
+// /api/history/{id}/audio-url 응답
+type AudioURLResponse struct {
+	URL       string `json:"url" example:"/api/history/12/audio?ticket=b1Xo3k..."`
+	ExpiresIn int    `json:"expires_in" example:"60"` // 유효 기간 (초)
+}
+
+// CreateWSTicket godoc
+// @Summary      WebSocket 연결 티켓 발급
+// @Description  `/ws/simulation` 연결에 사용할 1회용 티켓을 발급합니다. 티켓은 요청한 사용자와 시나리오에 묶이며 30초 안에 한 번만 사용할 수 있습니다.
+// @Description  JWT를 URL에 넣는 대신 `ticket` 쿼리로 연결하세요. (예: `/ws/simulation?ticket=...&scenario=loan_scam&mode=text`)
+// @Tags         API (Protected)
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.WSTicketRequest true "연결할 시나리오"
+// @Success      200 {object} handler.WSTicketResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 시나리오 키"
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/ws-ticket [post]
+func CreateWSTicket(c *gin.Context) {
+	var request WSTicketRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil || request.Scenario == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if _, exists := models.GetScenario(request.Scenario); !exists {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scenario key"})
+		return
+	}
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	ticket, err := storage.CreateAccessTicket(user.ID, models.TicketPurposeSimulation, request.Scenario, simulationTicketTTL)
+	if err != nil {
+		log.Printf("[ERROR] CreateAccessTicket failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ticket"})
+		return
+	}
+	c.JSON(http.StatusOK, WSTicketResponse{Ticket: ticket, ExpiresIn: int(simulationTicketTTL.Seconds())})
+}
+
+// CreateRecordAudioURL godoc
+// @Summary      녹음 재생 URL 발급
+// @Description  `<audio>` 요소처럼 Authorization 헤더를 보낼 수 없는 곳에서 사용할 녹음 재생 URL을 발급합니다.
+// @Description  URL의 티켓은 요청한 사용자와 기록에 묶이며 60초 안에 한 번만 사용할 수 있습니다. 다시 재생하려면 새 URL을 발급받으세요.
+// @Description  조회 권한은 `/api/history/{id}/audio`와 같습니다.
+// @Tags         API (Protected)
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "기록 ID (GET /api/history의 id)"
+// @Success      200  {object}  handler.AudioURLResponse
+// @Failure      400  {object}  handler.ErrorResponse "잘못된 기록 ID"
+// @Failure      401  {object}  handler.ErrorResponse "인증 실패"
+// @Failure      404  {object}  handler.ErrorResponse "기록 또는 녹음 파일 없음"
+// @Failure      500  {object}  handler.ErrorResponse "서버 내부 오류"
+// @Router       /api/history/{id}/audio-url [post]
+func CreateRecordAudioURL(c *gin.Context) {
+	record, ok := loadAccessibleRecord(c)
+	if !ok {
+		return
+	}
+	if record.FilePath == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
+		return
+	}
+	if _, err := os.Stat(record.FilePath); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
+		return
+	}
+	user, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	recordID := strconv.Itoa(record.ID)
+	ticket, err := storage.CreateAccessTicket(user.ID, models.TicketPurposeRecordAudio, recordID, recordAudioTicketTTL)
+	if err != nil {
+		log.Printf("[ERROR] CreateAccessTicket failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create ticket"})
+		return
+	}
+	c.JSON(http.StatusOK, AudioURLResponse{
+		URL:       "/api/history/" + recordID + "/audio?ticket=" + ticket,
+		ExpiresIn: int(recordAudioTicketTTL.Seconds()),
+	})
+}
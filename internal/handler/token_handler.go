@@ -0,0 +1,157 @@
+/**
+* Name: 			token_handler.go
+* Description: 		액세스 토큰 재발급, 로그아웃 및 토큰 검증용 공개 키(JWKS) HTTP 핸들러
+* Workflow: 		리프레시 토큰 교체(rotation) 후 새 액세스 토큰 발급, 재사용된 리프레시 토큰은 세션 전체 폐기, 로그아웃 시 리프레시 토큰 세션과 액세스 토큰(jti) 폐기, 다른 서비스가 토큰을 검증할 수 있도록 공개 키 제공
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/auth"
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// /token/refresh, /logout 요청 바디
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"q3Zr9m2K..."`
+}
+
+// RefreshToken godoc
+// @Summary      액세스 토큰 재발급
+// @Description  리프레시 토큰으로 새 액세스 토큰과 새 리프레시 토큰을 발급받습니다. 사용한 리프레시 토큰은 더 이상 쓸 수 없습니다.
+// @Description  이미 사용된 리프레시 토큰이 다시 들어오면 탈취로 간주하여 해당 로그인 세션의 리프레시 토큰을 모두 폐기합니다.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        request body handler.RefreshTokenRequest true "리프레시 토큰"
+// @Success      200 {object} handler.LoginSuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      401 {object} handler.ErrorResponse "유효하지 않거나 재사용된 리프레시 토큰"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /token/refresh [post]
+func RefreshToken(c *gin.Context) {
+	request, ok := parseRefreshTokenRequest(c)
+	if !ok {
+		return
+	}
+
+	session, err := storage.RotateRefreshToken(request.RefreshToken, auth.RefreshTokenTTL())
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRefreshTokenReused):
+			log.Printf("RefreshToken(): Refresh token reuse detected for user %d from %s", session.UserID, c.ClientIP())
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token reuse detected"})
+		case errors.Is(err, storage.ErrRefreshTokenInvalid):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		default:
+			log.Printf("[ERROR] RotateRefreshToken failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		}
+		return
+	}
+
+	// 역할 변경이 반영되도록 사용자 정보를 다시 조회
+	user, err := storage.GetUserByID(session.UserID)
+	if err != nil {
+		log.Printf("[ERROR] GetUserByID failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+	respondTokens(c, user, session.Token, session.MFA)
+}
+
+// Logout godoc
+// @Summary      로그아웃
+// @Description  리프레시 토큰이 속한 로그인 세션을 폐기합니다. `Authorization` 헤더에 액세스 토큰을 함께 보내면 해당 액세스 토큰도 만료 전에 즉시 폐기됩니다.
+// @Description  이미 폐기되었거나 알 수 없는 리프레시 토큰이어도 성공으로 응답합니다.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.RefreshTokenRequest true "리프레시 토큰"
+// @Success      200 {object} handler.SuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
+// @Router       /logout [post]
+func Logout(c *gin.Context) {
+	request, ok := parseRefreshTokenRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := storage.RevokeRefreshTokenFamily(request.RefreshToken); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Printf("[ERROR] RevokeRefreshTokenFamily failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+		return
+	}
+
+	// 만료되었거나 이미 폐기된 액세스 토큰은 검증에 실패하므로 따로 처리할 필요 없음
+	if tokenString, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); found {
+		if claims, err := auth.ValidateToken(tokenString); err == nil && claims.ID != "" {
+			if err := storage.RevokeAccessToken(claims.ID, claims.ExpiresAt.Time); err != nil {
+				log.Printf("[ERROR] RevokeAccessToken failed: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+				return
+			}
+			log.Printf("Logout(): User %s logged out", claims.Username)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// GetJWKS godoc
+// @Summary      토큰 검증용 공개 키 (JWKS)
+// @Description  액세스 토큰 서명을 검증할 수 있는 공개 키 목록(RFC 7517)을 반환합니다. 토큰 헤더의 `kid`로 키를 찾아 검증합니다.
+// @Description  키 교체 중에는 이전 키도 함께 포함되므로 주기적으로 다시 조회하세요.
+// @Tags         User
+// @Produce      json
+// @Success      200 {object} auth.JWKSet
+// @Router       /.well-known/jwks.json [get]
+func GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, auth.PublicJWKS())
+}
+
+func parseRefreshTokenRequest(c *gin.Context) (RefreshTokenRequest, bool) {
+	var request RefreshTokenRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil || request.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return request, false
+	}
+	return request, true
+}
+
+// 새 로그인 세션 시작, 리프레시 토큰과 액세스 토큰 발급 (mfa: 2단계 인증을 거친 로그인)
+func issueSession(c *gin.Context, user models.User, mfa bool) {
+	refreshToken, err := storage.CreateRefreshToken(user.ID, mfa, auth.RefreshTokenTTL())
+	if err != nil {
+		log.Printf("[ERROR] CreateRefreshToken failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	respondTokens(c, user, refreshToken, mfa)
+}
+
+// 새 액세스 토큰을 발급하여 리프레시 토큰과 함께 응답
+func respondTokens(c *gin.Context, user models.User, refreshToken string, mfa bool) {
+	tokenString, err := auth.GenerateToken(user.Username, user.Role, mfa)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	c.JSON(http.StatusOK, LoginSuccessResponse{
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(auth.AccessTokenTTL().Seconds()),
+	})
+}
@@ -0,0 +1,132 @@
+/**
+* Name: 			trainer_handler.go
+* Description: 		강사용 훈련생 조회 핸들러
+* Workflow: 		강사는 같은 그룹 훈련생, 조직 관리자는 같은 조직 훈련생, 관리자는 전체 훈련생의 목록과 시뮬레이션 기록 조회
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 훈련생 목록 응답 (Wrapper)
+type TraineeListResponse struct {
+	Trainees []models.User `json:"trainees"`
+}
+
+// ListTrainees godoc
+// @Summary      훈련생 목록 조회 (강사)
+// @Description  강사는 같은 그룹의 훈련생, 조직 관리자는 같은 조직의 훈련생, 관리자는 전체 훈련생 목록을 조회합니다. 그룹(조직 관리자는 조직)이 지정되지 않은 경우 빈 목록을 받습니다.
+// @Tags         Trainer
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} handler.TraineeListResponse
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      403 {object} handler.ErrorResponse "강사/조직 관리자/관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/trainer/trainees [get]
+func ListTrainees(c *gin.Context) {
+	viewer, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	// 조직 관리자는 조직 전체, 강사는 자신의 그룹 (그룹과 구성원의 조직은 항상 같으므로 조직이 없는 그룹도 그룹 기준)
+	filter := storage.UserFilter{Role: models.RoleTrainee}
+	switch viewer.Role {
+	case models.RoleOrgAdmin:
+		if viewer.OrgID == nil {
+			c.JSON(http.StatusOK, TraineeListResponse{Trainees: []models.User{}})
+			return
+		}
+		filter.OrgID = viewer.OrgID
+	case models.RoleTrainer:
+		if viewer.GroupID == nil {
+			c.JSON(http.StatusOK, TraineeListResponse{Trainees: []models.User{}})
+			return
+		}
+		filter.OrgID = viewer.OrgID
+		filter.GroupID = viewer.GroupID
+	}
+
+	trainees, err := storage.GetUsers(filter)
+	if err != nil {
+		log.Printf("[ERROR] GetUsers failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trainees"})
+		return
+	}
+	c.JSON(http.StatusOK, TraineeListResponse{Trainees: trainees})
+}
+
+// GetTraineeHistory godoc
+// @Summary      훈련생 기록 조회 (강사)
+// @Description  훈련생의 시뮬레이션 기록 목록을 최신순으로 반환합니다. 대화 기록, 평가 리포트, 녹음은 `/api/history/{id}/...`로 조회합니다.
+// @Description  강사는 같은 그룹의 훈련생, 조직 관리자는 같은 조직의 사용자만 조회할 수 있으며, 그 외 사용자는 404를 반환합니다.
+// @Description  관리자가 아니면 훈련생이 현재 조직에 소속된 동안 진행한 기록만 반환합니다.
+// @Tags         Trainer
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path   int    true  "훈련생 사용자 ID"
+// @Param        mode query  string false "모드 필터 (text, voice)"
+// @Success      200 {object} handler.HistoryResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 사용자 ID 또는 모드"
+// @Failure      401 {object} handler.ErrorResponse "인증 실패"
+// @Failure      403 {object} handler.ErrorResponse "강사/조직 관리자/관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "사용자 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/trainer/trainees/{id}/history [get]
+func GetTraineeHistory(c *gin.Context) {
+	traineeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || traineeID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
+		return
+	}
+	mode := c.Query("mode")
+	if mode != "" && !models.IsValidMode(mode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mode"})
+		return
+	}
+
+	viewer, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+	trainee, err := storage.GetUserByID(traineeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			log.Printf("[ERROR] GetUserByID failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		}
+		return
+	}
+	if !viewer.CanViewRecordsOf(trainee) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	// 관리자가 아니면 조회자 조직에서 진행한 기록만
+	var orgID *int
+	if viewer.Role != models.RoleAdmin {
+		orgID = viewer.OrgID
+	}
+	records, err := storage.GetRecordsByUserID(trainee.ID, orgID, mode)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch records"})
+		return
+	}
+	// 조직이 없는 강사는 조직 없이 진행한 기록만
+	records = slices.DeleteFunc(records, func(record models.Record) bool { return !viewer.CanViewRecord(record, trainee) })
+	c.JSON(http.StatusOK, HistoryResponse{History: records})
+}
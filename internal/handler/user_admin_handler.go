@@ -0,0 +1,357 @@
+/**
+* Name: 			user_admin_handler.go
+* Description: 		사용자 역할 및 그룹 관리용 HTTP 핸들러 (관리자)
+* Workflow: 		사용자 목록 조회, 역할 변경, 그룹 생성/조회, 사용자 그룹 지정 (그룹과 사용자의 조직 일치 확인)
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 사용자 목록 응답 (Wrapper)
+type UserListResponse struct {
+	Users []models.User `json:"users"`
+}
+
+// 그룹 목록 응답 (Wrapper)
+type GroupListResponse struct {
+	Groups []models.Group `json:"groups"`
+}
+
+// /api/admin/users/{id}/role 요청 바디
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" example:"trainer"`
+}
+
+// /api/admin/users/{id}/group 요청 바디, group_id가 null이면 그룹에서 제외
+type UpdateUserGroupRequest struct {
+	GroupID *int `json:"group_id" example:"3"`
+}
+
+// /api/admin/groups 요청 바디
+type CreateGroupRequest struct {
+	Name  string `json:"name" example:"2025 상반기 신입사원"`
+	OrgID *int   `json:"org_id" example:"1"` // 그룹이 속할 조직 (생략 시 조직 없음)
+}
+
+// AdminListUsers godoc
+// @Summary      사용자 목록 조회 (관리자)
+// @Description  사용자 목록을 사용자명 순으로 반환합니다. 역할, 조직, 그룹으로 필터링할 수 있습니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        role     query string false "역할 필터 (trainee, trainer, org_admin, admin)"
+// @Param        org_id   query int    false "조직 ID 필터"
+// @Param        group_id query int    false "그룹 ID 필터"
+// @Success      200 {object} handler.UserListResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 필터"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/users [get]
+func AdminListUsers(c *gin.Context) {
+	filter, ok := parseUserFilter(c)
+	if !ok {
+		return
+	}
+	if filter.OrgID, ok = parseIDQuery(c, "org_id", "Invalid organization id"); !ok {
+		return
+	}
+
+	users, err := storage.GetUsers(filter)
+	if err != nil {
+		log.Printf("[ERROR] GetUsers failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		return
+	}
+	c.JSON(http.StatusOK, UserListResponse{Users: users})
+}
+
+// UpdateUserRole godoc
+// @Summary      사용자 역할 변경 (관리자)
+// @Description  사용자의 역할(trainee, trainer, org_admin, admin)을 변경합니다. 역할별 API 접근은 DB의 역할로 확인하므로 변경된 역할은 해당 사용자의 기존 토큰에도 바로 적용됩니다.
+// @Description  관리자 계정이 없어지는 것을 막기 위해 자신의 역할은 변경할 수 없습니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                          true "사용자 ID"
+// @Param        request body handler.UpdateUserRoleRequest true "변경할 역할"
+// @Success      200 {object} models.User
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "사용자 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/users/{id}/role [put]
+func UpdateUserRole(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+	var request UpdateUserRoleRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if !models.IsValidRole(request.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be one of trainee, trainer, org_admin, admin"})
+		return
+	}
+
+	admin, ok := loadCurrentUser(c)
+	if !ok {
+		return
+	}
+	if admin.ID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot change your own role"})
+		return
+	}
+
+	if err := storage.UpdateUserRole(userID, request.Role); err != nil {
+		respondUserUpdateError(c, err)
+		return
+	}
+	respondUpdatedUser(c, userID)
+}
+
+// UpdateUserGroup godoc
+// @Summary      사용자 그룹 지정 (관리자)
+// @Description  사용자를 그룹에 배정합니다. `group_id`가 null이면 그룹에서 제외합니다. 그룹은 사용자와 같은 조직이어야 합니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                           true "사용자 ID"
+// @Param        request body handler.UpdateUserGroupRequest true "배정할 그룹"
+// @Success      200 {object} models.User
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청, 존재하지 않는 그룹 또는 다른 조직의 그룹"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "사용자 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/users/{id}/group [put]
+func UpdateUserGroup(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+	var request UpdateUserGroupRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	user, err := storage.GetUserByID(userID)
+	if err != nil {
+		respondUserUpdateError(c, err)
+		return
+	}
+	if !validateGroupAssignment(c, request.GroupID, user.OrgID) {
+		return
+	}
+
+	if err := storage.UpdateUserGroup(userID, request.GroupID); err != nil {
+		respondUserUpdateError(c, err)
+		return
+	}
+	respondUpdatedUser(c, userID)
+}
+
+// UnlockUserLogin godoc
+// @Summary      사용자 로그인 잠금 해제 (관리자)
+// @Description  연속 로그인 실패로 잠기거나 대기 중인 사용자의 실패 기록을 초기화하여 바로 로그인할 수 있도록 합니다.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "사용자 ID"
+// @Success      200 {object} handler.SuccessResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 사용자 ID"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      404 {object} handler.ErrorResponse "사용자 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/users/{id}/lockout [delete]
+func UnlockUserLogin(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+	user, err := storage.GetUserByID(userID)
+	if err != nil {
+		respondUserUpdateError(c, err)
+		return
+	}
+	if err := storage.ClearLoginFailures(user.Username); err != nil {
+		log.Printf("[ERROR] ClearLoginFailures failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlock user"})
+		return
+	}
+	log.Printf("UnlockUserLogin(): %s unlocked login of user %s", c.GetString("username"), user.Username)
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked"})
+}
+
+// AdminListGroups godoc
+// @Summary      그룹 목록 조회 (관리자)
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        org_id query int false "조직 ID 필터"
+// @Success      200 {object} handler.GroupListResponse
+// @Failure      400 {object} handler.ErrorResponse "잘못된 필터"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/groups [get]
+func AdminListGroups(c *gin.Context) {
+	orgID, ok := parseIDQuery(c, "org_id", "Invalid organization id")
+	if !ok {
+		return
+	}
+	groups, err := storage.GetGroups(orgID)
+	if err != nil {
+		log.Printf("[ERROR] GetGroups failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch groups"})
+		return
+	}
+	c.JSON(http.StatusOK, GroupListResponse{Groups: groups})
+}
+
+// CreateGroup godoc
+// @Summary      그룹 생성 (관리자)
+// @Description  훈련 그룹(팀, 기수)을 생성합니다. 강사는 같은 그룹 훈련생의 기록을 조회할 수 있습니다. `org_id`로 그룹이 속할 조직을 지정합니다.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.CreateGroupRequest true "그룹 정보"
+// @Success      201 {object} models.Group
+// @Failure      400 {object} handler.ErrorResponse "잘못된 요청 또는 존재하지 않는 조직"
+// @Failure      403 {object} handler.ErrorResponse "관리자 권한 없음"
+// @Failure      409 {object} handler.ErrorResponse "같은 조직에 이미 존재하는 그룹 이름"
+// @Failure      500 {object} handler.ErrorResponse "DB 오류"
+// @Router       /api/admin/groups [post]
+func CreateGroup(c *gin.Context) {
+	var request CreateGroupRequest
+	rawData, err := c.GetRawData()
+	if err != nil || json.Unmarshal(rawData, &request) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if request.OrgID != nil {
+		if _, err := storage.GetOrganizationByID(*request.OrgID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Organization not found"})
+			} else {
+				log.Printf("[ERROR] GetOrganizationByID failed: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization"})
+			}
+			return
+		}
+	}
+	createGroup(c, request.Name, request.OrgID)
+}
+
+// 그룹 생성 후 생성된 그룹 응답 (관리자, 조직 관리자 공용)
+func createGroup(c *gin.Context, name string, orgID *int) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Group name cannot be empty"})
+		return
+	}
+
+	group, err := storage.CreateGroup(name, orgID)
+	if err != nil {
+		if errors.Is(err, storage.ErrGroupExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Group name already exists in the organization"})
+		} else {
+			log.Printf("[ERROR] CreateGroup: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create group"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, group)
+}
+
+// 그룹 배정 검증, 그룹이 존재하고 orgID 조직에 속해야 함 (groupID가 nil이면 그룹 해제로 항상 허용)
+func validateGroupAssignment(c *gin.Context, groupID *int, orgID *int) bool {
+	if groupID == nil {
+		return true
+	}
+	group, err := storage.GetGroupByID(*groupID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Group not found"})
+		} else {
+			log.Printf("[ERROR] GetGroupByID failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get group"})
+		}
+		return false
+	}
+	if (group.OrgID == nil) != (orgID == nil) || (orgID != nil && *group.OrgID != *orgID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Group belongs to a different organization"})
+		return false
+	}
+	return true
+}
+
+// 사용자 목록의 role, group_id 필터 파싱
+func parseUserFilter(c *gin.Context) (storage.UserFilter, bool) {
+	filter := storage.UserFilter{Role: c.Query("role")}
+	if filter.Role != "" && !models.IsValidRole(filter.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+		return filter, false
+	}
+	groupID, ok := parseIDQuery(c, "group_id", "Invalid group id")
+	filter.GroupID = groupID
+	return filter, ok
+}
+
+// 선택 쿼리 파라미터의 ID 파싱, 없으면 nil
+func parseIDQuery(c *gin.Context, name string, message string) (*int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return nil, false
+	}
+	return &id, true
+}
+
+func parseUserIDParam(c *gin.Context) (int, bool) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
+		return 0, false
+	}
+	return userID, true
+}
+
+func respondUserUpdateError(c *gin.Context, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	log.Printf("[ERROR] Failed to update user: %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
+}
+
+func respondUpdatedUser(c *gin.Context, userID int) {
+	user, err := storage.GetUserByID(userID)
+	if err != nil {
+		respondUserUpdateError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
@@ -1,7 +1,7 @@
 /**
 * Name: 			auth_handler.go
 * Description: 		Gin 프레임워크의 HTTP 핸들러
-* Workflow: 		회원가입, 로그인, 프로필 조회
+* Workflow: 		회원가입, 로그인, 통화 기록 조회
  */
 package handler
 
@@ -10,10 +10,13 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"math"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"PishingSimulator_SecurityProject/internal/auth"
 	"PishingSimulator_SecurityProject/internal/models"
@@ -43,13 +46,9 @@ type ErrorResponse struct {
 	Error string `json:"error" example:"에러 원인 및 설명"`
 }
 type LoginSuccessResponse struct {
-	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-}
-
-// 프로필 조회 응답
-type ProfileResponse struct {
-	Message  string `json:"message" example:"this is a protected profile"`
-	Username string `json:"username" example:"gildong"`
+	Token        string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 액세스 토큰
+	RefreshToken string `json:"refresh_token" example:"q3Zr9m2K..."`                     // 액세스 토큰 재발급용 (1회용)
+	ExpiresIn    int    `json:"expires_in" example:"900"`                                // 액세스 토큰 유효 기간 (초)
 }
 
 // 통화 기록 목록 응답 (Wrapper)
@@ -60,12 +59,16 @@ type HistoryResponse struct {
 // Signup godoc
 // @Summary      회원가입 (Signup)
 // @Description  새로운 사용자 계정을 생성합니다.
+// @Description  비밀번호는 최소 길이(기본 10자)를 만족해야 하며 사용자명과 같거나 유출된 비밀번호 목록에 있으면 사용할 수 없습니다.
+// @Description  `X-Invite-Code` 헤더로 초대 코드를 보내면 코드에 지정된 조직, 역할, 그룹이 적용됩니다. (SIGNUP_INVITE_REQUIRED=true이면 필수)
 // @Tags         User
 // @Accept       json
 // @Produce      json
+// @Param        X-Invite-Code header string false "초대 코드 (예: K7QX-M2PA-9FZD-W4TR)"
 // @Param        request body handler.SignupRequest true "회원가입 요청 정보"
 // @Success      200 {object} handler.SuccessResponse
 // @Failure      400 {object} handler.ErrorResponse
+// @Failure      403 {object} handler.ErrorResponse "초대 코드 누락 또는 유효하지 않은 초대 코드"
 // @Failure      500 {object} handler.ErrorResponse
 // @Router       /signup [post]
 func Signup(c *gin.Context) {
@@ -91,17 +94,29 @@ func Signup(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Age must be a positive number"})
 		return
 	}
+	credentials.Profile.Normalize()
+	if err := credentials.Profile.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	// password 해싱
-	HashedPassword, err := bcrypt.GenerateFromPassword([]byte(credentials.Password), bcrypt.DefaultCost)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to hash password"})
+	// 비밀번호 정책 검사 및 해싱
+	HashedPassword, ok := hashNewPassword(c, credentials.Password, credentials.Username)
+	if !ok {
 		return
 	}
-	// DB에 사용자 생성
-	if err := storage.CreateUser(credentials.Username, string(HashedPassword), credentials.Profile); err != nil {
+	// DB에 사용자 생성 (초대 코드가 있으면 코드의 조직, 역할, 그룹 적용 및 사용 기록)
+	if inviteCode := c.GetString("invite_code"); inviteCode != "" {
+		user := models.User{Username: credentials.Username, PasswordHash: HashedPassword, Profile: credentials.Profile}
+		err = storage.CreateUserWithInviteCode(inviteCode, user, c.ClientIP())
+	} else {
+		err = storage.CreateUser(credentials.Username, HashedPassword, credentials.Profile)
+	}
+	if err != nil {
 		if errors.Is(err, storage.ErrUsernameExists) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Username already exists"})
+		} else if errors.Is(err, storage.ErrInviteCodeInvalid) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid invite code"})
 		} else {
 			log.Printf("[ERROR] Failed to create user (database error): %v", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user (database error)"})
@@ -115,14 +130,19 @@ func Signup(c *gin.Context) {
 
 // Login godoc
 // @Summary      로그인 (Login)
-// @Description  사용자명과 비밀번호로 로그인하고 JWT 토큰을 발급받습니다.
+// @Description  사용자명과 비밀번호로 로그인하고 JWT 액세스 토큰과 리프레시 토큰을 발급받습니다.
+// @Description  액세스 토큰은 짧게 유지되므로(기본 15분) 만료되면 `/token/refresh`로 재발급받습니다.
+// @Description  2단계 인증을 사용하는 계정은 토큰 대신 202와 함께 `mfa_token`을 반환하며, `/login/mfa`에 인증 코드와 함께 보내 토큰을 발급받습니다.
+// @Description  계정별로 연속 실패 3회 이후에는 실패할 때마다 다음 시도까지 대기 시간(1초부터 두 배씩 최대 30초)이 생기고, 10회 실패하면 15분 동안 잠깁니다. 대기 또는 잠금 중에는 429와 `Retry-After` 헤더를 반환합니다.
 // @Tags         User
 // @Accept       json
 // @Produce      json
 // @Param        request body handler.LoginRequest true "로그인 요청 정보"
 // @Success      200 {object} handler.LoginSuccessResponse
+// @Success      202 {object} handler.MFAChallengeResponse "2단계 인증 필요"
 // @Failure      400 {object} handler.ErrorResponse "잘못된 요청"
 // @Failure      401 {object} handler.ErrorResponse "인증 실패 (자격 증명 오류)"
+// @Failure      429 {object} handler.ErrorResponse "로그인 실패로 인한 대기 또는 계정 잠금"
 // @Failure      500 {object} handler.ErrorResponse "서버 내부 오류"
 // @Router       /login [post]
 func Login(c *gin.Context) {
@@ -144,9 +164,16 @@ func Login(c *gin.Context) {
 		return
 	}
 
+	if !beginLoginAttempt(c, credentials.Username) {
+		return
+	}
+
 	user, err := storage.GetUserByUsername(credentials.Username)
 	if err != nil {
 		if err == sql.ErrNoRows {
+			// 존재하지 않는 사용자명도 비밀번호 오류와 응답 시간, 실패 기록이 같도록 처리
+			bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(credentials.Password))
+			recordLoginFailure(credentials.Username)
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 			return
 		}
@@ -156,45 +183,105 @@ func Login(c *gin.Context) {
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(credentials.Password)); err != nil {
+		recordLoginFailure(user.Username)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
 
-	tokenString, err := auth.GenerateToken(credentials.Username)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+	// 2단계 인증 사용자는 코드 확인(/login/mfa) 후 토큰 발급
+	// 비밀번호를 아는 상태에서 인증 코드를 반복 추측하지 못하도록 실패 횟수는 코드 확인 후 초기화
+	if user.MFAEnabled {
+		if err := storage.BlockLogin(user.Username, time.Now()); err != nil {
+			log.Printf("[ERROR] BlockLogin failed: %v", err)
+		}
+		challenge, err := auth.GenerateMFAChallenge(user.Username)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+			return
+		}
+		c.JSON(http.StatusAccepted, MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    challenge,
+			ExpiresIn:   int(auth.MFAChallengeTTL().Seconds()),
+		})
 		return
 	}
+	clearLoginFailures(user.Username)
+	issueSession(c, user, false)
+}
 
-	c.JSON(http.StatusOK, gin.H{"token": tokenString})
+// 존재하지 않는 사용자명의 비밀번호 비교용 해시 (실제 사용자와 같은 bcrypt 비용)
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// 로그인 시도 중 같은 사용자명의 다른 시도를 막는 최대 시간 (처리가 중단되면 이 시간 후 해제)
+const loginAttemptHold = 10 * time.Second
+
+// 계정별 로그인 제한 확인 및 시도 예약, 실패 후 대기 또는 잠금 중이면 429와 Retry-After 응답 후 false
+// 존재하지 않는 사용자명도 같은 기준으로 제한
+func beginLoginAttempt(c *gin.Context, username string) bool {
+	throttle, ok, err := storage.BeginLoginAttempt(username, loginAttemptHold)
+	if err != nil {
+		log.Printf("[ERROR] BeginLoginAttempt failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return false
+	}
+	if ok {
+		return true
+	}
+
+	retryAfter := int(math.Ceil(time.Until(throttle.BlockedUntil).Seconds()))
+	c.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+	if auth.LoginLocked(throttle.Failures) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Account temporarily locked due to failed login attempts"})
+	} else {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many login attempts, try again later"})
+	}
+	return false
 }
 
-// Profile godoc
-// @Summary      프로필 조회 (Profile)
-// @Description  인증된 사용자의 프로필 정보를 조회합니다. (JWT 필요)
-// @Tags         API (Protected)
-// @Produce      json
-// @Security     BearerAuth
-// @Success      200 {object} object{message=string, username=string}
-// @Failure      401 {object} handler.ErrorResponse "인증 토큰 누락 또는 만료"
-// @Router       /api/profile [get]
-func Profile(c *gin.Context) {
-	username, _ := c.Get("username")
-	c.JSON(http.StatusOK, gin.H{"message": "this is a protected profile", "username": username})
+// 로그인 실패 기록 후 실패 횟수에 따른 대기 시간 동안 다음 시도 제한
+func recordLoginFailure(username string) {
+	failures, err := storage.RecordLoginFailure(username, auth.LoginFailureWindow())
+	if err != nil {
+		log.Printf("[ERROR] RecordLoginFailure failed: %v", err)
+		return
+	}
+	if err := storage.BlockLogin(username, time.Now().Add(auth.LoginDelay(failures))); err != nil {
+		log.Printf("[ERROR] BlockLogin failed: %v", err)
+		return
+	}
+	if auth.LoginLocked(failures) {
+		log.Printf("Login(): Account %q locked after %d failed attempts", username, failures)
+	}
+}
+
+// 로그인 성공 시 실패 기록 초기화
+func clearLoginFailures(username string) {
+	if err := storage.ClearLoginFailures(username); err != nil {
+		log.Printf("[ERROR] ClearLoginFailures failed: %v", err)
+	}
 }
 
 // GetCallHistory godoc
 // @Summary      사용자 통화 기록 조회
 // @Description  사용자의 과거 시뮬레이션(통화/채팅) 기록 목록을 최신순으로 반환합니다.
+// @Description  각 기록은 모드(`text`/`voice`), 시나리오, 진행 시간(`duration_ms`), 결과를 포함하며, 대화 내용은 `/api/history/{id}/transcript`로 조회합니다.
 // @Tags         API (Protected)
 // @Produce      json
 // @Security     BearerAuth
+// @Param        mode query    string false "모드 필터 (text, voice)"
 // @Success      200 {object} handler.HistoryResponse "history: [기록 배열]"
+// @Failure      400 {object} handler.ErrorResponse "잘못된 모드"
 // @Failure      401 {object} handler.ErrorResponse "인증 실패"
 // @Failure      500 {object} handler.ErrorResponse "DB 조회 실패 등 서버 오류"
 // @Router       /api/history [get]
 func GetCallHistory(c *gin.Context) {
 	username := c.GetString("username")
+	mode := c.Query("mode")
+	if mode != "" && !models.IsValidMode(mode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mode"})
+		return
+	}
 
 	userID, err := storage.GetUserIDByUsername(username)
 	if err != nil {
@@ -202,7 +289,7 @@ func GetCallHistory(c *gin.Context) {
 		return
 	}
 
-	records, err := storage.GetRecordsByUserID(userID)
+	records, err := storage.GetRecordsByUserID(userID, nil, mode)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch records"})
 		return
@@ -212,13 +299,13 @@ func GetCallHistory(c *gin.Context) {
 
 // StreamAudio godoc
 // @Summary      녹음된 오디오 파일 스트리밍
-// @Description  특정 통화 기록의 오디오 파일(.mp3)을 재생합니다.
-// @Description  <br> **[인증]** Header에 `Authorization: Bearer ...`를 넣거나, URL 파라미터 `?token=...`을 사용하세요.
+// @Description  특정 통화 기록의 오디오 파일(.mp3)을 재생합니다. 기록이 남아 있는 본인 녹음 파일만 재생할 수 있습니다.
+// @Description  기록 ID로 조회하는 `GET /api/history/{id}/audio` 사용을 권장합니다.
+// @Description  <br> **[인증]** Header에 `Authorization: Bearer ...`를 넣으세요. `<audio>` 요소처럼 Header를 사용할 수 없으면 `POST /api/history/{id}/audio-url`로 발급받은 URL을 사용하세요.
 // @Tags         API (Protected)
 // @Produce      audio/mpeg
 // @Security     BearerAuth
 // @Param        filename path      string  true  "오디오 파일명 (예: session_uuid.mp3)"
-// @Param        token    query     string  false "JWT 토큰 (Header 사용 불가 시)"
 // @Success      200      {file}    file    "오디오 바이너리 데이터"
 // @Failure      401      {object}  handler.ErrorResponse "인증 실패"
 // @Failure      404      {object}  handler.ErrorResponse "해당 파일을 찾을 수 없음"
@@ -230,10 +317,16 @@ func StreamAudio(c *gin.Context) {
 	cleanFilename := filepath.Base(filename)
 	filePath := filepath.Join("data", "records", username, cleanFilename)
 
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	// 파일에 해당하는 기록이 있고 조회 권한이 있는 경우에만 재생 (GetRecordAudio와 같은 권한 확인)
+	record, err := storage.GetRecordByFilePath(filePath)
+	record, ok := authorizeRecord(c, record, err)
+	if !ok {
+		return
+	}
+	if _, err := os.Stat(record.FilePath); os.IsNotExist(err) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Audio file not found"})
 		return
 	}
 
-	c.File(filePath)
+	c.File(record.FilePath)
 }
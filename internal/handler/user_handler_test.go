@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStreamAudioRequiresRecord(t *testing.T) {
+	user := createTestUser(t, "stream_audio")
+	dir := filepath.Join("data", "records", user.Username)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	recorded := filepath.Join(dir, "session_recorded.mp3")
+	orphan := filepath.Join(dir, "session_orphan.mp3")
+	for _, path := range []string{recorded, orphan} {
+		if err := os.WriteFile(path, []byte("mp3"), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	if _, err := storage.CreateRecords(models.Record{UserID: user.ID, Scenario: "loan_scam", Mode: models.ModeVoice, FilePath: recorded}); err != nil {
+		t.Fatalf("CreateRecords: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/history/audio/:filename", func(c *gin.Context) {
+		c.Set("username", c.GetHeader("X-Test-User"))
+	}, StreamAudio)
+
+	tests := []struct {
+		name     string
+		username string
+		filename string
+		want     int
+	}{
+		{"own recording", user.Username, "session_recorded.mp3", http.StatusOK},
+		{"file without record", user.Username, "session_orphan.mp3", http.StatusNotFound},
+		{"missing file", user.Username, "session_missing.mp3", http.StatusNotFound},
+		{"path traversal", user.Username, "..%2F..%2Fdatabase.db", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/history/audio/"+tt.filename, nil)
+			req.Header.Set("X-Test-User", tt.username)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("GET %s = %d (%s), want %d", tt.filename, w.Code, w.Body, tt.want)
+			}
+		})
+	}
+}
@@ -2,17 +2,27 @@ package handler
 
 import (
 	"PishingSimulator_SecurityProject/internal/auth"
+	"PishingSimulator_SecurityProject/internal/llm"
 	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/session"
 	"PishingSimulator_SecurityProject/internal/storage"
 	"context"
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
+// 시뮬레이션에 사용할 대화 엔진, main()에서 설정
+var conversationEngine llm.ConversationEngine
+
+func SetConversationEngine(engine llm.ConversationEngine) {
+	conversationEngine = engine
+}
+
 // Upgrade HTTP connection to WebSocket
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
@@ -25,32 +35,39 @@ var upgrader = websocket.Upgrader{
 // @Description  지정된 시나리오와 모드로 실시간 시뮬레이션을 위한 WebSocket 연결을 시작합니다.
 // @Description  <br>
 // @Description  **[중요]** 이것은 표준 HTTP API가 아닙니다. `ws://` 또는 `wss://` 스킴을 사용해야 합니다.
-// @Description  **인증:** WebSocket 연결 시에는 HTTP Header를 사용할 수 없으므로, **Query Parameter(`token`)**로 JWT를 전달해야 합니다.
+// @Description  **인증:** WebSocket 연결 시에는 HTTP Header를 사용할 수 없으므로, `POST /api/ws-ticket`으로 발급받은 1회용 티켓을 **Query Parameter(`ticket`)**로 전달합니다. 티켓은 발급 시 지정한 시나리오에만 사용할 수 있습니다.
+// @Description  JWT를 `token` 쿼리로 전달하는 방식은 토큰이 프록시 로그 등에 남으므로 기본적으로 거부되며, 서버에 `WS_TOKEN_QUERY_COMPAT=true`를 설정한 경우에만 호환용으로 허용됩니다.
+// @Description  <br>
+// @Description  **프로토콜:** 모든 텍스트 프레임은 `{"v": 1, "type": "...", "ts": "...", "data": {...}}` 형식의 JSON 봉투입니다. (handler.WSEnvelope)
+// @Description  - 서버 → 클라이언트: `session.started`, `assistant.utterance`, `assistant.audio`, `user.transcript`, `stt.interim`, `coach.hint`, `error`, `session.ended`
+// @Description  - 클라이언트 → 서버: `user.message` (텍스트 모드, `data.text`), `session.end` (세션 종료)
+// @Description  - 음성 모드의 오디오는 바이너리 프레임으로 주고받으며, 서버 오디오는 형식(`container`, `encoding`, `sample_rate_hz`, `bytes`)을 담은 `assistant.audio` 메시지 직후에 전송됩니다.
+// @Description  - 코치 모드(`coach=true`, 텍스트 모드 전용)에서는 위험 신호가 있는 사기범 발화 직후 `coach.hint`(`hint_id`, `message`)가 전송되며, 표시된 힌트는 평가 리포트의 `hints_shown`에 기록됩니다.
+// @Description  - `session.ended`는 종료 사유(`reason`), 결과(`outcome`), 저장된 기록 ID(`record_id`)를 포함하며 이후 연결이 닫힙니다.
 // @Tags         Simulation (WebSocket)
 // @Accept       json
 // @Produce      json
-// @Param        token    query     string  true  "Bearer 토큰 (접두사 없이 토큰 값만 입력)"
-// @Param        scenario query     string  true  "시나리오 키 (예: loan_scam, institution_impersonation)"
+// @Param        ticket   query     string  false "1회용 연결 티켓 (POST /api/ws-ticket으로 발급, 권장)"
+// @Param        token    query     string  false "Bearer 토큰 (WS_TOKEN_QUERY_COMPAT=true인 서버에서만 사용 가능, 사용 중단 예정)"
+// @Param        scenario query     string  true  "시나리오 키 (GET /api/scenarios 목록의 key, 예: loan_scam)"
 // @Param        mode     query     string  true  "모드 선택 (text: 텍스트 채팅, voice: 실시간 음성 통화)"
+// @Param        coach    query     bool    false "코치 모드 (초보 훈련생용 실시간 힌트, 텍스트 모드 전용)"
 // @Success      101      {string}  string  "Switching Protocols"
 // @Failure      400      {object}  map[string]string "잘못된 파라미터"
 // @Failure      401      {object}  map[string]string "인증 실패"
+// @Failure      403      {object}  map[string]string "조직에서 허용되지 않은 시나리오 또는 선수 과정 미완료 (ENFORCE_PREREQUISITES=true인 경우)"
 // @Router       /ws/simulation [get]
 func HandleSimulationConnection(c *gin.Context) {
 
 	// URL Query 파라미터 추출
-	tokenString := c.Query("token")
 	scenarioKey := c.Query("scenario")
 	mode := c.Query("mode")
+	coachMode := c.Query("coach") == "true"
 
-	// 사용자 토큰 검증
-	claims, err := auth.ValidateToken(tokenString)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+	username, mfa, ok := authenticateSimulation(c, scenarioKey)
+	if !ok {
 		return
 	}
-
-	username := claims.Username
 	log.Printf("User %s connected with scenario key: %s", username, scenarioKey)
 
 	// 시나리오와 모드 검증
@@ -59,10 +76,18 @@ func HandleSimulationConnection(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scenario key"})
 		return
 	}
-	if mode != "text" && mode != "voice" {
+	if !models.IsValidMode(mode) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mode"})
 		return
 	}
+	if !scenario.SupportsMode(mode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Mode not supported by scenario"})
+		return
+	}
+	if coachMode && mode != models.ModeText {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Coach mode is only available in text mode"})
+		return
+	}
 
 	user, err := storage.GetUserByUsername(username)
 	if err != nil {
@@ -71,6 +96,38 @@ func HandleSimulationConnection(c *gin.Context) {
 		return
 	}
 
+	// 조직별 허용 시나리오 검사
+	if user.OrgID != nil {
+		org, err := storage.GetOrganizationByID(*user.OrgID)
+		if err != nil {
+			log.Printf("HandleSimulationConnection(): Failed to get organization: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+			return
+		}
+		if !org.AllowsScenario(scenario.Key) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Scenario is not available for your organization"})
+			return
+		}
+		if !mfa && org.RequiresMFA(user.Role) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "MFA required"})
+			return
+		}
+	}
+
+	// 선수 과정 검사 (이전 모듈을 완료하지 않은 시나리오는 시작 불가)
+	if enforcePrerequisites {
+		overview, err := loadProgress(user)
+		if err != nil {
+			log.Printf("HandleSimulationConnection(): Failed to load progress: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check prerequisites"})
+			return
+		}
+		if overview.StatusOf(scenario.Key) == models.ProgressLocked {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Scenario is locked until previous modules are completed"})
+			return
+		}
+	}
+
 	log.Printf("User: %s, %d, %s, Scenario: %s, Mode: %s", user.Profile.Name, user.Profile.Age, user.Profile.Gender, scenario.Name, mode)
 
 	// WebSocket 연결 업그레이드과 종료
@@ -84,22 +141,84 @@ func HandleSimulationConnection(c *gin.Context) {
 	defer conn.Close()
 	log.Printf("WebSocket connection established for user: %s", username)
 
-	// 초기 메시지 전송
-	initalMessage := fmt.Sprintf("Start Secnario %s: %s", scenario.Name, scenario.Description)
-	if err := conn.WriteMessage(websocket.TextMessage, []byte(initalMessage)); err != nil {
+	// 세션 시작 메시지 전송
+	sessionID := uuid.New().String()
+	started := SessionStartedData{
+		SessionID: sessionID,
+		Scenario:  newScenarioSummary(scenario),
+		Mode:      mode,
+		Coach:     coachMode,
+	}
+	if mode == models.ModeVoice {
+		started.InputAudio = &userAudioFormat
+		started.OutputAudio = &assistantAudioFormat
+	}
+	if err := writeEnvelope(conn, MsgSessionStarted, started); err != nil {
 		log.Printf("Error sending message to user %s: %v", username, err)
 		return
 	}
 
 	// 모드에 따른 세션 관리
 	switch mode {
-	case "text":
-		manageTextSession(conn, user, context.Background(), scenarioKey)
-	case "voice":
-		manageAudioSession(conn, user, context.Background(), scenarioKey)
+	case models.ModeText:
+		var coach *session.Coach
+		if coachMode {
+			coach = session.NewCoach(scenario.CoachHints())
+		}
+		manageTextSession(conn, user, conversationEngine, context.Background(), scenario, sessionID, coach)
+	case models.ModeVoice:
+		manageAudioSession(conn, user, conversationEngine, context.Background(), scenario, sessionID)
 	default:
 		// add error handling for unsupported mode
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		log.Printf("Unsupported mode for user %s: %s", username, mode)
 	}
 }
+
+// 티켓 없이 token 쿼리(JWT)로 WebSocket 연결 허용 여부 (기존 클라이언트 호환용, 기본값 거부), main()에서 설정
+var allowTokenQuery bool
+
+func SetTokenQueryCompatibility(enabled bool) {
+	allowTokenQuery = enabled
+}
+
+// WebSocket 연결 인증, ticket 쿼리(1회용 티켓)를 우선 사용하고 없으면 호환 설정 시에만 token 쿼리(JWT) 검증
+// 인증된 사용자명과 2단계 인증 여부 반환 (티켓은 RequireMFA를 통과한 요청에서만 발급됨), 실패 시 응답을 보내고 false
+func authenticateSimulation(c *gin.Context, scenarioKey string) (string, bool, bool) {
+	if ticket := c.Query("ticket"); ticket != "" {
+		userID, err := storage.ConsumeAccessTicket(ticket, models.TicketPurposeSimulation, scenarioKey)
+		if err != nil {
+			if errors.Is(err, storage.ErrAccessTicketInvalid) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired ticket"})
+			} else {
+				log.Printf("HandleSimulationConnection(): Failed to check ticket: %v", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check ticket"})
+			}
+			return "", false, false
+		}
+		user, err := storage.GetUserByID(userID)
+		if err != nil {
+			log.Printf("HandleSimulationConnection(): Failed to get ticket user: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user"})
+			return "", false, false
+		}
+		return user.Username, true, true
+	}
+
+	if !allowTokenQuery {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Ticket required"})
+		return "", false, false
+	}
+
+	// 사용자 토큰 검증 (서명, 만료, 로그아웃으로 폐기된 jti)
+	claims, err := auth.ValidateToken(c.Query("token"))
+	if err != nil {
+		if errors.Is(err, auth.ErrTokenRevoked) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			return "", false, false
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		return "", false, false
+	}
+	return claims.Username, claims.MFA, true
+}
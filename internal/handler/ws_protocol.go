@@ -0,0 +1,233 @@
+/**
+* Name: 			ws_protocol.go
+* Description: 		시뮬레이션 WebSocket 메시지 프로토콜 정의
+* Workflow: 		모든 텍스트 프레임은 JSON 봉투({v, type, ts, data}), 오디오 바이너리 프레임은 assistant.audio 제어 메시지 직후 전송
+ */
+
+package handler
+
+import (
+	"PishingSimulator_SecurityProject/internal/llm"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 메시지 봉투 프로토콜 버전, 호환되지 않는 변경 시 증가
+const WSProtocolVersion = 1
+
+// 서버 -> 클라이언트 메시지 종류
+const (
+	MsgSessionStarted     = "session.started"
+	MsgAssistantUtterance = "assistant.utterance"
+	MsgAssistantAudio     = "assistant.audio" // 바로 다음 바이너리 프레임의 오디오 형식 안내
+	MsgUserTranscript     = "user.transcript"
+	MsgSTTInterim         = "stt.interim"
+	MsgCoachHint          = "coach.hint" // 코치 모드에서 사기범 발화 직후 표시할 힌트
+	MsgError              = "error"
+	MsgSessionEnded       = "session.ended"
+)
+
+// 클라이언트 -> 서버 메시지 종류
+const (
+	MsgUserMessage = "user.message" // 텍스트 모드의 사용자 발화
+	MsgSessionEnd  = "session.end"  // 사용자가 세션 종료 (통화 끊기)
+)
+
+// session.ended 종료 사유
+const (
+	EndReasonCompleted    = "completed"    // 시나리오가 종료 단계에 도달
+	EndReasonClientEnded  = "client_ended" // 클라이언트가 session.end 전송
+	EndReasonDisconnected = "disconnected" // 클라이언트 연결 끊김
+	EndReasonError        = "error"        // 서버 오류로 세션 중단
+)
+
+// error 메시지 코드
+const (
+	ErrCodeInvalidMessage = "invalid_message"
+	ErrCodeInitFailed     = "init_failed"
+	ErrCodeLLMFailed      = "llm_failed"
+	ErrCodeSTTFailed      = "stt_failed"
+	ErrCodeTTSFailed      = "tts_failed"
+)
+
+// WebSocket 텍스트 프레임의 공통 봉투
+type WSEnvelope struct {
+	Version   int             `json:"v" example:"1"`
+	Type      string          `json:"type" example:"assistant.utterance"`
+	Timestamp time.Time       `json:"ts"`
+	Data      json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+}
+
+// 오디오 형식 메타데이터
+type AudioFormat struct {
+	Container    string `json:"container" example:"wav"`
+	Encoding     string `json:"encoding" example:"linear16"`
+	SampleRateHz int    `json:"sample_rate_hz" example:"16000"`
+	Channels     int    `json:"channels" example:"1"`
+}
+
+// 서버가 전송하는 TTS 오디오 형식
+var assistantAudioFormat = AudioFormat{
+	Container:    "wav",
+	Encoding:     "linear16",
+	SampleRateHz: llm.TTSSampleRateHertz,
+	Channels:     1,
+}
+
+// 클라이언트가 전송해야 하는 마이크 오디오 형식
+var userAudioFormat = AudioFormat{
+	Container:    "webm",
+	Encoding:     "opus",
+	SampleRateHz: llm.STTSampleRateHertz,
+	Channels:     1,
+}
+
+// session.started 데이터
+type SessionStartedData struct {
+	SessionID   string          `json:"session_id" example:"2f1c9a7e-..."`
+	Scenario    ScenarioSummary `json:"scenario"`
+	Mode        string          `json:"mode" example:"voice"`
+	InputAudio  *AudioFormat    `json:"input_audio,omitempty"`  // 음성 모드에서 클라이언트가 보낼 오디오 형식
+	OutputAudio *AudioFormat    `json:"output_audio,omitempty"` // 음성 모드에서 서버가 보낼 오디오 형식
+	Coach       bool            `json:"coach"`                  // 코치 모드 여부
+}
+
+// assistant.utterance 데이터
+type AssistantUtteranceData struct {
+	Text string `json:"text" example:"안녕하세요, 서울중앙지검 수사관입니다."`
+	Step string `json:"step,omitempty" example:"pressure"`
+}
+
+// assistant.audio 데이터, 바로 다음 바이너리 프레임이 Bytes 크기의 오디오
+type AssistantAudioData struct {
+	AudioFormat
+	Bytes int `json:"bytes" example:"48000"`
+}
+
+// user.transcript / stt.interim 데이터
+type TranscriptData struct {
+	Text string `json:"text" example:"네 맞는데요"`
+}
+
+// coach.hint 데이터
+type CoachHintData struct {
+	HintID  string `json:"hint_id" example:"otp_request"`
+	Message string `json:"message" example:"실제 기관은 전화로 인증번호(OTP)를 묻지 않습니다."`
+}
+
+// error 데이터, Fatal이면 곧이어 session.ended가 전송됨
+type ErrorData struct {
+	Code    string `json:"code" example:"llm_failed"`
+	Message string `json:"message" example:"Error processing your message."`
+	Fatal   bool   `json:"fatal"`
+}
+
+// session.ended 데이터
+type SessionEndedData struct {
+	Reason     string `json:"reason" example:"completed"`
+	Outcome    string `json:"outcome" example:"resisted"`
+	FinalState string `json:"final_state" example:"user_hung_up"`
+	RecordID   *int   `json:"record_id,omitempty" example:"12"` // 기록이 저장된 경우에만 포함
+}
+
+// user.message 데이터
+type UserMessageData struct {
+	Text string `json:"text" example:"누구세요?"`
+}
+
+// 음성 세션을 중단시킨 오류, Message는 클라이언트에 그대로 전달되는 설명
+type sessionError struct {
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *sessionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Code, e.Err)
+}
+
+func (e *sessionError) Unwrap() error {
+	return e.Err
+}
+
+// 클라이언트에 전송할 error 메시지 데이터
+func fatalErrorData(err error) ErrorData {
+	var sessionErr *sessionError
+	if errors.As(err, &sessionErr) {
+		return ErrorData{Code: sessionErr.Code, Message: sessionErr.Message, Fatal: true}
+	}
+	return ErrorData{Code: EndReasonError, Message: "Internal server error.", Fatal: true}
+}
+
+// 음성 세션에서 clientWritePump로 전달하는 송신 메시지
+// Audio가 있으면 assistant.audio 안내 후 바이너리 프레임으로 전송
+type outboundMessage struct {
+	Type  string
+	Data  any
+	Audio []byte
+}
+
+func audioMessage(audio []byte) outboundMessage {
+	return outboundMessage{Audio: audio}
+}
+
+func newEnvelope(msgType string, data any) ([]byte, error) {
+	envelope := WSEnvelope{Version: WSProtocolVersion, Type: msgType, Timestamp: time.Now()}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		envelope.Data = raw
+	}
+	return json.Marshal(envelope)
+}
+
+// 봉투 메시지를 텍스트 프레임으로 전송
+func writeEnvelope(conn *websocket.Conn, msgType string, data any) error {
+	payload, err := newEnvelope(msgType, data)
+	if err != nil {
+		return fmt.Errorf("writeEnvelope(): failed to encode %s: %v", msgType, err)
+	}
+	return conn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// 송신 메시지 전송, 오디오는 형식 안내 메시지를 먼저 보냄
+func writeOutbound(conn *websocket.Conn, msg outboundMessage) error {
+	if msg.Audio == nil {
+		return writeEnvelope(conn, msg.Type, msg.Data)
+	}
+	if err := writeEnvelope(conn, MsgAssistantAudio, AssistantAudioData{AudioFormat: assistantAudioFormat, Bytes: len(msg.Audio)}); err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.BinaryMessage, msg.Audio)
+}
+
+// 클라이언트 텍스트 프레임 해석, 버전이 생략된 경우 현재 버전으로 간주
+func parseClientMessage(message []byte) (WSEnvelope, error) {
+	var envelope WSEnvelope
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return envelope, fmt.Errorf("message is not a JSON envelope")
+	}
+	if envelope.Version != 0 && envelope.Version != WSProtocolVersion {
+		return envelope, fmt.Errorf("unsupported protocol version %d (expected %d)", envelope.Version, WSProtocolVersion)
+	}
+	if envelope.Type == "" {
+		return envelope, fmt.Errorf("message type is required")
+	}
+	return envelope, nil
+}
+
+// session.ended 전송 후 정상 종료 프레임 전송, 이미 끊긴 연결이면 오류만 기록
+func endSession(conn *websocket.Conn, data SessionEndedData) {
+	if err := writeEnvelope(conn, MsgSessionEnded, data); err != nil {
+		log.Printf("endSession(): Failed to send session.ended: %v", err)
+		return
+	}
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, data.Reason))
+}
@@ -1,3 +1,9 @@
+/**
+* Name: 			client.go
+* Description: 		Python LLM 서버(HTTP) 대화 엔진
+* Workflow: 		세션 초기화, 대화, 세션 정리 요청
+ */
+
 package llm
 
 import (
@@ -8,17 +14,37 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 )
 
-const llmBaseURL = "http://localhost:8001" // LLM 서버의 기본 URL
+const defaultLLMBaseURL = "http://localhost:8001" // LLM 서버의 기본 URL
 var httpClient = &http.Client{Timeout: 10 * time.Second}
 
+// Python LLM 서버를 사용하는 대화 엔진
+type HTTPEngine struct {
+	baseURL string
+}
+
+// baseURL이 비어 있으면 기본 URL(localhost:8001) 사용
+func NewHTTPEngine(baseURL string) *HTTPEngine {
+	if baseURL == "" {
+		baseURL = defaultLLMBaseURL
+	}
+	return &HTTPEngine{baseURL: strings.TrimRight(baseURL, "/")}
+}
+
 type InitRequest struct {
-	SessionID   string             `json:"session_id"`
-	Scenario    string             `json:"scenario"`
-	UserInfo    models.UserProfile `json:"user_info"`
-	Temperature float64            `json:"temperature"`
+	SessionID       string             `json:"session_id"`
+	Scenario        string             `json:"scenario"`
+	UserInfo        models.UserProfile `json:"user_info"` // 사용자가 공유를 허용한 항목만 (나머지는 빈 값 또는 생략)
+	Temperature     float64            `json:"temperature"`
+	ScenarioName    string             `json:"scenario_name,omitempty"`
+	Persona         string             `json:"persona,omitempty"`
+	OpeningLine     string             `json:"opening_line,omitempty"`
+	Goals           []string           `json:"goals,omitempty"`
+	ForbiddenTopics []string           `json:"forbidden_topics,omitempty"`
+	Voice           string             `json:"voice,omitempty"`
 }
 
 type InitResponse struct {
@@ -39,18 +65,24 @@ type ControlRequest struct {
 	ClearSession bool `json:"clear_session"`
 }
 
-func InitSession(sessionID, scenarioKey string, userInfo models.UserProfile, ctx context.Context) (string, error) {
+func (e *HTTPEngine) InitSession(sessionID string, scenario models.Scenario, userInfo models.UserProfile, ctx context.Context) (string, error) {
 	reqBody, err := json.Marshal(InitRequest{
-		SessionID:   sessionID,
-		Scenario:    scenarioKey,
-		UserInfo:    userInfo,
-		Temperature: 0.7,
+		SessionID:       sessionID,
+		Scenario:        scenario.Key,
+		UserInfo:        userInfo,
+		Temperature:     scenario.Temperature,
+		ScenarioName:    scenario.Name,
+		Persona:         scenario.Persona,
+		OpeningLine:     scenario.OpeningLine,
+		Goals:           scenario.Goals,
+		ForbiddenTopics: scenario.ForbiddenTopics,
+		Voice:           scenario.Voice,
 	})
 	if err != nil {
 		return "", err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", llmBaseURL+"/session/init", bytes.NewBuffer(reqBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", e.baseURL+"/session/init", bytes.NewBuffer(reqBody))
 	if err != nil {
 		return "", err
 	}
@@ -73,7 +105,7 @@ func InitSession(sessionID, scenarioKey string, userInfo models.UserProfile, ctx
 	return initResp.Utterance, nil
 }
 
-func Chat(sessionID, text string, ctx context.Context) (*ChatResponse, error) {
+func (e *HTTPEngine) Chat(sessionID, text string, ctx context.Context) (*ChatResponse, error) {
 	reqBody, err := json.Marshal(ChatRequest{
 		SessionID: sessionID,
 		UserText:  text,
@@ -82,7 +114,7 @@ func Chat(sessionID, text string, ctx context.Context) (*ChatResponse, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", llmBaseURL+"/chat", bytes.NewBuffer(reqBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", e.baseURL+"/chat", bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +137,7 @@ func Chat(sessionID, text string, ctx context.Context) (*ChatResponse, error) {
 	return &chatResp, nil
 }
 
-func ClearSession(sessionID string) error {
+func (e *HTTPEngine) ClearSession(sessionID string) error {
 	reqBody, err := json.Marshal(map[string]interface{}{
 		"session_id":    sessionID,
 		"clear_session": true,
@@ -113,7 +145,7 @@ func ClearSession(sessionID string) error {
 	if err != nil {
 		return err
 	}
-	resp, err := httpClient.Post(llmBaseURL+"/session/control", "application/json", bytes.NewBuffer(reqBody))
+	resp, err := httpClient.Post(e.baseURL+"/session/control", "application/json", bytes.NewBuffer(reqBody))
 	if err != nil {
 		return err
 	}
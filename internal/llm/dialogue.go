@@ -0,0 +1,374 @@
+/**
+* Name: 			dialogue.go
+* Description: 		LLM 서버 없이 동작하는 규칙 기반 사기범 대화 엔진
+* Workflow: 		시나리오 대화 트리 로드, 키워드 분기, 폴백 발화, 종료 노드 도달 시 세션 종료
+ */
+
+package llm
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// 대화 단계 (ChatResponse.NextStep 값), 대화 트리 노드의 step과 같은 값
+const (
+	NextStepOpening           = models.DialogueStepOpening
+	NextStepPressure          = models.DialogueStepPressure
+	NextStepCredentialRequest = models.DialogueStepCredentialRequest
+	NextStepPaymentRequest    = models.DialogueStepPaymentRequest
+	NextStepClosing           = models.DialogueStepClosing
+	NextStepUserHungUp        = models.DialogueStepUserHungUp
+	NextStepScamSucceeded     = models.DialogueStepScamSucceeded
+)
+
+// 분기 키워드 중 특수 토큰, 사용자 발화에 4자리 이상의 숫자가 포함되면 일치
+const digitsKeyword = "{digits}"
+
+const defaultMaxFallbacks = 2
+
+var digitsPattern = regexp.MustCompile(`[0-9]{4,}`)
+
+type dialogueSession struct {
+	script    *models.DialogueScript
+	node      string
+	userName  string
+	visits    map[string]int
+	fallbacks int
+}
+
+// 시나리오별 대화 트리를 따라 응답하는 오프라인 대화 엔진
+type DialogueEngine struct {
+	mu       sync.Mutex
+	sessions map[string]*dialogueSession
+}
+
+func NewDialogueEngine() *DialogueEngine {
+	return &DialogueEngine{sessions: make(map[string]*dialogueSession)}
+}
+
+// 시나리오에 대화 트리가 없으면 기본 트리 사용, 첫 발화는 시나리오의 opening_line 우선
+func (e *DialogueEngine) InitSession(sessionID string, scenario models.Scenario, userInfo models.UserProfile, ctx context.Context) (string, error) {
+	script := scenario.Script
+	if script == nil {
+		script = &defaultDialogueScript
+	}
+	if err := script.Validate(); err != nil {
+		return "", fmt.Errorf("DialogueEngine.InitSession(): invalid script for %s: %v", scenario.Key, err)
+	}
+
+	session := &dialogueSession{
+		script:   script,
+		node:     script.Start,
+		userName: userInfo.Name,
+		visits:   map[string]int{script.Start: 1},
+	}
+	e.mu.Lock()
+	e.sessions[sessionID] = session
+	e.mu.Unlock()
+
+	if scenario.OpeningLine != "" {
+		return scenario.OpeningLine, nil
+	}
+	return session.line(script.Nodes[script.Start].Lines, 0), nil
+}
+
+func (e *DialogueEngine) Chat(sessionID, text string, ctx context.Context) (*ChatResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	session, exists := e.sessions[sessionID]
+	if !exists {
+		return nil, fmt.Errorf("DialogueEngine.Chat(): unknown session %s", sessionID)
+	}
+
+	current := session.script.Nodes[session.node]
+	// 종료 노드 이후의 발화에는 마지막 대사를 반복
+	if current.End {
+		return &ChatResponse{Utterance: session.line(current.Lines, session.visits[session.node]-1), NextStep: session.step()}, nil
+	}
+
+	if next, matched := matchBranch(current.Branches, text); matched {
+		return session.enter(next), nil
+	}
+
+	// 일치하는 분기가 없으면 폴백 발화, 일정 횟수 반복되면 다음 노드로 진행
+	session.fallbacks++
+	maxFallbacks := current.MaxFallbacks
+	if maxFallbacks <= 0 {
+		maxFallbacks = defaultMaxFallbacks
+	}
+	if current.Next != "" && (session.fallbacks > maxFallbacks || len(current.Fallback) == 0) {
+		return session.enter(current.Next), nil
+	}
+	lines := current.Fallback
+	if len(lines) == 0 {
+		lines = current.Lines
+	}
+	return &ChatResponse{Utterance: session.line(lines, session.fallbacks-1), NextStep: session.step()}, nil
+}
+
+func (e *DialogueEngine) ClearSession(sessionID string) error {
+	e.mu.Lock()
+	delete(e.sessions, sessionID)
+	e.mu.Unlock()
+	return nil
+}
+
+// 노드로 이동하고 해당 노드의 발화 반환
+func (s *dialogueSession) enter(nodeID string) *ChatResponse {
+	s.node = nodeID
+	s.fallbacks = 0
+	s.visits[nodeID]++
+	node := s.script.Nodes[nodeID]
+	return &ChatResponse{Utterance: s.line(node.Lines, s.visits[nodeID]-1), NextStep: s.step()}
+}
+
+// 현재 노드의 단계 (Validate에서 허용 값임을 확인함)
+func (s *dialogueSession) step() string {
+	return s.script.Nodes[s.node].Step
+}
+
+// 방문 횟수에 따라 대사를 순환 선택하고 {name}을 사용자 이름으로 치환
+func (s *dialogueSession) line(lines []string, index int) string {
+	line := lines[index%len(lines)]
+	name := s.userName
+	if name == "" {
+		name = "고객"
+	}
+	return strings.ReplaceAll(line, "{name}", name)
+}
+
+// 분기 키워드를 순서대로 검사, 부정 표현이 붙은 키워드는 일치하지 않은 것으로 처리
+// 일반 키워드는 공백을 제거하고 소문자로 변환한 발화에 포함되는지, "="로 시작하는 키워드는 발화의 단어와 정확히 같은지 비교
+func matchBranch(branches []models.DialogueBranch, text string) (string, bool) {
+	u := newUtterance(text)
+	for _, branch := range branches {
+		for _, keyword := range branch.Keywords {
+			if keyword == digitsKeyword {
+				if digitsPattern.MatchString(strings.NewReplacer("-", "", ".", "").Replace(u.normalized)) {
+					return branch.Next, true
+				}
+				continue
+			}
+			if word, whole := strings.CutPrefix(keyword, wholeWordPrefix); whole {
+				if u.containsWord(strings.ToLower(strings.TrimSpace(word))) {
+					return branch.Next, true
+				}
+				continue
+			}
+			if u.contains(normalizeUtterance(keyword)) {
+				return branch.Next, true
+			}
+		}
+	}
+	return "", false
+}
+
+func normalizeUtterance(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), ""))
+}
+
+// 분기 키워드 접두사, "=네"는 "네"라는 단어에만 일치 ("모르겠네요", "네이버"에는 불일치)
+const wholeWordPrefix = "="
+
+// 키워드 바로 앞이나 뒤의 단어가 negationWords이거나, 키워드 뒤에 negationSuffixes가 이어지면 부정으로 판단
+// 예: "안 보냈어요", "이체 완료 안 했어요", "맞지 않아요"
+var (
+	negationWords    = []string{"안", "못"}
+	negationSuffixes = []string{"않", "지않"}
+)
+
+// 단어 경계와 부정 표현 검사를 위해 단어 단위로 나눈 사용자 발화
+type utterance struct {
+	words      []string // 소문자, 앞뒤 문장 부호 제거
+	starts     []int    // normalized에서 각 단어의 시작 위치
+	normalized string   // 단어를 공백 없이 이어 붙인 발화
+}
+
+func newUtterance(text string) utterance {
+	var u utterance
+	var b strings.Builder
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		word := strings.TrimFunc(field, func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) })
+		if word == "" {
+			continue
+		}
+		u.words = append(u.words, word)
+		u.starts = append(u.starts, b.Len())
+		b.WriteString(word)
+	}
+	u.normalized = b.String()
+	return u
+}
+
+// 부정되지 않은 위치에 키워드가 포함되는지 검사 (키워드는 여러 단어에 걸칠 수 있음)
+func (u utterance) contains(keyword string) bool {
+	if keyword == "" {
+		return false
+	}
+	for offset := 0; offset < len(u.normalized); {
+		index := strings.Index(u.normalized[offset:], keyword)
+		if index < 0 {
+			return false
+		}
+		start := offset + index
+		end := start + len(keyword)
+		if !u.negated(u.wordAt(start), u.wordAt(end-1), u.normalized[end:]) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+// 부정되지 않은 단어 중 keyword와 같은 단어가 있는지 검사
+func (u utterance) containsWord(word string) bool {
+	if word == "" {
+		return false
+	}
+	for i, w := range u.words {
+		if w == word && !u.negated(i, i, u.normalized[u.starts[i]+len(w):]) {
+			return true
+		}
+	}
+	return false
+}
+
+// first~last 단어에 걸친 키워드 일치가 부정 표현과 함께 쓰였는지 검사, rest는 일치한 부분 이후의 발화
+func (u utterance) negated(first, last int, rest string) bool {
+	if first > 0 && slices.Contains(negationWords, u.words[first-1]) {
+		return true
+	}
+	if last+1 < len(u.words) && slices.Contains(negationWords, u.words[last+1]) {
+		return true
+	}
+	for _, suffix := range negationSuffixes {
+		if strings.HasPrefix(rest, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalized의 위치가 속한 단어의 인덱스
+func (u utterance) wordAt(offset int) int {
+	i, found := slices.BinarySearch(u.starts, offset)
+	if !found {
+		i--
+	}
+	return i
+}
+
+// 범용 대화 트리의 분기 키워드 (공백 제거 후 비교, "="로 시작하면 단어 단위 비교)
+var (
+	hangUpKeywords      = []string{"끊", "사기", "신고", "경찰", "보이스피싱", "안받", "그만"}
+	verifyKeywords      = []string{"다시전화", "대표번호", "확인해보", "어디시", "누구세요", "소속", "직접가", "방문"}
+	refuseKeywords      = []string{"못알려", "안알려", "알려줄수없", "싫", "안돼", "왜알려", "못해", "안보냈", "못보냈", "안했"}
+	complyKeywords      = []string{"=네", "=네네", "=예", "알겠", "맞아", "맞습", "그런데요", "무슨일", "왜요", "어떻게"}
+	transferredKeywords = []string{"이체했", "보냈", "송금했", "입금했", "완료했", "이체완료"}
+)
+
+// 시나리오에 대화 트리가 없을 때 사용하는 범용 대화 트리
+var defaultDialogueScript = models.DialogueScript{
+	Start: "opening",
+	Nodes: map[string]models.DialogueNode{
+		"opening": {
+			Step:  NextStepOpening,
+			Lines: []string{"안녕하세요, {name}님 되시죠? 중요한 일로 급하게 연락드렸습니다."},
+			Branches: []models.DialogueBranch{
+				{Keywords: hangUpKeywords, Next: "hung_up"},
+				{Keywords: verifyKeywords, Next: "verify_pushback"},
+				{Keywords: complyKeywords, Next: "pressure"},
+			},
+			Fallback: []string{"{name}님 본인 맞으시죠? 잠시만 통화 가능하실까요?"},
+			Next:     "pressure",
+		},
+		"pressure": {
+			Step: NextStepPressure,
+			Lines: []string{
+				"지금 바로 처리하지 않으시면 본인 명의로 큰 불이익이 생길 수 있습니다. 본인 확인부터 진행하겠습니다.",
+				"시간이 많지 않습니다. 오늘 안에 처리하지 않으면 되돌릴 수 없어요.",
+			},
+			Branches: []models.DialogueBranch{
+				{Keywords: hangUpKeywords, Next: "hung_up"},
+				{Keywords: verifyKeywords, Next: "verify_pushback"},
+				{Keywords: complyKeywords, Next: "credential_request"},
+			},
+			Fallback: []string{"제 말 잘 들으셔야 합니다. 지금 상황이 매우 급합니다."},
+			Next:     "credential_request",
+		},
+		"verify_pushback": {
+			Step: NextStepPressure,
+			Lines: []string{
+				"대표번호로 다시 거시면 담당자 배정이 취소돼서 처리가 늦어집니다. 지금 이 통화에서 바로 진행하셔야 해요.",
+			},
+			Branches: []models.DialogueBranch{
+				{Keywords: hangUpKeywords, Next: "hung_up"},
+				{Keywords: verifyKeywords, Next: "closing"},
+				{Keywords: complyKeywords, Next: "credential_request"},
+			},
+			Fallback: []string{"의심되시는 건 이해합니다만, 지금 끊으시면 저희도 도와드릴 수가 없어요."},
+			Next:     "credential_request",
+		},
+		"credential_request": {
+			Step: NextStepCredentialRequest,
+			Lines: []string{
+				"본인 확인을 위해 주민등록번호 앞자리와 방금 문자로 받으신 인증번호를 불러 주세요.",
+				"인증번호 여섯 자리만 불러 주시면 바로 확인해 드리겠습니다.",
+			},
+			Branches: []models.DialogueBranch{
+				{Keywords: hangUpKeywords, Next: "hung_up"},
+				{Keywords: []string{digitsKeyword}, Next: "payment_request"},
+				{Keywords: refuseKeywords, Next: "pressure"},
+				{Keywords: verifyKeywords, Next: "verify_pushback"},
+			},
+			Fallback: []string{"번호만 불러 주시면 됩니다. 천천히 말씀해 주세요."},
+			Next:     "payment_request",
+		},
+		"payment_request": {
+			Step:  NextStepPaymentRequest,
+			Lines: []string{"확인되었습니다. 자산 보호를 위해 지금 안내해 드리는 안전계좌로 잔액을 옮겨 두셔야 합니다."},
+			Branches: []models.DialogueBranch{
+				{Keywords: hangUpKeywords, Next: "hung_up"},
+				{Keywords: refuseKeywords, Next: "closing"},
+				{Keywords: transferredKeywords, Next: "scam_succeeded"},
+				{Keywords: complyKeywords, Next: "payment_detail"},
+			},
+			Fallback: []string{"이체만 하시면 모든 절차가 끝납니다. 어렵지 않아요."},
+			Next:     "closing",
+		},
+		"payment_detail": {
+			Step:  NextStepPaymentRequest,
+			Lines: []string{"OO은행 110-234-567890, 예금주 김안전으로 보내 주시고 완료되면 말씀해 주세요."},
+			Branches: []models.DialogueBranch{
+				{Keywords: hangUpKeywords, Next: "hung_up"},
+				{Keywords: refuseKeywords, Next: "closing"},
+				{Keywords: transferredKeywords, Next: "scam_succeeded"},
+			},
+			Fallback: []string{"이체 완료되셨나요? 완료되면 말씀해 주세요."},
+			Next:     "closing",
+		},
+		"closing": {
+			Step:  NextStepClosing,
+			Lines: []string{"알겠습니다. 그럼 나중에 다시 연락드리겠습니다."},
+			End:   true,
+		},
+		"hung_up": {
+			Step:  NextStepUserHungUp,
+			Lines: []string{"아니, 잠깐만요 {name}님! 여보세요?"},
+			End:   true,
+		},
+		"scam_succeeded": {
+			Step:  NextStepScamSucceeded,
+			Lines: []string{"네, 확인되었습니다. 처리 완료되었으니 이 통화 내용은 절대 다른 사람에게 말씀하지 마세요."},
+			End:   true,
+		},
+	},
+}
@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"context"
+	"testing"
+)
+
+func TestMatchBranch(t *testing.T) {
+	branches := []models.DialogueBranch{
+		{Keywords: hangUpKeywords, Next: "hung_up"},
+		{Keywords: refuseKeywords, Next: "refuse"},
+		{Keywords: transferredKeywords, Next: "transferred"},
+		{Keywords: complyKeywords, Next: "comply"},
+		{Keywords: []string{digitsKeyword}, Next: "digits"},
+	}
+	tests := []struct {
+		text string
+		want string // 빈 문자열이면 일치하는 분기 없음
+	}{
+		{"네", "comply"},
+		{"네, 말씀하세요.", "comply"},
+		{"네네 맞아요", "comply"},
+		{"예 그런데요?", "comply"},
+		{"네 안녕하세요", "comply"},
+		{"모르겠네요", ""},
+		{"네이버에서 봤어요", ""},
+		{"예금 얘기인가요", ""},
+		{"맞지 않아요", ""},
+		{"이체했어요", "transferred"},
+		{"방금 보냈습니다", "transferred"},
+		{"아직 안 보냈어요", "refuse"},
+		{"못 보냈는데요", "refuse"},
+		{"이체 완료 안 했어요", "refuse"},
+		{"송금 안했어요", "refuse"},
+		{"그건 안 돼요", "refuse"},
+		{"안 끊을게요 말씀하세요", ""},
+		{"전화 끊을게요", "hung_up"},
+		{"신고할 거예요", "hung_up"},
+		{"1234-5678", "digits"},
+		{"음...", ""},
+	}
+	for _, tt := range tests {
+		got, matched := matchBranch(branches, tt.text)
+		if !matched {
+			got = ""
+		}
+		if got != tt.want {
+			t.Errorf("matchBranch(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDialogueEngineDefaultScript(t *testing.T) {
+	tests := []struct {
+		name      string
+		utterance []string
+		want      string // 마지막 발화의 NextStep
+	}{
+		{"comply then transfer", []string{"네", "네", "123456", "네", "이체했어요"}, NextStepScamSucceeded},
+		{"refuses to transfer", []string{"네", "네", "123456", "아직 안 보냈어요"}, NextStepClosing},
+		{"negated transfer", []string{"네", "네", "123456", "네", "이체 완료 안 했어요"}, NextStepClosing},
+		{"hangs up", []string{"사기 아니에요? 끊을게요"}, NextStepUserHungUp},
+		{"not a yes", []string{"모르겠네요"}, NextStepOpening},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewDialogueEngine()
+			if _, err := engine.InitSession("s1", models.Scenario{Key: "test"}, models.UserProfile{Name: "홍길동"}, context.Background()); err != nil {
+				t.Fatalf("InitSession: %v", err)
+			}
+			var resp *ChatResponse
+			for _, text := range tt.utterance {
+				var err error
+				if resp, err = engine.Chat("s1", text, context.Background()); err != nil {
+					t.Fatalf("Chat(%q): %v", text, err)
+				}
+			}
+			if resp.NextStep != tt.want {
+				t.Errorf("NextStep = %s, want %s", resp.NextStep, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultDialogueScriptIsValid(t *testing.T) {
+	if err := defaultDialogueScript.Validate(); err != nil {
+		t.Fatalf("defaultDialogueScript.Validate() = %v", err)
+	}
+}
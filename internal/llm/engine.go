@@ -0,0 +1,53 @@
+/**
+* Name: 			engine.go
+* Description: 		대화 엔진 인터페이스 및 설정 기반 엔진 선택
+* Workflow: 		LLM_ENGINE 환경 변수에 따라 HTTP / OpenAI 호환 / 대화 트리 / 스크립트 엔진 생성
+ */
+
+package llm
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"context"
+	"fmt"
+	"os"
+)
+
+// 사기범 역할을 수행하는 대화 엔진, 세션 단위로 대화 상태를 관리함
+type ConversationEngine interface {
+	// 세션을 초기화하고 사기범의 첫 발화를 반환
+	InitSession(sessionID string, scenario models.Scenario, userInfo models.UserProfile, ctx context.Context) (string, error)
+	// 사용자 발화에 대한 사기범의 응답을 반환
+	Chat(sessionID, text string, ctx context.Context) (*ChatResponse, error)
+	// 세션 종료 시 대화 상태 정리
+	ClearSession(sessionID string) error
+}
+
+// 엔진 종류 (LLM_ENGINE 환경 변수 값)
+const (
+	EngineHTTP     = "http"
+	EngineOpenAI   = "openai"
+	EngineDialogue = "dialogue"
+	EngineScripted = "scripted"
+)
+
+// 환경 변수 설정에 따라 대화 엔진 생성
+//
+//	LLM_ENGINE=http      (기본값) LLM_BASE_URL의 Python LLM 서버 사용
+//	LLM_ENGINE=openai    OPENAI_BASE_URL, OPENAI_API_KEY, OPENAI_MODEL의 Chat Completions API 사용
+//	LLM_ENGINE=dialogue  외부 서비스 없이 시나리오별 대화 트리(키워드 분기)로 응답 (로컬 개발, CI용)
+//	LLM_ENGINE=scripted  외부 서비스 없이 고정된 대사를 순서대로 반환 (테스트용)
+func NewEngineFromEnv() (ConversationEngine, error) {
+	switch engine := os.Getenv("LLM_ENGINE"); engine {
+	case "", EngineHTTP:
+		return NewHTTPEngine(os.Getenv("LLM_BASE_URL")), nil
+	case EngineOpenAI:
+		return NewOpenAIEngine(os.Getenv("OPENAI_BASE_URL"), os.Getenv("OPENAI_API_KEY"), os.Getenv("OPENAI_MODEL"))
+	case EngineDialogue:
+		return NewDialogueEngine(), nil
+	case EngineScripted:
+		return NewScriptedEngine(nil), nil
+	default:
+		return nil, fmt.Errorf("NewEngineFromEnv(): unknown LLM_ENGINE %q", engine)
+	}
+}
@@ -0,0 +1,201 @@
+package llm
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestScriptedEngineReturnsLinesInOrder(t *testing.T) {
+	engine := NewScriptedEngine([]string{"첫 번째", "두 번째", "마지막"})
+	scenario := models.Scenario{Key: "loan_scam", Name: "Loan Scam"}
+
+	opening, err := engine.InitSession("s1", scenario, models.UserProfile{Name: "홍길동"}, context.Background())
+	if err != nil {
+		t.Fatalf("InitSession: %v", err)
+	}
+	if !strings.Contains(opening, "홍길동") || !strings.Contains(opening, "Loan Scam") {
+		t.Errorf("opening = %q, want name and scenario", opening)
+	}
+
+	tests := []struct {
+		utterance string
+		nextStep  string
+	}{
+		{"첫 번째", NextStepPressure},
+		{"두 번째", NextStepPressure},
+		{"마지막", NextStepClosing},
+		{"마지막", NextStepClosing}, // 대사가 끝나면 마지막 대사 반복
+	}
+	for i, tt := range tests {
+		resp, err := engine.Chat("s1", "네", context.Background())
+		if err != nil {
+			t.Fatalf("turn %d: Chat: %v", i, err)
+		}
+		if resp.Utterance != tt.utterance || resp.NextStep != tt.nextStep {
+			t.Errorf("turn %d: got (%q, %q), want (%q, %q)", i, resp.Utterance, resp.NextStep, tt.utterance, tt.nextStep)
+		}
+	}
+}
+
+func TestScriptedEngineOpeningLineAndUnknownSession(t *testing.T) {
+	engine := NewScriptedEngine(nil)
+	opening, err := engine.InitSession("s1", models.Scenario{OpeningLine: "OO은행입니다."}, models.UserProfile{}, context.Background())
+	if err != nil || opening != "OO은행입니다." {
+		t.Fatalf("InitSession = (%q, %v), want scenario opening line", opening, err)
+	}
+
+	engine.ClearSession("s1")
+	if _, err := engine.Chat("s1", "여보세요", context.Background()); err == nil {
+		t.Error("Chat after ClearSession: want error")
+	}
+}
+
+// Chat Completions 요청의 마지막 사용자 발화를 그대로 돌려주는 테스트 서버
+func newEchoCompletionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request chatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		last := request.Messages[len(request.Messages)-1]
+		content, _ := json.Marshal(ChatResponse{Utterance: "echo:" + last.Content, NextStep: NextStepPressure})
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": chatMessage{Role: "assistant", Content: string(content)}}},
+		})
+	}))
+}
+
+func TestOpenAIEngineConcurrentChatKeepsHistory(t *testing.T) {
+	server := newEchoCompletionServer(t)
+	defer server.Close()
+
+	engine, err := NewOpenAIEngine(server.URL, "", "test-model")
+	if err != nil {
+		t.Fatalf("NewOpenAIEngine: %v", err)
+	}
+	scenario := models.Scenario{Name: "Loan Scam", OpeningLine: "안녕하세요, 고객님.", Temperature: 0}
+	if _, err := engine.InitSession("s1", scenario, models.UserProfile{}, context.Background()); err != nil {
+		t.Fatalf("InitSession: %v", err)
+	}
+
+	const turns = 20
+	var wg sync.WaitGroup
+	for i := range turns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			text := fmt.Sprintf("발화 %d", i)
+			resp, err := engine.Chat("s1", text, context.Background())
+			if err != nil {
+				t.Errorf("Chat(%q): %v", text, err)
+				return
+			}
+			if resp.Utterance != "echo:"+text {
+				t.Errorf("Chat(%q) = %q", text, resp.Utterance)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// system, 첫 발화 이후 모든 턴이 사용자, 사기범 발화 쌍으로 남아 있어야 함
+	messages := engine.sessions["s1"].messages
+	if len(messages) != 2+2*turns {
+		t.Fatalf("history has %d messages, want %d", len(messages), 2+2*turns)
+	}
+	seen := make(map[string]bool)
+	for i := 2; i < len(messages); i += 2 {
+		user, assistant := messages[i], messages[i+1]
+		if user.Role != "user" || assistant.Role != "assistant" || assistant.Content != "echo:"+user.Content {
+			t.Fatalf("messages[%d:%d] = %+v, %+v, want matching user/assistant pair", i, i+2, user, assistant)
+		}
+		seen[user.Content] = true
+	}
+	if len(seen) != turns {
+		t.Errorf("history has %d distinct user turns, want %d", len(seen), turns)
+	}
+}
+
+func TestOpenAIEngineFailedChatLeavesHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	engine, err := NewOpenAIEngine(server.URL, "", "")
+	if err != nil {
+		t.Fatalf("NewOpenAIEngine: %v", err)
+	}
+	if _, err := engine.InitSession("s1", models.Scenario{OpeningLine: "안녕하세요."}, models.UserProfile{}, context.Background()); err != nil {
+		t.Fatalf("InitSession: %v", err)
+	}
+	if _, err := engine.Chat("s1", "누구세요?", context.Background()); err == nil {
+		t.Fatal("Chat: want error from failing server")
+	}
+	if got := len(engine.sessions["s1"].messages); got != 2 {
+		t.Errorf("history has %d messages after failed turn, want 2", got)
+	}
+}
+
+func TestOpenAIEngineNormalizesNextStep(t *testing.T) {
+	var systemPrompt string
+	// 사용자 발화를 next_step으로 그대로 돌려주는 테스트 서버
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request chatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		systemPrompt = request.Messages[0].Content
+		last := request.Messages[len(request.Messages)-1]
+		content, _ := json.Marshal(ChatResponse{Utterance: "네, 고객님.", NextStep: last.Content})
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": chatMessage{Role: "assistant", Content: string(content)}}},
+		})
+	}))
+	defer server.Close()
+
+	engine, err := NewOpenAIEngine(server.URL, "", "")
+	if err != nil {
+		t.Fatalf("NewOpenAIEngine: %v", err)
+	}
+	if _, err := engine.InitSession("s1", models.Scenario{OpeningLine: "안녕하세요."}, models.UserProfile{}, context.Background()); err != nil {
+		t.Fatalf("InitSession: %v", err)
+	}
+
+	tests := []struct {
+		step string
+		want string // 빈 문자열이면 현재 단계 유지
+	}{
+		{"closing", NextStepClosing},
+		{" Scam_Succeeded ", NextStepScamSucceeded},
+		{"user-hung-up", NextStepUserHungUp},
+		{"Credential Request", NextStepCredentialRequest},
+		{"협박 단계", ""},
+		{"end", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		resp, err := engine.Chat("s1", tt.step, context.Background())
+		if err != nil {
+			t.Fatalf("Chat(%q): %v", tt.step, err)
+		}
+		if resp.NextStep != tt.want {
+			t.Errorf("next_step %q = %q, want %q", tt.step, resp.NextStep, tt.want)
+		}
+	}
+
+	for _, step := range []string{NextStepOpening, NextStepPressure, NextStepCredentialRequest, NextStepPaymentRequest, NextStepClosing, NextStepUserHungUp, NextStepScamSucceeded} {
+		if !strings.Contains(systemPrompt, "- "+step+":") {
+			t.Errorf("system prompt does not list next_step %q", step)
+		}
+	}
+}
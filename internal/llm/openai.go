@@ -0,0 +1,273 @@
+/**
+* Name: 			openai.go
+* Description: 		OpenAI 호환 Chat Completions API 대화 엔진
+* Workflow: 		시나리오 기반 시스템 프롬프트 생성, 세션별 대화 기록 유지, 응답 JSON 파싱
+ */
+
+package llm
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+)
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model          string            `json:"model"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format,omitempty"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// 세션별 대화 기록
+type openAISession struct {
+	messages    []chatMessage
+	temperature float64
+
+	// 같은 세션의 Chat 호출을 직렬화 (동시에 들어온 발화가 서로의 대화 기록을 덮어쓰지 않도록 API 호출 동안 유지)
+	turn sync.Mutex
+}
+
+// OpenAI 호환 Chat Completions API를 사용하는 대화 엔진 (vLLM, Ollama 등 호환 서버 포함)
+type OpenAIEngine struct {
+	baseURL string
+	apiKey  string
+	model   string
+
+	mu       sync.Mutex
+	sessions map[string]*openAISession
+}
+
+func NewOpenAIEngine(baseURL, apiKey, model string) (*OpenAIEngine, error) {
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+	if apiKey == "" && baseURL == defaultOpenAIBaseURL {
+		return nil, errors.New("NewOpenAIEngine(): OPENAI_API_KEY environment variable is not set")
+	}
+	return &OpenAIEngine{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		apiKey:   apiKey,
+		model:    model,
+		sessions: make(map[string]*openAISession),
+	}, nil
+}
+
+func (e *OpenAIEngine) InitSession(sessionID string, scenario models.Scenario, userInfo models.UserProfile, ctx context.Context) (string, error) {
+	session := &openAISession{
+		messages:    []chatMessage{{Role: "system", Content: buildSystemPrompt(scenario, userInfo)}},
+		temperature: scenario.Temperature,
+	}
+
+	// 시나리오에 첫 발화가 정의되어 있으면 그대로 사용
+	if scenario.OpeningLine != "" {
+		session.messages = append(session.messages, chatMessage{Role: "assistant", Content: scenario.OpeningLine})
+		e.storeSession(sessionID, session)
+		return scenario.OpeningLine, nil
+	}
+
+	session.messages = append(session.messages, chatMessage{Role: "user", Content: "(전화가 연결되었습니다. 첫 마디를 시작하세요.)"})
+	resp, err := e.complete(session, ctx)
+	if err != nil {
+		return "", err
+	}
+	session.messages = append(session.messages, chatMessage{Role: "assistant", Content: resp.Utterance})
+	e.storeSession(sessionID, session)
+	return resp.Utterance, nil
+}
+
+func (e *OpenAIEngine) Chat(sessionID, text string, ctx context.Context) (*ChatResponse, error) {
+	// 엔진 잠금은 세션 조회에만 사용하고, 세션 잠금은 API 호출이 끝날 때까지 유지
+	e.mu.Lock()
+	session, exists := e.sessions[sessionID]
+	e.mu.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("OpenAIEngine.Chat(): unknown session %s", sessionID)
+	}
+	session.turn.Lock()
+	defer session.turn.Unlock()
+
+	// 실패한 발화는 대화 기록에 남기지 않도록 복사본으로 요청
+	request := &openAISession{
+		messages:    append(slices.Clone(session.messages), chatMessage{Role: "user", Content: text}),
+		temperature: session.temperature,
+	}
+	resp, err := e.complete(request, ctx)
+	if err != nil {
+		return nil, err
+	}
+	session.messages = append(request.messages, chatMessage{Role: "assistant", Content: resp.Utterance})
+	return resp, nil
+}
+
+func (e *OpenAIEngine) ClearSession(sessionID string) error {
+	e.mu.Lock()
+	delete(e.sessions, sessionID)
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *OpenAIEngine) storeSession(sessionID string, session *openAISession) {
+	e.mu.Lock()
+	e.sessions[sessionID] = session
+	e.mu.Unlock()
+}
+
+// Chat Completions API 호출 후 응답 JSON({"utterance", "next_step"}) 파싱
+func (e *OpenAIEngine) complete(session *openAISession, ctx context.Context) (*ChatResponse, error) {
+	reqBody, err := json.Marshal(chatCompletionRequest{
+		Model:          e.model,
+		Messages:       session.messages,
+		Temperature:    session.temperature,
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.baseURL+"/chat/completions", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("OpenAI chat completion failed with status: " + resp.Status)
+	}
+
+	var completion chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, err
+	}
+	if len(completion.Choices) == 0 {
+		return nil, errors.New("OpenAI chat completion returned no choices")
+	}
+
+	content := strings.TrimSpace(completion.Choices[0].Message.Content)
+	var chatResp ChatResponse
+	if err := json.Unmarshal([]byte(content), &chatResp); err != nil || chatResp.Utterance == "" {
+		// JSON 형식을 지키지 않은 경우 본문 전체를 발화로 사용
+		return &ChatResponse{Utterance: content}, nil
+	}
+	chatResp.NextStep = normalizeNextStep(chatResp.NextStep)
+	return &chatResp, nil
+}
+
+// 프롬프트에 안내하는 진행 단계와 설명 (next_step 허용 값)
+var nextStepGuide = []struct{ step, description string }{
+	{NextStepOpening, "인사, 신분 소개"},
+	{NextStepPressure, "긴급성, 불이익을 강조하며 압박"},
+	{NextStepCredentialRequest, "개인정보, 인증번호, 카드번호 등 요구"},
+	{NextStepPaymentRequest, "이체, 송금 요구"},
+	{NextStepClosing, "상대방이 끝까지 거부하거나 의심하여 통화를 마무리 (종료)"},
+	{NextStepUserHungUp, "상대방이 전화를 끊거나 신고하겠다고 함 (종료)"},
+	{NextStepScamSucceeded, "상대방이 이체, 송금을 완료했거나 인증번호 등을 모두 알려줌 (종료)"},
+}
+
+// 모델이 보낸 next_step을 허용 값으로 정규화 ("Credential Request" -> "credential_request")
+// 허용 값이 아니면 빈 문자열을 반환하여 현재 단계를 유지
+func normalizeNextStep(step string) string {
+	normalized := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(step)))
+	if normalized == "" {
+		return ""
+	}
+	if !slices.ContainsFunc(nextStepGuide, func(g struct{ step, description string }) bool { return g.step == normalized }) {
+		log.Printf("OpenAIEngine: Ignoring unknown next_step %q", step)
+		return ""
+	}
+	return normalized
+}
+
+// 시나리오와 사용자 프로필로 시스템 프롬프트 생성
+func buildSystemPrompt(scenario models.Scenario, userInfo models.UserProfile) string {
+	var b strings.Builder
+	b.WriteString("당신은 보이스피싱 예방 훈련 시뮬레이터에서 사기범 역할을 연기합니다. ")
+	b.WriteString("실제 통화처럼 자연스러운 한국어 구어체로 한두 문장씩 짧게 말하세요.\n")
+	fmt.Fprintf(&b, "시나리오: %s - %s\n", scenario.Name, scenario.Description)
+	if scenario.Persona != "" {
+		fmt.Fprintf(&b, "페르소나: %s\n", scenario.Persona)
+	}
+	if len(scenario.Goals) > 0 {
+		fmt.Fprintf(&b, "목표: %s\n", strings.Join(scenario.Goals, "; "))
+	}
+	if len(scenario.ForbiddenTopics) > 0 {
+		fmt.Fprintf(&b, "절대 언급하지 말 것: %s\n", strings.Join(scenario.ForbiddenTopics, "; "))
+	}
+	fmt.Fprintf(&b, "상대방 정보: %s\n", describeUser(userInfo))
+	b.WriteString(`응답은 반드시 {"utterance": "발화 내용", "next_step": "진행 단계"} 형식의 JSON으로만 작성하세요.` + "\n")
+	b.WriteString("next_step은 다음 값 중 하나를 그대로 사용하세요. 종료 단계를 보내면 통화가 끝납니다.\n")
+	for _, g := range nextStepGuide {
+		fmt.Fprintf(&b, "- %s: %s\n", g.step, g.description)
+	}
+	return b.String()
+}
+
+// 사용자가 공유한 프로필 항목만 나열 (공유하지 않은 항목은 빈 값)
+func describeUser(userInfo models.UserProfile) string {
+	var facts []string
+	if userInfo.Name != "" {
+		facts = append(facts, "이름 "+userInfo.Name)
+	}
+	if userInfo.Age > 0 {
+		facts = append(facts, fmt.Sprintf("나이 %d", userInfo.Age))
+	}
+	if userInfo.Gender != "" {
+		facts = append(facts, "성별 "+userInfo.Gender)
+	}
+	if userInfo.Occupation != "" {
+		facts = append(facts, "직업 "+userInfo.Occupation)
+	}
+	if userInfo.Bank != "" {
+		facts = append(facts, "주거래 은행 "+userInfo.Bank)
+	}
+	if userInfo.Region != "" {
+		facts = append(facts, "거주 지역 "+userInfo.Region)
+	}
+	if userInfo.HasChildren != nil {
+		if *userInfo.HasChildren {
+			facts = append(facts, "자녀 있음")
+		} else {
+			facts = append(facts, "자녀 없음")
+		}
+	}
+	if len(facts) == 0 {
+		return "알 수 없음 (개인 정보를 지어내지 말고 통화 중에 확인할 것)"
+	}
+	return strings.Join(facts, ", ")
+}
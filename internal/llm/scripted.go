@@ -0,0 +1,87 @@
+/**
+* Name: 			scripted.go
+* Description: 		외부 서비스 없이 동작하는 결정적(deterministic) 대화 엔진, 테스트용
+* Workflow: 		첫 발화 반환 후 사용자 발화마다 정해진 대사를 순서대로 반환
+ */
+
+package llm
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// 기본 대사 목록 (NewScriptedEngine(nil) 사용 시)
+var defaultScriptLines = []string{
+	"네, 고객님 본인 확인을 위해 성함과 생년월일을 말씀해 주시겠어요?",
+	"확인 감사합니다. 보안 절차상 지금 문자로 전송된 인증번호를 불러 주세요.",
+	"마지막으로 안전한 처리를 위해 안내해 드리는 계좌로 이체를 진행해 주셔야 합니다.",
+	"네, 처리 완료되었습니다. 이용해 주셔서 감사합니다.",
+}
+
+type scriptedSession struct {
+	turn int
+}
+
+// 고정된 대사를 순서대로 반환하는 대화 엔진
+type ScriptedEngine struct {
+	lines []string
+
+	mu       sync.Mutex
+	sessions map[string]*scriptedSession
+}
+
+// lines가 비어 있으면 기본 대사 목록 사용
+func NewScriptedEngine(lines []string) *ScriptedEngine {
+	if len(lines) == 0 {
+		lines = defaultScriptLines
+	}
+	return &ScriptedEngine{
+		lines:    lines,
+		sessions: make(map[string]*scriptedSession),
+	}
+}
+
+// 시나리오의 첫 발화를 반환, 정의되지 않은 경우 시나리오 이름으로 생성
+func (e *ScriptedEngine) InitSession(sessionID string, scenario models.Scenario, userInfo models.UserProfile, ctx context.Context) (string, error) {
+	e.mu.Lock()
+	e.sessions[sessionID] = &scriptedSession{}
+	e.mu.Unlock()
+
+	if scenario.OpeningLine != "" {
+		return scenario.OpeningLine, nil
+	}
+	return fmt.Sprintf("안녕하세요, %s님. %s 건으로 연락드렸습니다.", userInfo.Name, scenario.Name), nil
+}
+
+// 사용자 발화와 관계없이 다음 대사를 반환, 마지막 대사에서 NextStep은 "closing"
+func (e *ScriptedEngine) Chat(sessionID, text string, ctx context.Context) (*ChatResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	session, exists := e.sessions[sessionID]
+	if !exists {
+		return nil, fmt.Errorf("ScriptedEngine.Chat(): unknown session %s", sessionID)
+	}
+
+	index := session.turn
+	if index >= len(e.lines) {
+		index = len(e.lines) - 1
+	}
+	session.turn++
+
+	nextStep := NextStepPressure
+	if session.turn >= len(e.lines) {
+		nextStep = NextStepClosing
+	}
+	return &ChatResponse{Utterance: e.lines[index], NextStep: nextStep}, nil
+}
+
+func (e *ScriptedEngine) ClearSession(sessionID string) error {
+	e.mu.Lock()
+	delete(e.sessions, sessionID)
+	e.mu.Unlock()
+	return nil
+}
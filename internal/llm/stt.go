@@ -7,6 +7,7 @@
 package llm
 
 import (
+	"PishingSimulator_SecurityProject/internal/pii"
 	"context"
 	"errors"
 	"io"
@@ -18,6 +19,16 @@ import (
 	"google.golang.org/api/option"
 )
 
+// 클라이언트 마이크 오디오 샘플레이트 (WEBM_OPUS, 모노)
+const STTSampleRateHertz = 16000
+
+// 음성 인식 결과, IsFinal이 false이면 발화 도중의 중간 결과
+type STTResult struct {
+	Text       string
+	IsFinal    bool
+	Confidence float32 // 최종 결과에만 제공됨 (0.0 ~ 1.0)
+}
+
 type StreamingRecognizer struct {
 	stream speechpb.Speech_StreamingRecognizeClient
 	ctx    context.Context
@@ -45,7 +56,7 @@ func NewStreamingRecognizer(ctx context.Context) (*StreamingRecognizer, error) {
 	config := &speechpb.StreamingRecognitionConfig{
 		Config: &speechpb.RecognitionConfig{
 			Encoding:          speechpb.RecognitionConfig_WEBM_OPUS,
-			SampleRateHertz:   16000,
+			SampleRateHertz:   STTSampleRateHertz,
 			AudioChannelCount: 1,
 			LanguageCode:      "ko-KR",
 		},
@@ -77,7 +88,7 @@ func (r *StreamingRecognizer) SendAudio(audioData []byte) error {
 }
 
 // gRPC 스트리밍 응답 수신
-func (r *StreamingRecognizer) ReceiveTranslatedText(resultChannel chan<- string, errChan chan<- error) {
+func (r *StreamingRecognizer) ReceiveTranslatedText(resultChannel chan<- STTResult, errChan chan<- error) {
 	log.Printf("ReceiveTranslatedText(): started")
 	for {
 		resp, err := r.stream.Recv()
@@ -97,13 +108,16 @@ func (r *StreamingRecognizer) ReceiveTranslatedText(resultChannel chan<- string,
 		}
 
 		for _, result := range resp.Results {
+			if len(result.Alternatives) == 0 {
+				continue
+			}
+			alternative := result.Alternatives[0]
 			if result.IsFinal {
-				log.Printf("ReceiveTranslatedText(): final result: %s", result.Alternatives[0].Transcript)
-				resultChannel <- result.Alternatives[0].Transcript
+				log.Printf("ReceiveTranslatedText(): final result: %s", pii.Redact(alternative.Transcript))
 			} else {
-				log.Printf("ReceiveTranslatedText(): interim result: %s", result.Alternatives[0].Transcript)
-				// resultChannel <- result.Alternatives[0].Transcript
+				log.Printf("ReceiveTranslatedText(): interim result: %s", pii.Redact(alternative.Transcript))
 			}
+			resultChannel <- STTResult{Text: alternative.Transcript, IsFinal: result.IsFinal, Confidence: alternative.Confidence}
 		}
 	}
 }
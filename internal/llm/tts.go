@@ -11,6 +11,7 @@ import (
 	"errors"
 	"log"
 	"os"
+	"time"
 
 	"google.golang.org/api/option"
 
@@ -18,22 +19,33 @@ import (
 	"cloud.google.com/go/texttospeech/apiv1/texttospeechpb"
 )
 
+// 시나리오에 음성이 지정되지 않은 경우 사용하는 기본 음성
+const defaultVoiceName = "ko-KR-Wavenet-A"
+
+// 합성 오디오 샘플레이트 (LINEAR16, WAV 헤더 포함)
+const TTSSampleRateHertz = 16000
+
 // TTS 연결 정보
 type TTSClient struct {
-	client *texttospeech.Client
-	ctx    context.Context
+	client    *texttospeech.Client
+	ctx       context.Context
+	voiceName string
 }
 
-// TTS 클라이언트 초기화
-func NewTTSClient(ctx context.Context) (*TTSClient, error) {
+// TTS 클라이언트 초기화, voiceName이 비어 있으면 기본 음성 사용
+func NewTTSClient(ctx context.Context, voiceName string) (*TTSClient, error) {
 	credentialsFile := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
 	client, err := texttospeech.NewClient(ctx, option.WithCredentialsFile(credentialsFile))
 	if err != nil {
 		return nil, errors.New("NewTTSClient(): failed to create TTS client: " + err.Error())
 	}
+	if voiceName == "" {
+		voiceName = defaultVoiceName
+	}
 	return &TTSClient{
-		client: client,
-		ctx:    ctx,
+		client:    client,
+		ctx:       ctx,
+		voiceName: voiceName,
 	}, nil
 }
 
@@ -47,11 +59,11 @@ func (t *TTSClient) ConvertTextToAudio(text string) ([]byte, error) {
 		Voice: &texttospeechpb.VoiceSelectionParams{
 			LanguageCode: "ko-KR",
 			// SsmlGender:   texttospeechpb.Ssml,
-			Name: "ko-KR-Wavenet-A",
+			Name: t.voiceName,
 		},
 		AudioConfig: &texttospeechpb.AudioConfig{
 			AudioEncoding:   texttospeechpb.AudioEncoding_LINEAR16,
-			SampleRateHertz: 16000,
+			SampleRateHertz: TTSSampleRateHertz,
 		},
 	}
 
@@ -68,6 +80,16 @@ func (t *TTSClient) ConvertTextToAudio(text string) ([]byte, error) {
 	return resp.AudioContent, nil
 }
 
+// 합성 오디오(LINEAR16 WAV, 모노)의 재생 시간, 44바이트 WAV 헤더 제외
+func AudioDuration(audio []byte) time.Duration {
+	const wavHeaderSize = 44
+	if len(audio) <= wavHeaderSize {
+		return 0
+	}
+	samples := (len(audio) - wavHeaderSize) / 2
+	return time.Duration(samples) * time.Second / TTSSampleRateHertz
+}
+
 // TTS 클라이언트 종료
 func (t *TTSClient) Close() error {
 	if t.client != nil {
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"PishingSimulator_SecurityProject/internal/auth"
+	"PishingSimulator_SecurityProject/internal/models"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -27,14 +29,50 @@ func AuthMiddleware() gin.HandlerFunc {
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		claims, err := auth.ValidateToken(tokenString)
 		if err != nil {
-			if err == jwt.ErrTokenExpired {
+			// 클라이언트가 만료를 구분하여 /token/refresh로 재발급받을 수 있도록 사유를 구분
+			switch {
+			case errors.Is(err, jwt.ErrTokenExpired):
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has expired"})
+			case errors.Is(err, auth.ErrTokenRevoked):
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			default:
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			}
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
+		role := claims.Role
+		if role == "" {
+			role = models.RoleTrainee
+		}
+		c.Set("username", claims.Username)
+		c.Set("role", role)
+		c.Set("mfa", claims.MFA)
+		c.Next()
+	}
+}
+
+// 공개 API용 선택 인증, 유효한 토큰이 있으면 AuthMiddleware와 같이 사용자 정보를 설정하고
+// 토큰이 없거나 유효하지 않으면(만료, 폐기 포함) 익명 요청으로 통과
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found {
+			c.Next()
+			return
+		}
+		claims, err := auth.ValidateToken(tokenString)
+		if err != nil {
+			c.Next()
+			return
+		}
+		role := claims.Role
+		if role == "" {
+			role = models.RoleTrainee
+		}
 		c.Set("username", claims.Username)
+		c.Set("role", role)
+		c.Set("mfa", claims.MFA)
 		c.Next()
 	}
 }
@@ -1,25 +1,38 @@
 package middleware
 
 import (
+	"PishingSimulator_SecurityProject/internal/storage"
+	"errors"
 	"log"
 	"net/http"
-	"os"
 
 	"github.com/gin-gonic/gin"
 )
 
-func InviteCodeMiddleware() gin.HandlerFunc {
-	inviteCode := os.Getenv("SIGNUP_INVIT_CODE")
-	if inviteCode == "" {
-		log.Fatal("[Fatal] SIGNUP_INVITE_CODE가 없음, 회원가입 불가")
-	}
+// X-Invite-Code 헤더의 초대 코드를 DB에서 확인하고 유효한 코드는 "invite_code"로 전달 (가입 처리 시 사용)
+// required가 true이면 초대 코드 없이 가입할 수 없음
+func InviteCodeMiddleware(required bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		clientKey := c.GetHeader("X-Invite-Code")
+		if clientKey == "" {
+			if required {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Invite code required"})
+				return
+			}
+			c.Next()
+			return
+		}
 
-		if clientKey != inviteCode {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Invaid"})
+		if _, err := storage.GetUsableInviteCode(clientKey); err != nil {
+			if errors.Is(err, storage.ErrInviteCodeInvalid) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Invalid invite code"})
+			} else {
+				log.Printf("[ERROR] GetUsableInviteCode failed: %v", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check invite code"})
+			}
 			return
 		}
+		c.Set("invite_code", clientKey)
 		c.Next()
 	}
 }
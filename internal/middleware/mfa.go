@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 조직 정책상 2단계 인증이 필요한 역할은 2단계 인증으로 로그인한 토큰만 통과, AuthMiddleware 이후에 사용
+// 아직 등록하지 않은 사용자는 /api/mfa로 등록한 뒤 다시 로그인해야 함
+func RequireMFA() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("mfa") {
+			c.Next()
+			return
+		}
+
+		// 토큰 발급 후 역할이 바뀌었을 수 있으므로 DB의 역할로 확인
+		user, err := storage.GetUserByUsername(c.GetString("username"))
+		if err != nil {
+			log.Printf("[ERROR] GetUserByUsername failed: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user"})
+			return
+		}
+		if !models.IsElevatedRole(user.Role) || user.OrgID == nil {
+			c.Next()
+			return
+		}
+		org, err := storage.GetOrganizationByID(*user.OrgID)
+		if err != nil {
+			log.Printf("[ERROR] GetOrganizationByID failed: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve organization"})
+			return
+		}
+		if org.RequiresMFA(user.Role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "MFA required"})
+			return
+		}
+		c.Next()
+	}
+}
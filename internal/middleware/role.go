@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"PishingSimulator_SecurityProject/internal/storage"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 현재 역할이 roles 중 하나인 사용자만 통과, AuthMiddleware 이후에 사용
+// 역할 변경이 기존 토큰에 바로 반영되도록 JWT의 역할 대신 DB의 역할을 확인하고 이후 핸들러에도 DB의 역할을 설정
+func RequireRole(roles ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+	return func(c *gin.Context) {
+		user, err := storage.GetUserByUsername(c.GetString("username"))
+		if err != nil {
+			log.Printf("[ERROR] GetUserByUsername failed: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user"})
+			return
+		}
+		c.Set("role", user.Role)
+		if !allowed[user.Role] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient role"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"PishingSimulator_SecurityProject/internal/storage"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ?ticket= 쿼리의 1회용 티켓으로 인증 (브라우저 audio 요소처럼 Authorization 헤더를 보낼 수 없는 요청용)
+// 티켓은 purpose 용도로 발급되고 resourceParam 경로 파라미터 값에 묶여 있어야 함
+// 티켓이 없으면 AuthMiddleware와 같이 Authorization 헤더로 인증
+func TicketAuthMiddleware(purpose, resourceParam string) gin.HandlerFunc {
+	authMiddleware := AuthMiddleware()
+	return func(c *gin.Context) {
+		ticket := c.Query("ticket")
+		if ticket == "" {
+			authMiddleware(c)
+			return
+		}
+
+		userID, err := storage.ConsumeAccessTicket(ticket, purpose, c.Param(resourceParam))
+		if err != nil {
+			if errors.Is(err, storage.ErrAccessTicketInvalid) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired ticket"})
+			} else {
+				log.Printf("[ERROR] ConsumeAccessTicket failed: %v", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check ticket"})
+			}
+			return
+		}
+		user, err := storage.GetUserByID(userID)
+		if err != nil {
+			log.Printf("[ERROR] GetUserByID failed: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user"})
+			return
+		}
+		c.Set("username", user.Username)
+		c.Set("role", user.Role)
+		// 티켓은 RequireMFA를 통과한 요청에서만 발급됨
+		c.Set("mfa", true)
+		c.Next()
+	}
+}
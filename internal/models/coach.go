@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// 코칭 힌트 ID 형식 (예: otp_request)
+var coachHintIDPattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+// 코치 모드에서 사기범 발화 직후 훈련생에게 보여줄 힌트
+// Keywords 중 하나가 발화에 포함되거나 발화의 진행 단계가 Steps 중 하나이면 표시 (세션당 한 번)
+type CoachHint struct {
+	ID       string   `json:"id" yaml:"id" example:"otp_request"`
+	Keywords []string `json:"keywords,omitempty" yaml:"keywords,omitempty" example:"인증번호,OTP"`
+	Steps    []string `json:"steps,omitempty" yaml:"steps,omitempty" example:"credential_request"`
+	Message  string   `json:"message" yaml:"message" example:"실제 기관은 전화로 인증번호(OTP)를 묻지 않습니다."`
+}
+
+// 사기범 발화와 진행 단계가 힌트 조건에 해당하는지 확인 (키워드는 대소문자, 공백 무시)
+func (h CoachHint) Matches(utterance string, step string) bool {
+	for _, s := range h.Steps {
+		if s == step {
+			return true
+		}
+	}
+	normalized := normalizeHintText(utterance)
+	for _, keyword := range h.Keywords {
+		if keyword = normalizeHintText(keyword); keyword != "" && strings.Contains(normalized, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeHintText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), ""))
+}
+
+// 힌트 목록 검증 (ID 형식 및 중복, 메시지와 표시 조건)
+func ValidateCoachHints(hints []CoachHint) error {
+	seen := make(map[string]bool, len(hints))
+	for i, hint := range hints {
+		if !coachHintIDPattern.MatchString(hint.ID) {
+			return fmt.Errorf("hints[%d]: id must match [a-z0-9_]{1,64}", i)
+		}
+		if seen[hint.ID] {
+			return fmt.Errorf("hints[%d]: duplicate id %q", i, hint.ID)
+		}
+		seen[hint.ID] = true
+		if strings.TrimSpace(hint.Message) == "" {
+			return fmt.Errorf("hints[%d] (%s): message cannot be empty", i, hint.ID)
+		}
+		if len(hint.Keywords) == 0 && len(hint.Steps) == 0 {
+			return fmt.Errorf("hints[%d] (%s): keywords or steps are required", i, hint.ID)
+		}
+	}
+	return nil
+}
+
+// 시나리오에 힌트가 정의되지 않은 경우 사용하는 공통 위험 신호 힌트
+var defaultCoachHints = []CoachHint{
+	{
+		ID:       "otp_request",
+		Keywords: []string{"인증번호", "otp", "보안카드", "승인번호"},
+		Message:  "실제 금융기관이나 공공기관은 전화로 인증번호(OTP)나 보안카드 번호를 묻지 않습니다.",
+	},
+	{
+		ID:       "password_request",
+		Keywords: []string{"비밀번호", "비번", "패스워드"},
+		Message:  "비밀번호는 어떤 기관도 전화로 요구하지 않습니다. 절대 알려주지 마세요.",
+	},
+	{
+		ID:       "identity_request",
+		Keywords: []string{"주민등록번호", "주민번호", "카드번호"},
+		Message:  "통화 중 주민등록번호나 카드번호를 요구하면 전화를 끊고 공식 대표번호로 직접 확인하세요.",
+	},
+	{
+		ID:       "money_transfer",
+		Keywords: []string{"안전계좌", "이체", "송금", "입금"},
+		Steps:    []string{"payment_request"},
+		Message:  "수사기관이나 금융기관은 '안전계좌'로 돈을 옮기라고 하지 않습니다. 송금 요구는 사기의 대표적인 신호입니다.",
+	},
+	{
+		ID:       "link_or_app",
+		Keywords: []string{"링크", "url", "앱 설치", "앱을 설치", "원격"},
+		Message:  "문자로 받은 링크를 누르거나 앱을 설치하라는 요구는 악성 앱 설치로 이어질 수 있습니다.",
+	},
+	{
+		ID:       "urgency",
+		Keywords: []string{"지금 바로", "당장", "오늘 안에", "구속", "체포", "연체"},
+		Message:  "시간 압박이나 처벌 위협으로 판단을 서두르게 하는 것은 전형적인 사기 수법입니다. 잠시 멈추고 확인하세요.",
+	},
+}
+
+// 세션에 적용할 힌트, 시나리오에 정의된 힌트가 없으면 공통 힌트
+func (s Scenario) CoachHints() []CoachHint {
+	if len(s.Hints) > 0 {
+		return s.Hints
+	}
+	return defaultCoachHints
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// 시나리오/모듈 진행 상태
+const (
+	ProgressCompleted  = "completed"   // 통과 기준 달성
+	ProgressInProgress = "in_progress" // 시도했으나 아직 통과하지 못함
+	ProgressAvailable  = "available"   // 시작 가능, 시도 기록 없음
+	ProgressLocked     = "locked"      // 이전 모듈을 완료해야 시작 가능
+)
+
+// 모듈 키 형식 (예: basics)
+var moduleKeyPattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+// 커리큘럼, 모듈은 순서대로 진행하며 이전 모듈을 모두 완료해야 다음 모듈이 열림
+type Curriculum struct {
+	Modules []CurriculumModule `json:"modules" yaml:"modules"`
+}
+
+// 커리큘럼 모듈, 포함된 시나리오를 모두 통과하면 완료
+type CurriculumModule struct {
+	Key         string   `json:"key" yaml:"key" example:"basics"`
+	Name        string   `json:"name" yaml:"name" example:"기초: 생활 속 사칭"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Scenarios   []string `json:"scenarios" yaml:"scenarios" example:"delivery_notification"`
+	PassScore   int      `json:"pass_score" yaml:"pass_score" example:"70"` // 시나리오 통과 기준 점수 (평가 리포트 점수, 0 ~ 100)
+}
+
+// 커리큘럼 검증 (모듈 키 형식 및 중복, 통과 점수 범위, 시나리오 중복 배정)
+func (c Curriculum) Validate() error {
+	if len(c.Modules) == 0 {
+		return errors.New("curriculum has no modules")
+	}
+	modules := make(map[string]bool, len(c.Modules))
+	owners := make(map[string]string)
+	for i, module := range c.Modules {
+		if !moduleKeyPattern.MatchString(module.Key) {
+			return fmt.Errorf("modules[%d]: key must match [a-z0-9_]{1,64}", i)
+		}
+		if modules[module.Key] {
+			return fmt.Errorf("modules[%d]: duplicate key %q", i, module.Key)
+		}
+		modules[module.Key] = true
+		if module.Name == "" {
+			return fmt.Errorf("modules[%d] (%s): name cannot be empty", i, module.Key)
+		}
+		if module.PassScore < 0 || module.PassScore > 100 {
+			return fmt.Errorf("modules[%d] (%s): pass_score must be between 0 and 100", i, module.Key)
+		}
+		if len(module.Scenarios) == 0 {
+			return fmt.Errorf("modules[%d] (%s): no scenarios", i, module.Key)
+		}
+		for _, scenarioKey := range module.Scenarios {
+			if owner, exists := owners[scenarioKey]; exists {
+				return fmt.Errorf("modules[%d] (%s): scenario %q is already in module %q", i, module.Key, scenarioKey, owner)
+			}
+			owners[scenarioKey] = module.Key
+		}
+	}
+	return nil
+}
+
+// 커리큘럼 파일이 없을 때 사용하는 기본 커리큘럼 (기본 시나리오 기준)
+func DefaultCurriculum() Curriculum {
+	return Curriculum{Modules: []CurriculumModule{
+		{
+			Key:         "basics",
+			Name:        "기초: 생활 속 사칭",
+			Description: "택배, 문자 링크 등 일상에서 자주 접하는 사칭 수법을 익힙니다.",
+			Scenarios:   []string{"delivery_notification"},
+			PassScore:   70,
+		},
+		{
+			Key:         "intermediate",
+			Name:        "중급: 지인 및 금융 사칭",
+			Description: "지인 사칭과 대출 사기처럼 감정과 이익을 이용하는 수법에 대응합니다.",
+			Scenarios:   []string{"friends_impersonation", "loan_scam"},
+			PassScore:   80,
+		},
+		{
+			Key:         "advanced",
+			Name:        "고급: 기관 사칭",
+			Description: "수사기관을 사칭해 권위와 공포로 압박하는 수법에 대응합니다.",
+			Scenarios:   []string{"institution_impersonation"},
+			PassScore:   80,
+		},
+	}}
+}
+
+// 사용자별 시나리오 진행 기록 (user_progress 테이블)
+type ScenarioProgress struct {
+	UserID       int       `json:"-"`
+	ScenarioKey  string    `json:"-"`
+	Attempts     int       `json:"attempts" example:"3"`
+	BestScore    *int      `json:"best_score,omitempty" example:"85"` // 평가를 통과한(치명 항목 없음) 세션 중 최고 점수
+	LastScore    *int      `json:"last_score,omitempty" example:"60"`
+	LastRecordID *int      `json:"last_record_id,omitempty" example:"12"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
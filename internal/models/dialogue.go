@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+)
+
+// 대화 노드의 진행 단계 (llm.NextStep* 값)
+const (
+	DialogueStepOpening           = "opening"
+	DialogueStepPressure          = "pressure"
+	DialogueStepCredentialRequest = "credential_request"
+	DialogueStepPaymentRequest    = "payment_request"
+	DialogueStepClosing           = "closing"
+	DialogueStepUserHungUp        = "user_hung_up"
+	DialogueStepScamSucceeded     = "scam_succeeded"
+)
+
+// 세션을 종료시키는 단계, 종료 노드(End)는 이 중 하나를 보고해야 함
+var terminalDialogueSteps = []string{DialogueStepClosing, DialogueStepUserHungUp, DialogueStepScamSucceeded}
+
+var dialogueSteps = append([]string{DialogueStepOpening, DialogueStepPressure, DialogueStepCredentialRequest, DialogueStepPaymentRequest}, terminalDialogueSteps...)
+
+// 오프라인 대화 엔진(dialogue)이 사용하는 시나리오별 대화 트리
+type DialogueScript struct {
+	Start string                  `json:"start" yaml:"start"`
+	Nodes map[string]DialogueNode `json:"nodes" yaml:"nodes"`
+}
+
+// 대화 트리의 노드, 노드에 진입하면 Lines 중 하나를 발화함
+type DialogueNode struct {
+	Step         string           `json:"step" yaml:"step"`                                       // 진입 시 보고할 NextStep 값
+	Lines        []string         `json:"lines" yaml:"lines"`                                     // 진입 시 발화 ({name}은 사용자 이름으로 치환)
+	Branches     []DialogueBranch `json:"branches,omitempty" yaml:"branches,omitempty"`           // 키워드 분기, 위에서부터 순서대로 검사
+	Fallback     []string         `json:"fallback,omitempty" yaml:"fallback,omitempty"`           // 일치하는 분기가 없을 때의 발화
+	Next         string           `json:"next,omitempty" yaml:"next,omitempty"`                   // 폴백이 MaxFallbacks회 반복되면 이동할 노드
+	MaxFallbacks int              `json:"max_fallbacks,omitempty" yaml:"max_fallbacks,omitempty"` // 기본값 2
+	End          bool             `json:"end,omitempty" yaml:"end,omitempty"`                     // 진입 시 세션 종료
+}
+
+// 사용자 발화에 Keywords 중 하나가 포함되면 Next 노드로 이동
+// "="로 시작하는 키워드는 단어 단위로 비교하며, 부정 표현(안, 못, 않)이 붙은 키워드는 일치하지 않음
+type DialogueBranch struct {
+	Keywords []string `json:"keywords" yaml:"keywords"`
+	Next     string   `json:"next" yaml:"next"`
+}
+
+// 대화 트리 구조 검증 (시작 노드, 진행 단계, 분기 대상 노드 존재 여부 등)
+func (d DialogueScript) Validate() error {
+	if _, exists := d.Nodes[d.Start]; !exists {
+		return fmt.Errorf("dialogue start node %q is not defined", d.Start)
+	}
+	for id, node := range d.Nodes {
+		if len(node.Lines) == 0 {
+			return fmt.Errorf("dialogue node %q has no lines", id)
+		}
+		if !slices.Contains(dialogueSteps, node.Step) {
+			return fmt.Errorf("dialogue node %q has unknown step %q (allowed: %v)", id, node.Step, dialogueSteps)
+		}
+		if node.End && !slices.Contains(terminalDialogueSteps, node.Step) {
+			return fmt.Errorf("dialogue end node %q must use a terminal step (%v), got %q", id, terminalDialogueSteps, node.Step)
+		}
+		if !node.End && len(node.Branches) == 0 && node.Next == "" {
+			return fmt.Errorf("dialogue node %q has no way to continue (add branches, next, or end)", id)
+		}
+		for i, branch := range node.Branches {
+			if len(branch.Keywords) == 0 {
+				return fmt.Errorf("dialogue node %q branch %d has no keywords", id, i)
+			}
+			if _, exists := d.Nodes[branch.Next]; !exists {
+				return fmt.Errorf("dialogue node %q branch %d targets undefined node %q", id, i, branch.Next)
+			}
+		}
+		if node.Next != "" {
+			if _, exists := d.Nodes[node.Next]; !exists {
+				return fmt.Errorf("dialogue node %q next targets undefined node %q", id, node.Next)
+			}
+		}
+	}
+	return nil
+}
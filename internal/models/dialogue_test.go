@@ -0,0 +1,46 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDialogueScriptValidateSteps(t *testing.T) {
+	// opening 노드에서 end 노드로 이동하는 최소 대화 트리
+	script := func(openingStep, endStep string) DialogueScript {
+		return DialogueScript{
+			Start: "opening",
+			Nodes: map[string]DialogueNode{
+				"opening": {Step: openingStep, Lines: []string{"안녕하세요."}, Branches: []DialogueBranch{{Keywords: []string{"네"}, Next: "end"}}},
+				"end":     {Step: endStep, Lines: []string{"끊겠습니다."}, End: true},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		script      DialogueScript
+		wantErrPart string // 빈 문자열이면 오류 없음
+	}{
+		{"valid steps", script(DialogueStepOpening, DialogueStepClosing), ""},
+		{"end node reports user hung up", script(DialogueStepPressure, DialogueStepUserHungUp), ""},
+		{"end node reports scam succeeded", script(DialogueStepOpening, DialogueStepScamSucceeded), ""},
+		{"missing step", script("", DialogueStepClosing), `"opening" has unknown step ""`},
+		{"unknown step", script("greeting", DialogueStepClosing), `unknown step "greeting"`},
+		{"misspelled step", script(DialogueStepOpening, "Closing"), `unknown step "Closing"`},
+		{"end node with non-terminal step", script(DialogueStepOpening, DialogueStepPaymentRequest), `end node "end" must use a terminal step`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.script.Validate()
+			if tt.wantErrPart == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrPart) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErrPart)
+			}
+		})
+	}
+}
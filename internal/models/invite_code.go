@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// 초대 코드 상태 (InviteCode.Status)
+const (
+	InviteCodeActive    = "active"
+	InviteCodeExpired   = "expired"
+	InviteCodeExhausted = "exhausted" // 최대 사용 횟수 도달
+	InviteCodeRevoked   = "revoked"
+)
+
+// 회원가입 초대 코드, 가입한 사용자에게 지정된 조직, 역할, 그룹을 적용
+// 코드 원문은 저장하지 않으며(SHA-256 해시) 생성 응답에서만 반환
+type InviteCode struct {
+	ID         int        `json:"id" example:"7"`
+	Code       string     `json:"code,omitempty" example:"K7QX-M2PA-9FZD-W4TR"` // 생성 응답에만 포함
+	CodePrefix string     `json:"code_prefix" example:"K7QX"`                   // 목록에서 코드를 구분하기 위한 앞 4자리
+	OrgID      *int       `json:"org_id,omitempty" example:"1"`
+	Role       string     `json:"role" example:"trainee"`
+	GroupID    *int       `json:"group_id,omitempty" example:"3"`
+	MaxUses    int        `json:"max_uses" example:"30"`
+	Uses       int        `json:"uses" example:"12"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy  int        `json:"created_by" example:"1"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Status     string     `json:"status" example:"active"`
+}
+
+// 기준 시각의 코드 상태
+func (c InviteCode) StatusAt(now time.Time) string {
+	switch {
+	case c.RevokedAt != nil:
+		return InviteCodeRevoked
+	case !now.Before(c.ExpiresAt):
+		return InviteCodeExpired
+	case c.Uses >= c.MaxUses:
+		return InviteCodeExhausted
+	}
+	return InviteCodeActive
+}
+
+// 초대 코드 사용 기록 (누가 언제 어디서 가입했는지)
+type InviteCodeRedemption struct {
+	ID         int       `json:"id" example:"15"`
+	CodeID     int       `json:"code_id" example:"7"`
+	UserID     int       `json:"user_id" example:"42"`
+	Username   string    `json:"username" example:"gildong"`
+	ClientIP   string    `json:"client_ip" example:"203.0.113.10"`
+	RedeemedAt time.Time `json:"redeemed_at"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// 조직 (고객사), 사용자, 그룹, 시뮬레이션 기록은 조직 단위로 분리됨
+type Organization struct {
+	ID               int       `json:"id" example:"1"`
+	Name             string    `json:"name" example:"OO은행"`
+	Scenarios        []string  `json:"scenarios" example:"loan_scam,institution_impersonation"` // 관리자가 허용한 시나리오 키, null이면 모든 활성 시나리오
+	EnabledScenarios []string  `json:"enabled_scenarios" example:"loan_scam"`                   // 조직 관리자가 허용 시나리오 중 선택한 시나리오, null이면 허용 시나리오 전체
+	MFARequiredRoles []string  `json:"mfa_required_roles" example:"org_admin"`                  // 관리자가 지정한 2단계 인증(TOTP) 필수 역할 (조직 관리자가 해제할 수 없음)
+	OrgMFARoles      []string  `json:"org_mfa_required_roles" example:"trainer"`                // 조직 관리자가 추가로 지정한 2단계 인증 필수 역할
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// 조직 정책상 역할에 2단계 인증이 필요한지 확인
+func (o Organization) RequiresMFA(role string) bool {
+	return slices.Contains(o.MFARequiredRoles, role) || slices.Contains(o.OrgMFARoles, role)
+}
+
+// 관리자가 조직에 시나리오를 허용했는지 확인 (조직 관리자가 선택할 수 있는 범위)
+func (o Organization) GrantsScenario(key string) bool {
+	return o.Scenarios == nil || slices.Contains(o.Scenarios, key)
+}
+
+// 조직에서 시나리오를 사용할 수 있는지 확인 (관리자 허용, 조직 관리자 선택 모두 해당)
+func (o Organization) AllowsScenario(key string) bool {
+	return o.GrantsScenario(key) && (o.EnabledScenarios == nil || slices.Contains(o.EnabledScenarios, key))
+}
+
+// 조직 초대, 조직 관리자가 조직에 소속되지 않은 기존 사용자를 초대하고 사용자가 수락하면 소속됨
+type OrganizationInvitation struct {
+	ID         int        `json:"id" example:"5"`
+	OrgID      int        `json:"org_id" example:"1"`
+	OrgName    string     `json:"org_name" example:"OO은행"`
+	Username   string     `json:"username" example:"gildong"`
+	Role       string     `json:"role" example:"trainee"`         // 수락 시 지정되는 역할
+	GroupID    *int       `json:"group_id,omitempty" example:"3"` // 수락 시 배정되는 그룹
+	InvitedBy  int        `json:"invited_by" example:"2"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"` // 대기 중이면 생략
+}
+
+// 초대(조직 초대, 초대 코드)로 부여할 수 있는 역할 (관리자 역할은 제외)
+func IsInvitableRole(role string) bool {
+	return role == RoleTrainee || role == RoleTrainer || role == RoleOrgAdmin
+}
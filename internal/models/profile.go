@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 프로필 항목 이름 (User.LLMSharedFields)
+const (
+	ProfileFieldName        = "name"
+	ProfileFieldAge         = "age"
+	ProfileFieldGender      = "gender"
+	ProfileFieldOccupation  = "occupation"
+	ProfileFieldBank        = "bank"
+	ProfileFieldRegion      = "region"
+	ProfileFieldHasChildren = "has_children"
+)
+
+// 텍스트 항목 최대 길이 (LLM 프롬프트에 그대로 들어가므로 제한)
+const maxProfileTextLength = 50
+
+var profileFields = []string{
+	ProfileFieldName, ProfileFieldAge, ProfileFieldGender,
+	ProfileFieldOccupation, ProfileFieldBank, ProfileFieldRegion, ProfileFieldHasChildren,
+}
+
+// 공유 항목을 지정하지 않은 사용자의 LLM 공유 항목 (추가 항목은 사용자가 직접 선택해야 공유)
+var DefaultLLMSharedFields = []string{ProfileFieldName, ProfileFieldAge, ProfileFieldGender}
+
+// LLM 세션용 사용자 프로필, 사기범 역할의 LLM이 상대방에 맞춘 대사를 만들 때 사용
+type UserProfile struct {
+	Name        string `json:"name" example:"홍길동"`
+	Age         int    `json:"age" example:"34"` // 0이면 미입력 (SSO로 생성된 계정)
+	Gender      string `json:"gender" example:"male"`
+	Occupation  string `json:"occupation,omitempty" example:"회사원"`
+	Bank        string `json:"bank,omitempty" example:"국민은행"`     // 주로 사용하는 은행
+	Region      string `json:"region,omitempty" example:"서울 마포구"` // 거주 지역
+	HasChildren *bool  `json:"has_children,omitempty"`            // 자녀 유무, 미입력이면 생략
+}
+
+func IsValidProfileField(field string) bool {
+	return slices.Contains(profileFields, field)
+}
+
+// 텍스트 항목의 앞뒤 공백 제거
+func (p *UserProfile) Normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Gender = strings.TrimSpace(p.Gender)
+	p.Occupation = strings.TrimSpace(p.Occupation)
+	p.Bank = strings.TrimSpace(p.Bank)
+	p.Region = strings.TrimSpace(p.Region)
+}
+
+// 프로필 값 검증
+func (p UserProfile) Validate() error {
+	if p.Age < 0 || p.Age > 150 {
+		return errors.New("Age must be between 1 and 150")
+	}
+	texts := []struct{ field, value string }{
+		{ProfileFieldName, p.Name},
+		{ProfileFieldGender, p.Gender},
+		{ProfileFieldOccupation, p.Occupation},
+		{ProfileFieldBank, p.Bank},
+		{ProfileFieldRegion, p.Region},
+	}
+	for _, t := range texts {
+		if utf8.RuneCountInString(t.value) > maxProfileTextLength {
+			return fmt.Errorf("%s must be at most %d characters", t.field, maxProfileTextLength)
+		}
+		if strings.IndexFunc(t.value, unicode.IsControl) >= 0 {
+			return fmt.Errorf("%s cannot contain control characters", t.field)
+		}
+	}
+	return nil
+}
+
+// LLM 공유 항목 검증 및 중복 제거
+func NormalizeLLMSharedFields(fields []string) ([]string, error) {
+	normalized := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !IsValidProfileField(field) {
+			return nil, fmt.Errorf("Unknown profile field %q (allowed: %s)", field, strings.Join(profileFields, ", "))
+		}
+		if !slices.Contains(normalized, field) {
+			normalized = append(normalized, field)
+		}
+	}
+	return normalized, nil
+}
+
+// 사용자가 공유를 허용한 항목만 남긴 프로필 (LLM 대화 엔진에 전달)
+func (u User) LLMProfile() UserProfile {
+	shared := func(field string) bool { return slices.Contains(u.LLMSharedFields, field) }
+	var profile UserProfile
+	if shared(ProfileFieldName) {
+		profile.Name = u.Profile.Name
+	}
+	if shared(ProfileFieldAge) {
+		profile.Age = u.Profile.Age
+	}
+	if shared(ProfileFieldGender) {
+		profile.Gender = u.Profile.Gender
+	}
+	if shared(ProfileFieldOccupation) {
+		profile.Occupation = u.Profile.Occupation
+	}
+	if shared(ProfileFieldBank) {
+		profile.Bank = u.Profile.Bank
+	}
+	if shared(ProfileFieldRegion) {
+		profile.Region = u.Profile.Region
+	}
+	if shared(ProfileFieldHasChildren) {
+		profile.HasChildren = u.Profile.HasChildren
+	}
+	return profile
+}
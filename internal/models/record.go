@@ -2,10 +2,24 @@ package models
 
 import "time"
 
+// 세션 결과 (Record.Outcome)
+const (
+	OutcomeScamSucceeded = "scam_succeeded" // 훈련생이 사기에 넘어감
+	OutcomeResisted      = "resisted"       // 훈련생이 통화를 끊거나 요구를 거절함
+	OutcomeIncomplete    = "incomplete"     // 결론 없이 연결이 끊김
+)
+
 type Record struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	Scenario  string    `json:"scenario"`
-	FilePath  string    `json:"file_path"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	OrgID      *int      `json:"org_id,omitempty" example:"1"` // 세션 당시 사용자의 소속 조직
+	Scenario   string    `json:"scenario"`
+	Mode       string    `json:"mode" example:"voice"`         // text | voice
+	FilePath   string    `json:"file_path"`                    // 음성 모드의 녹음 파일 (텍스트 모드는 빈 값)
+	DurationMs int64     `json:"duration_ms" example:"185000"` // 세션 진행 시간
+	Outcome    string    `json:"outcome" example:"resisted"`
+	FinalState string    `json:"final_state" example:"user_hung_up"`
+	Coach      bool      `json:"coach"`                        // 코치 모드(힌트 표시) 세션 여부
+	Score      *int      `json:"score,omitempty" example:"70"` // 평가 리포트 점수 (평가 전이면 생략)
+	CreatedAt  time.Time `json:"created_at"`
 }
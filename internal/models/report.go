@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// 평가 항목 (Finding.Category)
+const (
+	FindingResidentNumber       = "resident_registration_number"
+	FindingCardNumber           = "card_number"
+	FindingAccountNumber        = "account_number"
+	FindingPhoneNumber          = "phone_number"
+	FindingOTP                  = "otp"
+	FindingPassword             = "password"
+	FindingAddress              = "address"
+	FindingMoneyTransfer        = "money_transfer"
+	FindingCallbackVerification = "callback_verification"
+)
+
+// 평가 항목 종류 (Finding.Kind)
+const (
+	FindingKindRisk = "risk" // 감점 항목 (정보 유출, 송금 동의 등)
+	FindingKindGood = "good" // 가점 항목 (발신자 확인 등)
+)
+
+// 평가 근거가 된 발화, 민감 정보는 일부 마스킹됨
+type Evidence struct {
+	TurnSeq int    `json:"turn_seq" example:"3"`
+	StartMs int64  `json:"start_ms" example:"15200"`
+	Quote   string `json:"quote" example:"주민번호는 90****-*****34 입니다"`
+}
+
+// 평가 항목별 결과
+type Finding struct {
+	Category    string     `json:"category" example:"resident_registration_number"`
+	Kind        string     `json:"kind" example:"risk"`
+	Points      int        `json:"points" example:"-30"` // 점수 반영값 (감점은 음수)
+	Description string     `json:"description" example:"주민등록번호를 알려주었습니다."`
+	Evidence    []Evidence `json:"evidence"`
+}
+
+// 코치 모드에서 표시된 힌트
+type ShownHint struct {
+	HintID  string `json:"hint_id" example:"otp_request"`
+	TurnSeq int    `json:"turn_seq" example:"4"`
+	StartMs int64  `json:"start_ms" example:"21000"`
+	Message string `json:"message" example:"실제 기관은 전화로 인증번호(OTP)를 묻지 않습니다."`
+}
+
+// 세션 종료 후 대화 기록으로 생성되는 평가 리포트
+type EvaluationReport struct {
+	RecordID    int         `json:"record_id" example:"12"`
+	Version     int         `json:"version" example:"1"`
+	Score       int         `json:"score" example:"70"` // 0 ~ 100
+	Passed      bool        `json:"passed"`
+	Outcome     string      `json:"outcome" example:"resisted"`
+	Findings    []Finding   `json:"findings"`
+	Coach       bool        `json:"coach"`       // 코치 모드 세션 여부
+	HintsShown  []ShownHint `json:"hints_shown"` // 세션 중 표시된 코칭 힌트 (표시 순서)
+	GeneratedAt time.Time   `json:"generated_at"`
+}
@@ -1,33 +1,191 @@
 package models
 
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// 시뮬레이션 모드
+const (
+	ModeText  = "text"
+	ModeVoice = "voice"
+)
+
+// 시나리오 난이도
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// LLM 기본 temperature (시나리오에 지정되지 않은 경우, 0은 유효한 값이므로 생략 여부로 판단)
+const DefaultTemperature = 0.7
+
+// 시나리오 출처 (시나리오 팩은 파일 경로)
+const (
+	ScenarioSourceBuiltin = "builtin"
+	ScenarioSourceAdmin   = "admin"
+)
+
+// 시나리오 키 형식 (예: loan_scam)
+var scenarioKeyPattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
 // Define Scenario
 type Scenario struct {
-	Name        string
-	Description string
+	Key              string   `json:"key" example:"loan_scam"`
+	Name             string   `json:"name" example:"Loan Scam"`
+	Description      string   `json:"description" example:"A scenario where the user is targeted with a loan scam call."`
+	Modes            []string `json:"modes" example:"text,voice"`
+	Difficulty       string   `json:"difficulty" example:"easy"`
+	EstimatedMinutes int      `json:"estimated_minutes" example:"8"`
+	Enabled          bool     `json:"enabled"`
+
+	// LLM 페르소나 설정 (훈련생용 목록에는 노출하지 않음)
+	Persona         string   `json:"persona,omitempty"`
+	OpeningLine     string   `json:"opening_line,omitempty"`
+	Goals           []string `json:"goals,omitempty"`
+	ForbiddenTopics []string `json:"forbidden_topics,omitempty"`
+	Voice           string   `json:"voice,omitempty"`
+	Temperature     float64  `json:"temperature"`
+
+	// 오프라인 대화 엔진용 대화 트리 (없으면 엔진 기본 트리 사용)
+	Script *DialogueScript `json:"script,omitempty"`
+
+	// 코치 모드 힌트 (없으면 공통 힌트 사용)
+	Hints []CoachHint `json:"hints,omitempty"`
+
+	// 시나리오 출처 (builtin, admin, 또는 시나리오 팩 파일 경로)
+	Source string `json:"source"`
 }
 
-// Define types of scenarios
-var scenarios = map[string]Scenario{
+// 생략된 선택 항목에 기본값을 채움
+func (s *Scenario) ApplyDefaults() {
+	s.Name = strings.TrimSpace(s.Name)
+	if len(s.Modes) == 0 {
+		s.Modes = []string{ModeText, ModeVoice}
+	}
+	if s.Difficulty == "" {
+		s.Difficulty = DifficultyMedium
+	}
+}
+
+// 시나리오 값 검증
+func (s Scenario) Validate() error {
+	if !scenarioKeyPattern.MatchString(s.Key) {
+		return errors.New("Scenario key must match [a-z0-9_]{1,64}")
+	}
+	if s.Name == "" {
+		return errors.New("Scenario name cannot be empty")
+	}
+	for _, mode := range s.Modes {
+		if !IsValidMode(mode) {
+			return errors.New("Mode must be one of text, voice")
+		}
+	}
+	if !IsValidDifficulty(s.Difficulty) {
+		return errors.New("Difficulty must be one of easy, medium, hard")
+	}
+	if s.EstimatedMinutes < 0 {
+		return errors.New("Estimated minutes cannot be negative")
+	}
+	if s.Temperature < 0 || s.Temperature > 2 {
+		return errors.New("Temperature must be between 0 and 2")
+	}
+	if err := ValidateCoachHints(s.Hints); err != nil {
+		return err
+	}
+	if s.Script != nil {
+		if err := s.Script.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 해당 모드(text/voice)를 지원하는지 확인
+func (s Scenario) SupportsMode(mode string) bool {
+	for _, m := range s.Modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidMode(mode string) bool {
+	return mode == ModeText || mode == ModeVoice
+}
+
+func IsValidDifficulty(difficulty string) bool {
+	return difficulty == DifficultyEasy || difficulty == DifficultyMedium || difficulty == DifficultyHard
+}
+
+// Define types of scenarios, DB 최초 생성 시 기본 시나리오로 사용
+var defaultScenarios = map[string]Scenario{
 	"institution_impersonation": {
-		Name:        "Institution Impersonation",
-		Description: "A scenario where the user receives an call impersonating a trusted institution.",
+		Name:             "Institution Impersonation",
+		Description:      "A scenario where the user receives an call impersonating a trusted institution.",
+		Modes:            []string{ModeText, ModeVoice},
+		Difficulty:       DifficultyHard,
+		EstimatedMinutes: 10,
 	},
 	"loan_scam": {
-		Name:        "Loan Scam",
-		Description: "A scenario where the user is targeted with a loan scam call.",
+		Name:             "Loan Scam",
+		Description:      "A scenario where the user is targeted with a loan scam call.",
+		Modes:            []string{ModeText, ModeVoice},
+		Difficulty:       DifficultyMedium,
+		EstimatedMinutes: 8,
 	},
 	"delivery_notification": {
-		Name:        "Delivery Notification",
-		Description: "A scenario where the user receives a fake delivery notification call.",
+		Name:             "Delivery Notification",
+		Description:      "A scenario where the user receives a fake delivery notification call.",
+		Modes:            []string{ModeText, ModeVoice},
+		Difficulty:       DifficultyEasy,
+		EstimatedMinutes: 5,
 	},
 	"friends_impersonation": {
-		Name:        "Friends Impersonation",
-		Description: "A scenario where the user receives a call impersonating a friend in need.",
+		Name:             "Friends Impersonation",
+		Description:      "A scenario where the user receives a call impersonating a friend in need.",
+		Modes:            []string{ModeText, ModeVoice},
+		Difficulty:       DifficultyMedium,
+		EstimatedMinutes: 7,
 	},
 }
 
-// Getter for scenarios
+// 시나리오 조회 함수, storage 패키지가 DB 조회 함수를 등록함 (import cycle 방지)
+var scenarioLookup func(scenarioKey string) (Scenario, bool)
+
+// 기본 시나리오 목록 반환 (DB 시드용)
+func DefaultScenarios() []Scenario {
+	list := make([]Scenario, 0, len(defaultScenarios))
+	for key, scenario := range defaultScenarios {
+		scenario.Key = key
+		scenario.Enabled = true
+		scenario.Source = ScenarioSourceBuiltin
+		scenario.Temperature = DefaultTemperature
+		scenario.ApplyDefaults()
+		list = append(list, scenario)
+	}
+	return list
+}
+
+// 시나리오 저장소 등록
+func RegisterScenarioLookup(lookup func(scenarioKey string) (Scenario, bool)) {
+	scenarioLookup = lookup
+}
+
+// Getter for scenarios, 저장소가 등록되지 않은 경우 기본 시나리오에서 조회
 func GetScenario(scenarioKey string) (Scenario, bool) {
-	scenario, exists := scenarios[scenarioKey]
+	if scenarioLookup != nil {
+		return scenarioLookup(scenarioKey)
+	}
+	scenario, exists := defaultScenarios[scenarioKey]
+	if exists {
+		scenario.Key = scenarioKey
+		scenario.Enabled = true
+		scenario.Temperature = DefaultTemperature
+		scenario.ApplyDefaults()
+	}
 	return scenario, exists
 }
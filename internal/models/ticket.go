@@ -0,0 +1,11 @@
+package models
+
+// 1회용 티켓 용도, 티켓은 발급한 사용자와 용도별 리소스에 묶임
+const (
+	TicketPurposeSimulation  = "simulation"   // /ws/simulation 연결, 리소스는 시나리오 키
+	TicketPurposeRecordAudio = "record_audio" // /api/history/{id}/audio 재생, 리소스는 기록 ID
+	TicketPurposeSSOLogin    = "sso_login"    // SSO 로그인 후 토큰 교환 (/auth/oidc/token), 리소스는 IdP 2단계 인증 여부 (TicketResourceMFA 또는 빈 값)
+)
+
+// SSO 로그인 티켓의 리소스, IdP에서 2단계 인증을 거친 로그인
+const TicketResourceMFA = "mfa"
@@ -0,0 +1,21 @@
+package models
+
+// 발화자 (TranscriptTurn.Speaker)
+const (
+	SpeakerUser      = "user"      // 훈련생
+	SpeakerAssistant = "assistant" // 사기범 역할의 대화 엔진
+	SpeakerCoach     = "coach"     // 코치 모드에서 표시된 힌트
+)
+
+// 세션 대화 기록의 한 턴, 시각은 세션 시작 기준 오프셋(ms)
+type TranscriptTurn struct {
+	ID         int      `json:"id"`
+	RecordID   int      `json:"record_id"`
+	Seq        int      `json:"seq" example:"0"`
+	Speaker    string   `json:"speaker" example:"user"`
+	Text       string   `json:"text" example:"누구세요?"`
+	StartMs    int64    `json:"start_ms" example:"5200"`
+	EndMs      int64    `json:"end_ms" example:"6900"`
+	Confidence *float64 `json:"confidence,omitempty" example:"0.92"`     // 음성 모드 사용자 발화의 STT 신뢰도
+	HintID     string   `json:"hint_id,omitempty" example:"otp_request"` // 코치 힌트 턴의 힌트 ID
+}
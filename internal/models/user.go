@@ -1,15 +1,83 @@
 package models
 
+import "time"
+
+// 사용자 역할 (User.Role)
+const (
+	RoleTrainee  = "trainee"   // 훈련생, 본인 기록만 조회
+	RoleTrainer  = "trainer"   // 강사, 같은 그룹 훈련생의 기록 조회
+	RoleOrgAdmin = "org_admin" // 조직 관리자, 소속 조직의 사용자 초대, 그룹/시나리오 관리 및 조직 내 기록 조회
+	RoleAdmin    = "admin"     // 관리자, 시나리오/조직/사용자/그룹 관리 및 전체 기록 조회
+)
+
 // 회원 사용자 모델
 type User struct {
-	Username     string      `'json:"username"`
+	ID           int         `json:"id"`
+	Username     string      `json:"username"`
 	PasswordHash string      `json:"-"`
+	Role         string      `json:"role" example:"trainee"`
+	OrgID        *int        `json:"org_id,omitempty" example:"1"`   // 소속 조직 (없으면 생략)
+	GroupID      *int        `json:"group_id,omitempty" example:"3"` // 소속 그룹 (없으면 생략)
+	MFAEnabled   bool        `json:"mfa_enabled"`                    // TOTP 2단계 인증 사용 여부
 	Profile      UserProfile `json:"profile"`
+	// LLM 대화 엔진에 전달할 프로필 항목 (사용자가 /api/profile에서 선택, 지정하지 않았으면 DefaultLLMSharedFields)
+	LLMSharedFields []string `json:"llm_shared_fields" example:"name,age,occupation"`
+}
+
+// 훈련 그룹 (팀, 기수), 강사는 같은 그룹 훈련생의 기록을 조회할 수 있음
+type Group struct {
+	ID        int       `json:"id" example:"3"`
+	OrgID     *int      `json:"org_id,omitempty" example:"1"` // 그룹이 속한 조직
+	Name      string    `json:"name" example:"2025 상반기 신입사원"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func IsValidRole(role string) bool {
+	return role == RoleTrainee || role == RoleTrainer || role == RoleOrgAdmin || role == RoleAdmin
+}
+
+// 다른 사용자의 기록이나 조직을 다룰 수 있는 역할 (조직 MFA 정책 적용 대상)
+func IsElevatedRole(role string) bool {
+	return role == RoleTrainer || role == RoleOrgAdmin || role == RoleAdmin
+}
+
+// 두 사용자가 같은 조직에 속하는지 확인 (조직이 없는 사용자는 어느 조직과도 같지 않음)
+func (u User) SameOrganization(other User) bool {
+	return u.OrgID != nil && other.OrgID != nil && *u.OrgID == *other.OrgID
+}
+
+// viewer가 owner의 기록(대화 기록, 리포트, 녹음)을 조회할 수 있는지 확인
+// 본인 기록은 항상 허용, 관리자는 전체, 조직 관리자는 같은 조직 사용자, 강사는 같은 조직, 같은 그룹 훈련생만 허용
+// 조직이 없는 그룹(조직 도입 이전 그룹, 관리자가 조직 없이 만든 그룹)의 강사는 같은 그룹 훈련생만 허용
+func (viewer User) CanViewRecordsOf(owner User) bool {
+	switch {
+	case viewer.ID == owner.ID:
+		return true
+	case viewer.Role == RoleAdmin:
+		return true
+	case viewer.Role == RoleTrainer && viewer.OrgID == nil:
+		return owner.OrgID == nil && owner.Role == RoleTrainee && viewer.GroupID != nil && owner.GroupID != nil && *viewer.GroupID == *owner.GroupID
+	case !viewer.SameOrganization(owner):
+		return false
+	case viewer.Role == RoleOrgAdmin:
+		return true
+	case viewer.Role == RoleTrainer:
+		return owner.Role == RoleTrainee && viewer.GroupID != nil && owner.GroupID != nil && *viewer.GroupID == *owner.GroupID
+	}
+	return false
 }
 
-// LLM 세션용 사용자 프로필
-type UserProfile struct {
-	Name   string `json:"name"`
-	Age    int    `json:"age"`
-	Gender string `json:"gender"`
+// 기록 단위 조회 권한, 다른 사용자의 기록은 세션 당시 조직이 조회자의 조직과 같아야 함 (관리자 제외)
+// 조직을 옮긴 사용자의 이전 조직 기록은 새 조직에서 조회할 수 없음, 조직이 없는 강사는 조직 없이 진행한 기록만 조회
+func (viewer User) CanViewRecord(record Record, owner User) bool {
+	if viewer.ID == record.UserID || viewer.Role == RoleAdmin {
+		return true
+	}
+	if viewer.OrgID == nil {
+		return record.OrgID == nil && viewer.CanViewRecordsOf(owner)
+	}
+	if record.OrgID == nil || *record.OrgID != *viewer.OrgID {
+		return false
+	}
+	return viewer.CanViewRecordsOf(owner)
 }
@@ -0,0 +1,189 @@
+/**
+* Name: 			config.go
+* Description: 		OIDC(SSO) 공급자 설정 파일 로드 및 검증
+* Workflow: 		설정 파일(YAML/JSON)의 ${ENV} 참조를 환경 변수로 치환한 뒤 공급자별 설정 검증, 그룹-역할 매핑으로 로그인 사용자의 역할과 조직 결정
+ */
+
+package oidc
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IdP 그룹이 어떤 매핑에도 해당하지 않고 기본 역할도 없는 경우 (로그인 거부)
+var ErrNoRoleMapping = errors.New("no role mapping for user groups")
+
+var providerIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// 설정 파일 최상위 구조
+type Config struct {
+	Providers []ProviderConfig `yaml:"providers" json:"providers"`
+}
+
+// 공급자(고객사 IdP)별 설정
+type ProviderConfig struct {
+	ID                string         `yaml:"id" json:"id"`         // URL 경로에 사용 (/auth/oidc/{id}/login)
+	Name              string         `yaml:"name" json:"name"`     // 로그인 화면 표시 이름
+	Issuer            string         `yaml:"issuer" json:"issuer"` // {issuer}/.well-known/openid-configuration으로 엔드포인트 조회
+	ClientID          string         `yaml:"client_id" json:"client_id"`
+	ClientSecret      string         `yaml:"client_secret" json:"client_secret"`             // 공개 클라이언트(PKCE만 사용)는 생략
+	RedirectURL       string         `yaml:"redirect_url" json:"redirect_url"`               // IdP에 등록한 콜백 URL (/auth/oidc/{id}/callback)
+	PostLoginRedirect string         `yaml:"post_login_redirect" json:"post_login_redirect"` // 로그인 후 이동할 프론트엔드 URL, 생략하면 콜백이 토큰을 JSON으로 응답
+	Scopes            []string       `yaml:"scopes" json:"scopes"`                           // 기본값: openid, profile, email
+	UsernameClaim     string         `yaml:"username_claim" json:"username_claim"`           // 기본값: preferred_username
+	GroupsClaim       string         `yaml:"groups_claim" json:"groups_claim"`               // 기본값: groups
+	Organization      string         `yaml:"organization" json:"organization"`               // 매핑에 조직이 없을 때 소속 조직 이름
+	DefaultRole       string         `yaml:"default_role" json:"default_role"`               // 매핑되는 그룹이 없을 때 역할, 생략하면 로그인 거부
+	GroupMappings     []GroupMapping `yaml:"group_mappings" json:"group_mappings"`
+	LinkExistingUsers bool           `yaml:"link_existing_users" json:"link_existing_users"` // 같은 사용자명의 기존 계정과 연결 (IdP의 사용자명을 신뢰하는 경우만)
+	TrustMFA          bool           `yaml:"trust_mfa" json:"trust_mfa"`                     // IdP가 항상 2단계 인증을 수행하는 경우 (amr 클레임 없이 2단계 인증 로그인으로 처리)
+}
+
+// IdP 그룹과 역할, 조직 매핑
+type GroupMapping struct {
+	Group        string `yaml:"group" json:"group"`
+	Role         string `yaml:"role" json:"role"`
+	Organization string `yaml:"organization" json:"organization"` // 생략하면 공급자의 organization
+}
+
+// 설정 파일 로드 및 검증, 파일이 없으면 os.ErrNotExist
+// client_secret 등의 값은 ${NAME} 형식으로 환경 변수를 참조할 수 있음
+func Load(path string) ([]*Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	expanded := []byte(os.ExpandEnv(string(data)))
+
+	var config Config
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(expanded, &config)
+	} else {
+		err = yaml.UnmarshalStrict(expanded, &config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse error: %v", err)
+	}
+
+	providers := make([]*Provider, 0, len(config.Providers))
+	seen := map[string]bool{}
+	for _, pc := range config.Providers {
+		pc.applyDefaults()
+		if err := pc.Validate(); err != nil {
+			return nil, fmt.Errorf("provider %q: %v", pc.ID, err)
+		}
+		if seen[pc.ID] {
+			return nil, fmt.Errorf("provider %q: duplicate id", pc.ID)
+		}
+		seen[pc.ID] = true
+		providers = append(providers, NewProvider(pc))
+	}
+	return providers, nil
+}
+
+func (pc *ProviderConfig) applyDefaults() {
+	pc.Issuer = strings.TrimSuffix(pc.Issuer, "/")
+	if pc.Name == "" {
+		pc.Name = pc.ID
+	}
+	if len(pc.Scopes) == 0 {
+		pc.Scopes = []string{"openid", "profile", "email"}
+	} else if !slices.Contains(pc.Scopes, "openid") {
+		pc.Scopes = append([]string{"openid"}, pc.Scopes...)
+	}
+	if pc.UsernameClaim == "" {
+		pc.UsernameClaim = "preferred_username"
+	}
+	if pc.GroupsClaim == "" {
+		pc.GroupsClaim = "groups"
+	}
+}
+
+// 설정 검증 (필수 값, URL 형식, 역할 이름)
+func (pc ProviderConfig) Validate() error {
+	if !providerIDPattern.MatchString(pc.ID) {
+		return errors.New("id must contain only lowercase letters, digits, '-' and '_'")
+	}
+	if pc.ClientID == "" {
+		return errors.New("client_id is required")
+	}
+	if err := validateURL(pc.Issuer); err != nil {
+		return fmt.Errorf("issuer: %v", err)
+	}
+	if err := validateURL(pc.RedirectURL); err != nil {
+		return fmt.Errorf("redirect_url: %v", err)
+	}
+	if pc.PostLoginRedirect != "" {
+		if err := validateURL(pc.PostLoginRedirect); err != nil {
+			return fmt.Errorf("post_login_redirect: %v", err)
+		}
+	}
+	if pc.DefaultRole != "" && !models.IsValidRole(pc.DefaultRole) {
+		return fmt.Errorf("invalid default_role %q", pc.DefaultRole)
+	}
+	for _, m := range pc.GroupMappings {
+		if m.Group == "" {
+			return errors.New("group_mappings: group is required")
+		}
+		if !models.IsValidRole(m.Role) {
+			return fmt.Errorf("group_mappings: invalid role %q for group %q", m.Role, m.Group)
+		}
+	}
+	return nil
+}
+
+// https URL만 허용 (로컬 개발용 localhost는 http 허용)
+func validateURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid URL %q", value)
+	}
+	if u.Scheme == "https" {
+		return nil
+	}
+	if u.Scheme == "http" && (u.Hostname() == "localhost" || u.Hostname() == "127.0.0.1") {
+		return nil
+	}
+	return fmt.Errorf("URL must use https: %q", value)
+}
+
+// IdP 그룹으로 역할과 조직 이름 결정
+// 매핑된 역할이 여러 개이면 권한이 가장 높은 역할, 조직은 그 역할을 준 매핑의 조직 (없으면 공급자의 organization)
+func (pc ProviderConfig) MapGroups(groups []string) (role string, organization string, err error) {
+	best := -1
+	for _, m := range pc.GroupMappings {
+		if !slices.Contains(groups, m.Group) {
+			continue
+		}
+		if rank := roleRank(m.Role); rank > best {
+			best = rank
+			role = m.Role
+			organization = m.Organization
+		}
+	}
+	if best < 0 {
+		if pc.DefaultRole == "" {
+			return "", "", ErrNoRoleMapping
+		}
+		role = pc.DefaultRole
+	}
+	if organization == "" {
+		organization = pc.Organization
+	}
+	return role, organization, nil
+}
+
+func roleRank(role string) int {
+	return slices.Index([]string{models.RoleTrainee, models.RoleTrainer, models.RoleOrgAdmin, models.RoleAdmin}, role)
+}
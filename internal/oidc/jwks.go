@@ -0,0 +1,104 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// 공급자 JWKS 응답 (RFC 7517)
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ID 토큰 서명 검증 키
+type verificationKey struct {
+	kty    string
+	public any
+}
+
+// 키 종류에 맞는 서명 방법인지 확인 (RSA: RS*/PS*, EC: ES*, OKP: EdDSA)
+func (k verificationKey) allows(method jwt.SigningMethod) bool {
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		return k.kty == "RSA"
+	case *jwt.SigningMethodECDSA:
+		return k.kty == "EC"
+	case *jwt.SigningMethodEd25519:
+		return k.kty == "OKP"
+	}
+	return false
+}
+
+// 서명용 키만 kid별로 변환, 지원하지 않거나 잘못된 키는 제외
+func (set jsonWebKeySet) verificationKeys() map[string]verificationKey {
+	keys := map[string]verificationKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if public := jwk.publicKey(); public != nil {
+			keys[jwk.Kid] = verificationKey{kty: jwk.Kty, public: public}
+		}
+	}
+	return keys
+}
+
+func (jwk jsonWebKey) publicKey() any {
+	switch jwk.Kty {
+	case "RSA":
+		n, e := decodeBigInt(jwk.N), decodeBigInt(jwk.E)
+		if n == nil || e == nil || !e.IsInt64() {
+			return nil
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil
+		}
+		x, y := decodeBigInt(jwk.X), decodeBigInt(jwk.Y)
+		if x == nil || y == nil || !curve.IsOnCurve(x, y) {
+			return nil
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if jwk.Crv != "Ed25519" || err != nil || len(x) != ed25519.PublicKeySize {
+			return nil
+		}
+		return ed25519.PublicKey(x)
+	}
+	return nil
+}
+
+func decodeBigInt(value string) *big.Int {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(data)
+}
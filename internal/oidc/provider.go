@@ -0,0 +1,260 @@
+/**
+* Name: 			provider.go
+* Description: 		OIDC 인증 코드 흐름(PKCE) 클라이언트
+* Workflow: 		discovery 문서로 엔드포인트 조회 → 인증 URL 생성(state, nonce, S256 code_challenge) → 인증 코드를 code_verifier와 함께 토큰으로 교환 → ID 토큰 검증(공급자 JWKS 서명, iss, aud, exp, nonce) → 사용자명, 그룹 추출 후 역할과 조직 매핑
+ */
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+)
+
+// JWKS에 없는 kid의 토큰을 받았을 때 키를 다시 조회하는 최소 간격 (키 교체 대응, 잘못된 토큰으로 인한 반복 조회 방지)
+const jwksRefreshInterval = time.Minute
+
+// ID 토큰 검증 실패 (서명, 발급자, 대상, 만료, nonce)
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+// discovery 문서 중 사용하는 항목
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// 설정된 OIDC 공급자, discovery 문서와 서명 키는 처음 사용할 때 조회하여 캐시
+type Provider struct {
+	config ProviderConfig
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *providerMetadata
+	keys          map[string]verificationKey
+	keysFetchedAt time.Time
+}
+
+// IdP에서 확인된 사용자 정보
+type Identity struct {
+	Subject      string // IdP의 사용자 고유 ID (sub)
+	Username     string
+	Name         string
+	Groups       []string
+	Role         string // 그룹 매핑으로 결정된 역할
+	Organization string // 그룹 매핑으로 결정된 조직 이름, 없으면 빈 값
+	MFA          bool   // IdP에서 2단계 인증을 거쳤는지 (amr에 mfa 포함 또는 trust_mfa)
+}
+
+func NewProvider(config ProviderConfig) *Provider {
+	return &Provider{config: config, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (p *Provider) Config() ProviderConfig {
+	return p.config
+}
+
+// IdP 인증 페이지 URL, state와 nonce는 콜백에서 확인하고 verifier는 토큰 교환 시 전송
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	return oauthConfig.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+// 인증 코드를 토큰으로 교환하고 ID 토큰을 검증하여 사용자 정보 반환
+// 그룹이 어떤 매핑에도 해당하지 않고 기본 역할이 없으면 ErrNoRoleMapping
+func (p *Provider) Authenticate(ctx context.Context, code, verifier, nonce string) (Identity, error) {
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+	token, err := oauthConfig.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Identity{}, fmt.Errorf("token exchange failed: %v", err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return Identity{}, fmt.Errorf("%w: token response has no id_token", ErrInvalidIDToken)
+	}
+	claims, err := p.verifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	identity := Identity{
+		Subject:  stringClaim(claims, "sub"),
+		Username: stringClaim(claims, p.config.UsernameClaim),
+		Name:     stringClaim(claims, "name"),
+		Groups:   stringListClaim(claims, p.config.GroupsClaim),
+		MFA:      p.config.TrustMFA || slices.Contains(stringListClaim(claims, "amr"), "mfa"),
+	}
+	if identity.Subject == "" {
+		return identity, fmt.Errorf("%w: missing sub claim", ErrInvalidIDToken)
+	}
+	if identity.Username == "" {
+		return identity, fmt.Errorf("%w: missing %s claim", ErrInvalidIDToken, p.config.UsernameClaim)
+	}
+	identity.Role, identity.Organization, err = p.config.MapGroups(identity.Groups)
+	return identity, err
+}
+
+func (p *Provider) oauthConfig(ctx context.Context) (*oauth2.Config, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       p.config.Scopes,
+		Endpoint:     oauth2.Endpoint{AuthURL: metadata.AuthorizationEndpoint, TokenURL: metadata.TokenEndpoint},
+	}, nil
+}
+
+// discovery 문서 조회 (성공하면 캐시, 실패하면 다음 요청에서 다시 조회)
+func (p *Provider) discover(ctx context.Context) (*providerMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var metadata providerMetadata
+	if err := p.getJSON(ctx, p.config.Issuer+"/.well-known/openid-configuration", &metadata); err != nil {
+		return nil, fmt.Errorf("discovery failed: %v", err)
+	}
+	// 다른 발급자의 문서로 바꿔치기되지 않도록 issuer 일치 확인 (OpenID Connect Discovery 4.3)
+	if strings.TrimSuffix(metadata.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("discovery failed: issuer mismatch %q", metadata.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("discovery failed: missing endpoints")
+	}
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// ID 토큰 서명 및 클레임 검증 (OpenID Connect Core 3.1.3.7)
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.verificationKey(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		// 알고리즘 혼동 공격 방지를 위해 키 종류에 맞는 서명 방법만 허용
+		if !key.allows(token.Method) {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return key.public, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if !claims.VerifyIssuer(p.config.Issuer, true) {
+		return nil, fmt.Errorf("%w: issuer mismatch", ErrInvalidIDToken)
+	}
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, fmt.Errorf("%w: audience mismatch", ErrInvalidIDToken)
+	}
+	if _, hasExp := claims["exp"]; !hasExp {
+		return nil, fmt.Errorf("%w: missing exp claim", ErrInvalidIDToken)
+	}
+	if stringClaim(claims, "nonce") != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// kid에 해당하는 공급자 공개 키, 없으면 JWKS를 다시 조회 (최소 간격 jwksRefreshInterval)
+func (p *Provider) verificationKey(ctx context.Context, kid string) (verificationKey, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return verificationKey{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < jwksRefreshInterval {
+		return verificationKey{}, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	var set jsonWebKeySet
+	if err := p.getJSON(ctx, metadata.JWKSURI, &set); err != nil {
+		return verificationKey{}, fmt.Errorf("failed to fetch JWKS: %v", err)
+	}
+	p.keys = set.verificationKeys()
+	p.keysFetchedAt = time.Now()
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return verificationKey{}, fmt.Errorf("unknown key id %q", kid)
+}
+
+// kid가 없는 토큰은 공급자의 키가 하나일 때만 허용
+func (p *Provider) lookupKey(kid string) (verificationKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(target)
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// 문자열 배열 클레임 (단일 문자열도 허용)
+func stringListClaim(claims jwt.MapClaims, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+/**
+* Name: 			digits.go
+* Description: 		발화 텍스트에서 숫자열 추출 (아라비아 숫자 및 한글로 읽은 숫자)
+* Workflow: 		공백 단위 토큰 분리, 조사 제거 후 숫자 토큰 판별, 인접 토큰을 하나의 숫자열(묶음 목록)로 병합
+ */
+
+package pii
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// 한글로 읽은 숫자 (STT 결과에 "공일공 일이삼사"처럼 나타남)
+var spokenDigits = map[rune]byte{
+	'공': '0', '영': '0', '빵': '0',
+	'일': '1', '이': '2', '삼': '3', '사': '4', '오': '5',
+	'육': '6', '륙': '6', '칠': '7', '팔': '8', '구': '9',
+}
+
+// 한글 숫자로만 이루어진 일상 단어, 숫자열 전체가 이 단어들뿐이면 숫자로 보지 않음 ("오이 구이", "삼삼오오")
+var spokenWords = map[string]bool{
+	"사이": true, "이사": true, "오이": true, "구이": true, "오일": true, "구구": true,
+	"사이사이": true, "삼삼오오": true,
+}
+
+// 숫자 토큰 뒤에 붙는 조사/어미, 긴 것부터 검사
+var tokenSuffixes = []string{
+	"입니다", "이에요", "이예요", "이구요", "이고요", "인데요", "번이요", "번이고",
+	"예요", "에요", "이요", "이고", "하고", "이랑", "번은", "번",
+	"요", "은", "는", "을", "를", "에", "고", "랑", "과", "와",
+}
+
+// 추출된 숫자열, 공백이나 하이픈으로 구분된 묶음 단위로 보관
+type digitRun struct {
+	start, end int      // 원문 바이트 오프셋 [start, end)
+	groups     []string // 묶음별 숫자
+	spoken     bool     // 한글로 읽은 숫자 포함 여부
+}
+
+// 원문 구간을 유지하면서 묶음 일부만 잘라낸 숫자열
+type groupSpan struct {
+	start, end int
+}
+
+// 토큰 내 숫자 조각
+type digitPiece struct {
+	start, end  int
+	groups      []string
+	groupSpans  []groupSpan
+	spoken      bool
+	word        bool // spokenWords의 일상 단어
+	openLeft    bool // 토큰 시작에서 시작 (앞 토큰과 병합 가능)
+	openRight   bool // 토큰 끝에서 끝남 (뒤 토큰과 병합 가능)
+	tokenNumber int
+}
+
+// 텍스트의 숫자열 목록, 각 숫자열의 묶음 위치도 함께 반환
+func extractRuns(text string) ([]digitRun, [][]groupSpan) {
+	var pieces []digitPiece
+	for index, token := range splitTokens(text) {
+		pieces = append(pieces, tokenPieces(text, token, index)...)
+	}
+
+	var runs []digitRun
+	var spans [][]groupSpan
+	for i := 0; i < len(pieces); i++ {
+		piece := pieces[i]
+		run := digitRun{start: piece.start, end: piece.end, groups: append([]string(nil), piece.groups...), spoken: piece.spoken}
+		runSpans := append([]groupSpan(nil), piece.groupSpans...)
+		onlyWords := piece.word
+		// 공백으로만 구분된 인접 토큰의 숫자 조각은 하나의 숫자열로 병합
+		for i+1 < len(pieces) {
+			next := pieces[i+1]
+			if !pieces[i].openRight || !next.openLeft || next.tokenNumber != pieces[i].tokenNumber+1 {
+				break
+			}
+			run.end = next.end
+			run.groups = append(run.groups, next.groups...)
+			run.spoken = run.spoken || next.spoken
+			runSpans = append(runSpans, next.groupSpans...)
+			onlyWords = onlyWords && next.word
+			i++
+		}
+		if onlyWords {
+			continue
+		}
+		runs = append(runs, run)
+		spans = append(spans, runSpans)
+	}
+	return runs, spans
+}
+
+type token struct {
+	start, end int
+}
+
+func splitTokens(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		if r == ' ' || r == '\t' || r == '\n' || r == ',' {
+			if start >= 0 {
+				tokens = append(tokens, token{start, i})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{start, len(text)})
+	}
+	return tokens
+}
+
+// 토큰에서 숫자 조각 추출
+// 조사를 제외한 토큰 전체가 숫자(한글 숫자 포함)이면 하나의 조각, 아니면 아라비아 숫자 부분만 추출
+func tokenPieces(text string, tok token, index int) []digitPiece {
+	word := text[tok.start:tok.end]
+	core := strings.TrimRight(word, ".?!")
+	for _, suffix := range tokenSuffixes {
+		trimmed := strings.TrimSuffix(core, suffix)
+		// 조사만 남는 한 글자("이에요"의 "이")는 숫자로 보지 않음
+		if trimmed != core && trimmed != "" && isDigitWord(trimmed) && (utf8.RuneCountInString(trimmed) > 1 || containsASCIIDigit(trimmed)) {
+			core = trimmed
+			break
+		}
+	}
+	if core != "" && isDigitWord(core) && containsDigit(core) {
+		piece := digitPiece{start: tok.start, word: spokenWords[core], openLeft: true, openRight: true, tokenNumber: index}
+		piece.collect(text, tok.start, tok.start+len(core), true)
+		// 조사가 붙어 있으면 다음 토큰과 병합하지 않음 ("공일공이고 주민번호는...")
+		piece.openRight = len(core) == len(strings.TrimRight(word, ".?!"))
+		if piece.end-piece.start > 0 {
+			return []digitPiece{piece}
+		}
+		return nil
+	}
+
+	// 단어 중간의 아라비아 숫자 ("번호는482913입니다")
+	var pieces []digitPiece
+	i := tok.start
+	for i < tok.end {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !isASCIIDigit(r) {
+			i += size
+			continue
+		}
+		j := i
+		for j < tok.end {
+			r2, size2 := utf8.DecodeRuneInString(text[j:])
+			if isASCIIDigit(r2) || ((r2 == '-' || r2 == '.') && j+size2 < tok.end && isASCIIDigit(rune(text[j+size2]))) {
+				j += size2
+				continue
+			}
+			break
+		}
+		piece := digitPiece{start: i, openLeft: i == tok.start, tokenNumber: index}
+		piece.collect(text, i, j, false)
+		piece.openRight = j == tok.end
+		pieces = append(pieces, piece)
+		i = j
+	}
+	return pieces
+}
+
+// [from, to) 구간의 숫자를 하이픈/마침표 기준 묶음으로 수집
+func (p *digitPiece) collect(text string, from, to int, allowSpoken bool) {
+	var group strings.Builder
+	groupStart := -1
+	flush := func(end int) {
+		if group.Len() > 0 {
+			p.groups = append(p.groups, group.String())
+			p.groupSpans = append(p.groupSpans, groupSpan{groupStart, end})
+			group.Reset()
+		}
+		groupStart = -1
+	}
+	p.start, p.end = from, from
+	for i, r := range text[from:to] {
+		offset := from + i
+		digit, ok := byte(0), false
+		switch {
+		case isASCIIDigit(r):
+			digit, ok = byte(r), true
+		case allowSpoken:
+			digit, ok = spokenDigits[r]
+			if ok {
+				p.spoken = true
+			}
+		}
+		if ok {
+			if groupStart < 0 {
+				groupStart = offset
+			}
+			group.WriteByte(digit)
+			p.end = offset + utf8.RuneLen(r)
+			continue
+		}
+		flush(offset)
+	}
+	flush(p.end)
+}
+
+func isDigitWord(word string) bool {
+	for _, r := range word {
+		if isASCIIDigit(r) || r == '-' || r == '.' {
+			continue
+		}
+		if _, ok := spokenDigits[r]; ok {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func containsDigit(word string) bool {
+	for _, r := range word {
+		if isASCIIDigit(r) {
+			return true
+		}
+		if _, ok := spokenDigits[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsASCIIDigit(word string) bool {
+	return strings.IndexFunc(word, isASCIIDigit) >= 0
+}
+
+// 한글로 읽은 숫자 포함 여부
+func containsSpoken(word string) bool {
+	for _, r := range word {
+		if _, ok := spokenDigits[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
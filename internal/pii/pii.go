@@ -0,0 +1,194 @@
+/**
+* Name: 			pii.go
+* Description: 		한국 개인식별정보(주민등록번호, 전화번호, 계좌번호, 카드번호) 탐지 및 마스킹
+* Workflow: 		숫자열 추출(한글 숫자 포함), 묶음 구간별 형식/체크섬 검증, 탐지 결과로 평가 근거 마스킹 및 로그 비식별화
+ */
+
+package pii
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// 탐지 종류 (Match.Kind)
+const (
+	KindResidentNumber = "resident_registration_number"
+	KindPhoneNumber    = "phone_number"
+	KindAccountNumber  = "account_number"
+	KindCardNumber     = "card_number"
+	KindNumber         = "number" // 형식을 특정할 수 없는 4자리 이상 숫자 (인증번호 등은 문맥으로 판단)
+)
+
+// 분류되지 않은 숫자열로 보고할 최소 자릿수
+const minNumberDigits = 4
+
+// 탐지 결과
+type Match struct {
+	Kind      string
+	Start     int // 원문 바이트 오프셋 [Start, End)
+	End       int
+	Digits    string   // 정규화된 숫자 ("공일공" -> "010")
+	Validated bool     // 체크섬(주민등록번호, Luhn) 검증 통과
+	Banks     []string // 계좌번호 형식이 일치하는 은행 목록
+	Spoken    bool     // 한글로 읽은 숫자 포함
+}
+
+// 텍스트에서 개인식별정보와 숫자열 탐지, 원문 순서로 반환
+func Find(text string) []Match {
+	runs, runSpans := extractRuns(text)
+
+	var matches []Match
+	for i, run := range runs {
+		matches = append(matches, classifyRun(text, run, runSpans[i])...)
+	}
+	sort.Slice(matches, func(a, b int) bool { return matches[a].Start < matches[b].Start })
+	return matches
+}
+
+// 특정 종류만 탐지
+func FindKind(text string, kind string) []Match {
+	var matches []Match
+	for _, m := range Find(text) {
+		if m.Kind == kind {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// 로그 기록용 비식별화, 탐지된 모든 숫자열을 *로 치환
+func Redact(text string) string {
+	matches := Find(text)
+	spans := make([][2]int, 0, len(matches))
+	for _, m := range matches {
+		spans = append(spans, [2]int{m.Start, m.End})
+	}
+	return MaskSpans(text, spans, 0)
+}
+
+// 숫자열의 묶음을 앞에서부터 가장 긴 구간 우선으로 분류
+// 분류되지 않은 연속 묶음은 합쳐서 KindNumber로 보고
+func classifyRun(text string, run digitRun, spans []groupSpan) []Match {
+	n := len(run.groups)
+	classified := make([]bool, n)
+	var matches []Match
+
+	for size := n; size >= 1; size-- {
+		for from := 0; from+size <= n; from++ {
+			if anyClassified(classified[from : from+size]) {
+				continue
+			}
+			match, ok := classifyGroups(run.groups[from : from+size])
+			if !ok {
+				continue
+			}
+			match.Start, match.End = spans[from].start, spans[from+size-1].end
+			match.Spoken = run.spoken && containsSpoken(text[match.Start:match.End])
+			matches = append(matches, match)
+			for k := from; k < from+size; k++ {
+				classified[k] = true
+			}
+		}
+	}
+
+	// 남은 묶음
+	for from := 0; from < n; {
+		if classified[from] {
+			from++
+			continue
+		}
+		to := from
+		digits := ""
+		for to < n && !classified[to] {
+			digits += run.groups[to]
+			to++
+		}
+		if len(digits) >= minNumberDigits {
+			matches = append(matches, Match{
+				Kind:   KindNumber,
+				Start:  spans[from].start,
+				End:    spans[to-1].end,
+				Digits: digits,
+				Spoken: run.spoken && containsSpoken(text[spans[from].start:spans[to-1].end]),
+			})
+		}
+		from = to
+	}
+	return matches
+}
+
+func anyClassified(flags []bool) bool {
+	for _, f := range flags {
+		if f {
+			return true
+		}
+	}
+	return false
+}
+
+// 묶음 목록이 하나의 식별정보 형식에 해당하는지 판별
+func classifyGroups(groups []string) (Match, bool) {
+	digits := ""
+	lengths := make([]int, len(groups))
+	for i, g := range groups {
+		digits += g
+		lengths[i] = len(g)
+	}
+
+	if isResidentNumber(digits, lengths) {
+		return Match{Kind: KindResidentNumber, Digits: digits, Validated: validResidentChecksum(digits)}, true
+	}
+	if isCardNumber(digits, lengths) {
+		return Match{Kind: KindCardNumber, Digits: digits, Validated: true}, true
+	}
+	if isPhoneNumber(digits, lengths) {
+		return Match{Kind: KindPhoneNumber, Digits: digits}, true
+	}
+	if banks := accountBanks(lengths); len(banks) > 0 {
+		return Match{Kind: KindAccountNumber, Digits: digits, Banks: banks}, true
+	}
+	return Match{}, false
+}
+
+// 일치 구간의 앞뒤 keep 글자만 남기고 *로 치환 (공백, 하이픈은 유지)
+// keep이 0이면 전체 치환, 짧은 값(4자 이하)은 keep과 관계없이 전체 치환
+func MaskSpans(text string, spans [][2]int, keep int) string {
+	sorted := append([][2]int(nil), spans...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a][0] < sorted[b][0] })
+
+	out := make([]byte, 0, len(text))
+	last := 0
+	for _, span := range sorted {
+		start, end := span[0], span[1]
+		if start < last || end > len(text) || start >= end {
+			continue
+		}
+		out = append(out, text[last:start]...)
+		out = append(out, maskValue(text[start:end], keep)...)
+		last = end
+	}
+	out = append(out, text[last:]...)
+	return string(out)
+}
+
+func maskValue(value string, keep int) string {
+	total := utf8.RuneCountInString(value)
+	if total <= 4 {
+		keep = 0
+	}
+	out := make([]rune, 0, total)
+	index := 0
+	for _, r := range value {
+		switch {
+		case r == ' ' || r == '-':
+			out = append(out, r)
+		case index < keep || index >= total-keep:
+			out = append(out, r)
+		default:
+			out = append(out, '*')
+		}
+		index++
+	}
+	return string(out)
+}
@@ -0,0 +1,143 @@
+package pii
+
+import (
+	"slices"
+	"testing"
+)
+
+// STT 결과 형태의 발화에서 탐지한 결과 (원문 구간, 종류, 정규화된 숫자)
+type found struct {
+	kind, text, digits string
+}
+
+func findAll(text string) []found {
+	var result []found
+	for _, m := range Find(text) {
+		result = append(result, found{m.Kind, text[m.Start:m.End], m.Digits})
+	}
+	return result
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []found
+	}{
+		// 주민등록번호
+		{"resident number with valid checksum", "제 주민번호는 900101-1234568 입니다",
+			[]found{{KindResidentNumber, "900101-1234568", "9001011234568"}}},
+		{"resident number spoken in 6-7 groups without checksum", "주민번호 900101 1234567이요",
+			[]found{{KindResidentNumber, "900101 1234567", "9001011234567"}}},
+		{"unseparated 13 digits with invalid checksum", "9001011234567",
+			[]found{{KindNumber, "9001011234567", "9001011234567"}}},
+		{"resident number with invalid birth date", "901301-1234568",
+			[]found{{KindNumber, "901301-1234568", "9013011234568"}}},
+		{"spoken resident number", "구공공일공일 일이삼사오육팔",
+			[]found{{KindResidentNumber, "구공공일공일 일이삼사오육팔", "9001011234568"}}},
+
+		// 전화번호
+		{"mobile with hyphens", "제 번호는 010-1234-5678이에요",
+			[]found{{KindPhoneNumber, "010-1234-5678", "01012345678"}}},
+		{"spoken mobile", "공일공 일이삼사 오육칠팔입니다",
+			[]found{{KindPhoneNumber, "공일공 일이삼사 오육칠팔", "01012345678"}}},
+		{"mobile spoken with 영 and 빵", "영일영 빵빵일이 삼사오육",
+			[]found{{KindPhoneNumber, "영일영 빵빵일이 삼사오육", "01000123456"}}},
+		{"seoul landline", "02-123-4567로 전화 주세요",
+			[]found{{KindPhoneNumber, "02-123-4567", "021234567"}}},
+		{"regional landline", "031 987 6543",
+			[]found{{KindPhoneNumber, "031 987 6543", "0319876543"}}},
+		{"representative number", "1588-1234로 연락드릴게요",
+			[]found{{KindPhoneNumber, "1588-1234", "15881234"}}},
+
+		// 계좌번호
+		{"kookmin account", "국민은행 123456-12-123456",
+			[]found{{KindAccountNumber, "123456-12-123456", "12345612123456"}}},
+		{"shinhan account", "신한 110-123-456789요",
+			[]found{{KindAccountNumber, "110-123-456789", "110123456789"}}},
+		{"woori account", "우리은행 1002-123-456789",
+			[]found{{KindAccountNumber, "1002-123-456789", "1002123456789"}}},
+		{"kakaobank account", "카카오뱅크 3333-12-1234567",
+			[]found{{KindAccountNumber, "3333-12-1234567", "3333121234567"}}},
+		{"nonghyup account", "농협 302-1234-5678-91",
+			[]found{{KindAccountNumber, "302-1234-5678-91", "3021234567891"}}},
+
+		// 카드번호
+		{"card number in groups", "카드번호 4111 1111 1111 1111",
+			[]found{{KindCardNumber, "4111 1111 1111 1111", "4111111111111111"}}},
+		{"amex card number", "3782 822463 10005",
+			[]found{{KindCardNumber, "3782 822463 10005", "378282246310005"}}},
+		{"card number failing luhn", "4111-1111-1111-1112",
+			[]found{{KindAccountNumber, "4111-1111-1111", "411111111111"}, {KindNumber, "1112", "1112"}}},
+
+		// 분류되지 않는 숫자
+		{"otp inside a word", "인증번호는482913입니다",
+			[]found{{KindNumber, "482913", "482913"}}},
+		{"spoken otp", "일 이 삼 사 오 육",
+			[]found{{KindNumber, "일 이 삼 사 오 육", "123456"}}},
+
+		// 오탐하지 않아야 하는 발화
+		{"everyday words made of digit syllables", "사이사이에 삼삼오오 모여서 오이 구이 먹었어요", nil},
+		{"short spoken numbers", "오늘 사일 정도 걸려요, 일이 있어서요", nil},
+		{"sino-korean amount", "오백만 원 보냈어요", nil},
+		{"date", "3월 15일에 만나요", nil},
+		{"public institution", "공공기관이라면서요", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findAll(tt.text)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Find(%q)\n got  %v\n want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindValidatedAndBanks(t *testing.T) {
+	tests := []struct {
+		text      string
+		validated bool
+		banks     []string
+	}{
+		{"900101-1234568", true, nil},
+		{"900101 1234567", false, nil},
+		{"4111 1111 1111 1111", true, nil},
+		{"110-123-456789", false, []string{"신한은행", "케이뱅크"}},
+		{"123456-12-123456", false, []string{"KB국민은행", "NH농협은행", "우체국"}},
+	}
+	for _, tt := range tests {
+		matches := Find(tt.text)
+		if len(matches) != 1 {
+			t.Errorf("Find(%q) returned %d matches, want 1", tt.text, len(matches))
+			continue
+		}
+		if matches[0].Validated != tt.validated || !slices.Equal(matches[0].Banks, tt.banks) {
+			t.Errorf("Find(%q) = validated %v banks %v, want %v %v", tt.text, matches[0].Validated, matches[0].Banks, tt.validated, tt.banks)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		text, want string
+	}{
+		{"제 번호는 010-1234-5678이에요", "제 번호는 ***-****-****이에요"},
+		{"공일공 일이삼사 오육칠팔입니다", "*** **** ****입니다"},
+		{"인증번호는 482913입니다", "인증번호는 ******입니다"},
+		{"네 알겠습니다", "네 알겠습니다"},
+	}
+	for _, tt := range tests {
+		if got := Redact(tt.text); got != tt.want {
+			t.Errorf("Redact(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMaskSpansKeepsEdges(t *testing.T) {
+	text := "카드 4111 1111 1111 1111"
+	match := Find(text)[0]
+	got := MaskSpans(text, [][2]int{{match.Start, match.End}}, 4)
+	if want := "카드 4111 **** **** 1111"; got != want {
+		t.Errorf("MaskSpans = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+/**
+* Name: 			validate.go
+* Description: 		식별정보 형식 및 체크섬 검증
+* Workflow: 		주민등록번호(생년월일, 성별 코드, 체크섬), 카드번호(Luhn), 전화번호(국번), 은행별 계좌번호 묶음 형식 검사
+ */
+
+package pii
+
+import (
+	"slices"
+	"strings"
+)
+
+// 주민등록번호: 앞 6자리 생년월일 + 성별 코드(1~8) + 6자리
+// 2020년 10월 이후 발급 번호는 체크섬이 없으므로 6-7 묶음으로 말한 경우 체크섬 없이도 인정
+func isResidentNumber(digits string, lengths []int) bool {
+	if len(digits) != 13 || !validBirthDate(digits[:6]) || digits[6] < '1' || digits[6] > '8' {
+		return false
+	}
+	if slices.Equal(lengths, []int{6, 7}) {
+		return true
+	}
+	return validResidentChecksum(digits)
+}
+
+func validBirthDate(yymmdd string) bool {
+	month := atoi(yymmdd[2:4])
+	day := atoi(yymmdd[4:6])
+	return month >= 1 && month <= 12 && day >= 1 && day <= 31
+}
+
+// 주민등록번호 체크섬: 가중치 2~9, 2~5 / (11 - 합 % 11) % 10
+func validResidentChecksum(digits string) bool {
+	weights := []int{2, 3, 4, 5, 6, 7, 8, 9, 2, 3, 4, 5}
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	return (11-sum%11)%10 == int(digits[12]-'0')
+}
+
+// 카드번호: 13~19자리, Luhn 검증, 4자리 묶음 또는 AMEX(4-6-5) 또는 붙여 말한 경우
+func isCardNumber(digits string, lengths []int) bool {
+	if len(digits) < 13 || len(digits) > 19 || !validLuhn(digits) {
+		return false
+	}
+	if len(lengths) == 1 || slices.Equal(lengths, []int{4, 6, 5}) || slices.Equal(lengths, []int{4, 6, 4}) {
+		return true
+	}
+	for _, l := range lengths {
+		if l != 4 && l != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+func validLuhn(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// 지역번호 (02 제외)
+var areaCodes = []string{
+	"031", "032", "033", "041", "042", "043", "044", "051", "052", "053", "054", "055", "061", "062", "063", "064",
+}
+
+// 전화번호: 휴대전화(01X), 서울(02), 지역번호, 인터넷전화(070), 대표번호(15XX/16XX/18XX)
+func isPhoneNumber(digits string, lengths []int) bool {
+	n := len(digits)
+	switch {
+	case strings.HasPrefix(digits, "010"):
+		return n == 11 && phoneGrouping(lengths, 3)
+	case strings.HasPrefix(digits, "01") && strings.ContainsRune("16789", rune(digits[2])):
+		return (n == 10 || n == 11) && phoneGrouping(lengths, 3)
+	case strings.HasPrefix(digits, "02"):
+		return (n == 9 || n == 10) && phoneGrouping(lengths, 2)
+	case strings.HasPrefix(digits, "070") || (n >= 3 && slices.Contains(areaCodes, digits[:3])):
+		return (n == 10 || n == 11) && phoneGrouping(lengths, 3)
+	case n == 8 && (strings.HasPrefix(digits, "15") || strings.HasPrefix(digits, "16") || strings.HasPrefix(digits, "18")):
+		return len(lengths) == 1 || slices.Equal(lengths, []int{4, 4})
+	}
+	return false
+}
+
+// 국번-중간-끝 묶음 (붙여 말한 경우 포함)
+func phoneGrouping(lengths []int, prefix int) bool {
+	switch len(lengths) {
+	case 1:
+		return true
+	case 2:
+		return lengths[0] == prefix
+	case 3:
+		return lengths[0] == prefix && (lengths[1] == 3 || lengths[1] == 4) && lengths[2] == 4
+	}
+	return false
+}
+
+// 은행별 계좌번호 묶음 형식
+var accountFormats = []struct {
+	bank   string
+	groups []int
+}{
+	{"KB국민은행", []int{6, 2, 6}},
+	{"KB국민은행", []int{3, 2, 4, 3}},
+	{"신한은행", []int{3, 3, 6}},
+	{"신한은행", []int{3, 2, 6}},
+	{"우리은행", []int{4, 3, 6}},
+	{"하나은행", []int{3, 6, 5}},
+	{"NH농협은행", []int{3, 4, 4, 2}},
+	{"NH농협은행", []int{6, 2, 6}},
+	{"IBK기업은행", []int{3, 6, 2, 3}},
+	{"SC제일은행", []int{3, 2, 6}},
+	{"카카오뱅크", []int{4, 2, 7}},
+	{"케이뱅크", []int{3, 3, 6}},
+	{"토스뱅크", []int{4, 4, 4}},
+	{"우체국", []int{6, 2, 6}},
+	{"새마을금고", []int{4, 2, 6, 1}},
+}
+
+// 묶음 형식이 일치하는 은행 목록
+func accountBanks(lengths []int) []string {
+	var banks []string
+	for _, format := range accountFormats {
+		if slices.Equal(format.groups, lengths) && !slices.Contains(banks, format.bank) {
+			banks = append(banks, format.bank)
+		}
+	}
+	return banks
+}
+
+func atoi(s string) int {
+	n := 0
+	for _, c := range s {
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
@@ -0,0 +1,309 @@
+/**
+* Name: 			loader.go
+* Description: 		시나리오 팩(YAML/JSON) 파일 로드 및 변경 감시
+* Workflow: 		디렉토리 스캔, 파일별 검증, DB 반영, 주기적 변경 감지 후 재로드
+ */
+
+package scenariopack
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"PishingSimulator_SecurityProject/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// 지원하는 시나리오 팩 파일 포맷 버전
+const SupportedVersion = 1
+
+// 시나리오 팩 파일 구조, 하나의 파일에 여러 시나리오를 정의할 수 있음
+type packFile struct {
+	Version   int            `yaml:"version" json:"version"`
+	Scenarios []scenarioSpec `yaml:"scenarios" json:"scenarios"`
+}
+
+type scenarioSpec struct {
+	Key              string   `yaml:"key" json:"key"`
+	Name             string   `yaml:"name" json:"name"`
+	Description      string   `yaml:"description" json:"description"`
+	Modes            []string `yaml:"modes" json:"modes"`
+	Difficulty       string   `yaml:"difficulty" json:"difficulty"`
+	EstimatedMinutes int      `yaml:"estimated_minutes" json:"estimated_minutes"`
+	Persona          string   `yaml:"persona" json:"persona"`
+	OpeningLine      string   `yaml:"opening_line" json:"opening_line"`
+	Goals            []string `yaml:"goals" json:"goals"`
+	ForbiddenTopics  []string `yaml:"forbidden_topics" json:"forbidden_topics"`
+	Voice            string   `yaml:"voice" json:"voice"`
+	Temperature      *float64 `yaml:"temperature" json:"temperature"`
+	Enabled          *bool    `yaml:"enabled" json:"enabled"`
+
+	Script *models.DialogueScript `yaml:"script" json:"script"`
+	Hints  []models.CoachHint     `yaml:"hints" json:"hints"`
+}
+
+// 파일 단위 로드 오류
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// 파일 변경 감지용 상태
+type fileState struct {
+	modTime time.Time
+	size    int64
+	keys    []string
+}
+
+// 시나리오 팩 로더, Load와 Watch는 같은 고루틴에서만 호출해야 함
+type Loader struct {
+	dir   string
+	files map[string]fileState
+}
+
+func NewLoader(dir string) *Loader {
+	return &Loader{
+		dir:   dir,
+		files: make(map[string]fileState),
+	}
+}
+
+// 디렉토리를 스캔하여 변경된 파일만 다시 로드, 파일별 오류 목록 반환
+// 오류가 있는 파일은 반영하지 않으며 이전에 로드된 내용을 유지함
+func (l *Loader) Load() []error {
+	var errs []error
+
+	paths, err := l.scan()
+	if err != nil {
+		return []error{err}
+	}
+
+	// 키 중복 검사를 위해 변경되지 않은 파일의 키를 먼저 수집
+	owners := make(map[string]string)
+	for _, path := range paths {
+		state, loaded := l.files[path]
+		if !loaded || l.changed(path, state) {
+			continue
+		}
+		for _, key := range state.keys {
+			owners[key] = path
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		seen[path] = true
+		state, loaded := l.files[path]
+		if loaded && !l.changed(path, state) {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			errs = append(errs, &FileError{Path: path, Err: err})
+			continue
+		}
+		scenarios, err := parseFile(path)
+		if err == nil {
+			err = claimKeys(path, scenarios, owners)
+		}
+		if err != nil {
+			errs = append(errs, &FileError{Path: path, Err: err})
+			// 같은 내용으로 매번 오류가 반복되지 않도록 변경 시각은 기록
+			state.modTime, state.size = info.ModTime(), info.Size()
+			l.files[path] = state
+			continue
+		}
+
+		keys, err := apply(scenarios)
+		if err != nil {
+			errs = append(errs, &FileError{Path: path, Err: err})
+			continue
+		}
+		disableRemoved(state.keys, keys)
+		l.files[path] = fileState{modTime: info.ModTime(), size: info.Size(), keys: keys}
+		log.Printf("scenariopack.Load(): Loaded %d scenario(s) from %s", len(keys), path)
+	}
+
+	// 삭제된 파일의 시나리오는 비활성화
+	for path, state := range l.files {
+		if seen[path] {
+			continue
+		}
+		disableRemoved(state.keys, nil)
+		delete(l.files, path)
+		log.Printf("scenariopack.Load(): Pack removed, disabled scenarios %v from %s", state.keys, path)
+	}
+
+	return errs
+}
+
+// interval 주기로 디렉토리를 다시 스캔하여 변경된 파일을 반영
+func (l *Loader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, err := range l.Load() {
+				log.Printf("scenariopack.Watch(): %v", err)
+			}
+		}
+	}
+}
+
+func (l *Loader) scan() ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read scenario directory %s: %v", l.dir, err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			paths = append(paths, filepath.Join(l.dir, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func (l *Loader) changed(path string, state fileState) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return true
+	}
+	return !info.ModTime().Equal(state.modTime) || info.Size() != state.size
+}
+
+// 파일을 파싱하고 시나리오별로 검증
+func parseFile(path string) ([]models.Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pack packFile
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		err = json.Unmarshal(data, &pack)
+	} else {
+		err = yaml.UnmarshalStrict(data, &pack)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse error: %v", err)
+	}
+	if pack.Version != SupportedVersion {
+		return nil, fmt.Errorf("unsupported pack version %d (expected %d)", pack.Version, SupportedVersion)
+	}
+	if len(pack.Scenarios) == 0 {
+		return nil, fmt.Errorf("no scenarios defined")
+	}
+
+	scenarios := make([]models.Scenario, 0, len(pack.Scenarios))
+	for i, spec := range pack.Scenarios {
+		scenario := models.Scenario{
+			Key:              spec.Key,
+			Name:             spec.Name,
+			Description:      spec.Description,
+			Modes:            spec.Modes,
+			Difficulty:       spec.Difficulty,
+			EstimatedMinutes: spec.EstimatedMinutes,
+			Persona:          spec.Persona,
+			OpeningLine:      spec.OpeningLine,
+			Goals:            spec.Goals,
+			ForbiddenTopics:  spec.ForbiddenTopics,
+			Voice:            spec.Voice,
+			Temperature:      models.DefaultTemperature,
+			Script:           spec.Script,
+			Hints:            spec.Hints,
+			Enabled:          spec.Enabled == nil || *spec.Enabled,
+			Source:           path,
+		}
+		if spec.Temperature != nil {
+			scenario.Temperature = *spec.Temperature
+		}
+		scenario.ApplyDefaults()
+		if err := scenario.Validate(); err != nil {
+			return nil, fmt.Errorf("scenarios[%d] (%s): %v", i, spec.Key, err)
+		}
+		scenarios = append(scenarios, scenario)
+	}
+	return scenarios, nil
+}
+
+// 다른 파일과 키가 겹치지 않는지 확인하고 키의 소유 파일을 기록
+func claimKeys(path string, scenarios []models.Scenario, owners map[string]string) error {
+	local := make(map[string]bool)
+	for _, scenario := range scenarios {
+		if local[scenario.Key] {
+			return fmt.Errorf("duplicate scenario key %q in file", scenario.Key)
+		}
+		if owner, exists := owners[scenario.Key]; exists && owner != path {
+			return fmt.Errorf("scenario key %q is already defined in %s", scenario.Key, owner)
+		}
+		local[scenario.Key] = true
+	}
+	for key := range local {
+		owners[key] = path
+	}
+	return nil
+}
+
+// 관리자가 생성한 시나리오와 키가 겹치면 건너뜀 (파일이 소유한 키에 포함하지 않음)
+func apply(scenarios []models.Scenario) ([]string, error) {
+	keys := make([]string, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		err := storage.UpsertScenario(scenario)
+		if errors.Is(err, storage.ErrScenarioNotOwned) {
+			log.Printf("scenariopack: Skipped scenario %s from %s, key is used by an admin-created scenario", scenario.Key, scenario.Source)
+			continue
+		}
+		if err != nil {
+			return keys, fmt.Errorf("failed to save scenario %s: %v", scenario.Key, err)
+		}
+		keys = append(keys, scenario.Key)
+	}
+	return keys, nil
+}
+
+// 이전 로드에는 있었지만 현재 목록에서 빠진 시나리오를 비활성화
+func disableRemoved(previous, current []string) {
+	keep := make(map[string]bool, len(current))
+	for _, key := range current {
+		keep[key] = true
+	}
+	for _, key := range previous {
+		if keep[key] {
+			continue
+		}
+		if err := storage.DisableScenario(key); err != nil {
+			log.Printf("scenariopack: Failed to disable removed scenario %s: %v", key, err)
+		}
+	}
+}
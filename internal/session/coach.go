@@ -0,0 +1,34 @@
+/**
+* Name: 			coach.go
+* Description: 		코치 모드 힌트 선택
+* Workflow: 		사기범 발화마다 시나리오 힌트의 키워드/진행 단계 조건 검사, 세션 중 아직 표시하지 않은 힌트만 반환
+ */
+
+package session
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+)
+
+// 세션별 코치, 같은 힌트는 한 번만 표시
+type Coach struct {
+	hints []models.CoachHint
+	shown map[string]bool
+}
+
+func NewCoach(hints []models.CoachHint) *Coach {
+	return &Coach{hints: hints, shown: make(map[string]bool)}
+}
+
+// 사기범 발화 직후 표시할 힌트 목록 (정의 순서)
+func (c *Coach) Check(utterance string, step State) []models.CoachHint {
+	var matched []models.CoachHint
+	for _, hint := range c.hints {
+		if c.shown[hint.ID] || !hint.Matches(utterance, string(step)) {
+			continue
+		}
+		c.shown[hint.ID] = true
+		matched = append(matched, hint)
+	}
+	return matched
+}
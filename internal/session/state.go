@@ -0,0 +1,118 @@
+/**
+* Name: 			state.go
+* Description: 		시뮬레이션 세션 상태 머신
+* Workflow: 		대화 엔진의 NextStep으로 상태 전이, 종료 상태 도달 시 세션 종료 및 결과 판정
+ */
+
+package session
+
+import (
+	"PishingSimulator_SecurityProject/internal/llm"
+	"PishingSimulator_SecurityProject/internal/models"
+	"log"
+	"sync"
+	"time"
+)
+
+type State string
+
+// 세션 상태, 대화 엔진의 NextStep 값과 동일
+const (
+	StateOpening           State = llm.NextStepOpening
+	StatePressure          State = llm.NextStepPressure
+	StateCredentialRequest State = llm.NextStepCredentialRequest
+	StatePaymentRequest    State = llm.NextStepPaymentRequest
+	StateClosing           State = llm.NextStepClosing
+	StateUserHungUp        State = llm.NextStepUserHungUp
+	StateScamSucceeded     State = llm.NextStepScamSucceeded
+)
+
+// 종료 상태와 그에 따른 세션 결과
+var terminalOutcomes = map[State]string{
+	StateClosing:       models.OutcomeResisted,
+	StateUserHungUp:    models.OutcomeResisted,
+	StateScamSucceeded: models.OutcomeScamSucceeded,
+}
+
+var knownStates = map[State]bool{
+	StateOpening:           true,
+	StatePressure:          true,
+	StateCredentialRequest: true,
+	StatePaymentRequest:    true,
+	StateClosing:           true,
+	StateUserHungUp:        true,
+	StateScamSucceeded:     true,
+}
+
+// 상태 전이 기록
+type Transition struct {
+	From State
+	To   State
+	At   time.Time
+}
+
+// 세션 상태 머신, 음성 세션에서는 여러 고루틴이 접근하므로 잠금 사용
+type StateMachine struct {
+	mu          sync.Mutex
+	state       State
+	transitions []Transition
+}
+
+func NewStateMachine() *StateMachine {
+	return &StateMachine{state: StateOpening}
+}
+
+// NextStep을 반영하여 상태 전이, 알 수 없는 값이나 종료 이후의 값은 무시
+// 반환값은 전이 후 상태와 세션 종료 여부
+func (m *StateMachine) Apply(nextStep string) (State, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, done := terminalOutcomes[m.state]; done {
+		return m.state, true
+	}
+
+	next := State(nextStep)
+	if !knownStates[next] {
+		if nextStep != "" {
+			log.Printf("StateMachine.Apply(): Ignoring unknown next step %q (state: %s)", nextStep, m.state)
+		}
+		return m.state, false
+	}
+	if next != m.state {
+		m.transitions = append(m.transitions, Transition{From: m.state, To: next, At: time.Now()})
+		m.state = next
+	}
+
+	_, done := terminalOutcomes[m.state]
+	return m.state, done
+}
+
+func (m *StateMachine) State() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+func (m *StateMachine) Done() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, done := terminalOutcomes[m.state]
+	return done
+}
+
+// 세션 결과, 종료 상태에 도달하지 못한 채 끝난 세션은 incomplete
+func (m *StateMachine) Outcome() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if outcome, done := terminalOutcomes[m.state]; done {
+		return outcome
+	}
+	return models.OutcomeIncomplete
+}
+
+func (m *StateMachine) Transitions() []Transition {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Transition(nil), m.transitions...)
+}
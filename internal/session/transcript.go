@@ -0,0 +1,66 @@
+/**
+* Name: 			transcript.go
+* Description: 		세션 대화 기록 수집
+* Workflow: 		세션 중 발화를 순서대로 누적, 세션 종료 후 기록(Record)과 함께 저장
+ */
+
+package session
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"sync"
+	"time"
+)
+
+// 세션 대화 기록, 음성 세션에서는 여러 고루틴이 접근하므로 잠금 사용
+type Transcript struct {
+	mu        sync.Mutex
+	startTime time.Time
+	turns     []models.TranscriptTurn
+}
+
+func NewTranscript(startTime time.Time) *Transcript {
+	return &Transcript{startTime: startTime}
+}
+
+// 세션 시작 이후 경과 시간
+func (t *Transcript) Elapsed() time.Duration {
+	return time.Since(t.startTime)
+}
+
+// 발화 추가, start/end는 세션 시작 기준 오프셋, confidence는 STT 결과가 없으면 nil
+func (t *Transcript) Add(speaker, text string, start, end time.Duration, confidence *float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if end < start {
+		end = start
+	}
+	t.turns = append(t.turns, models.TranscriptTurn{
+		Seq:        len(t.turns),
+		Speaker:    speaker,
+		Text:       text,
+		StartMs:    start.Milliseconds(),
+		EndMs:      end.Milliseconds(),
+		Confidence: confidence,
+	})
+}
+
+// 코치 모드에서 표시한 힌트 추가
+func (t *Transcript) AddHint(hint models.CoachHint, at time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.turns = append(t.turns, models.TranscriptTurn{
+		Seq:     len(t.turns),
+		Speaker: models.SpeakerCoach,
+		Text:    hint.Message,
+		StartMs: at.Milliseconds(),
+		EndMs:   at.Milliseconds(),
+		HintID:  hint.ID,
+	})
+}
+
+func (t *Transcript) Turns() []models.TranscriptTurn {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]models.TranscriptTurn(nil), t.turns...)
+}
@@ -1,8 +1,12 @@
 package storage
 
 import (
+	"PishingSimulator_SecurityProject/internal/auth"
+	"PishingSimulator_SecurityProject/internal/models"
 	"database/sql"
+	"fmt"
 	"log"
+	"strings"
 
 	_ "modernc.org/sqlite"
 )
@@ -20,31 +24,386 @@ func InitDB() {
 		log.Fatal("storage.InitDB(): Failed to connect to database: ", err)
 	}
 
+	createOrganizationsTable := `
+	CREATE TABLE IF NOT EXISTS organizations (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"name" TEXT NOT NULL UNIQUE,
+			"scenarios" TEXT,
+			"enabled_scenarios" TEXT,
+			"mfa_required_roles" TEXT,
+			"org_mfa_required_roles" TEXT,
+			"created_at" DATETIME NOT NULL
+	)`
 	createUsersTable := `
 	CREATE TABLE IF NOT EXISTS users (
 			"id" INTEGER PRIMARY KEY AUTOINCREMENT, 
 			"username" TEXT NOT NULL UNIQUE,
 			"password_hash" TEXT NOT NULL,
+			"role" TEXT NOT NULL DEFAULT 'trainee',
+			"org_id" INTEGER REFERENCES organizations(id),
+			"group_id" INTEGER REFERENCES groups(id),
 			"name" TEXT,
 			"age" INTEGER,
-			"gender" TEXT
+			"gender" TEXT,
+			"occupation" TEXT,
+			"bank" TEXT,
+			"region" TEXT,
+			"has_children" INTEGER,
+			"llm_shared_fields" TEXT,
+			"mfa_secret" TEXT,
+			"mfa_enabled_at" DATETIME,
+			"mfa_last_step" INTEGER,
+			"tokens_valid_after" INTEGER
 	);`
+	createGroupsTable := `
+	CREATE TABLE IF NOT EXISTS groups (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"org_id" INTEGER REFERENCES organizations(id),
+			"name" TEXT NOT NULL,
+			"created_at" DATETIME NOT NULL,
+			UNIQUE(org_id, name)
+	)`
+	// 조직에 속하지 않은 그룹끼리도 이름 중복 방지 (UNIQUE는 NULL org_id를 서로 다른 값으로 취급)
+	createGroupsUnassignedNameIndex := `CREATE UNIQUE INDEX IF NOT EXISTS idx_groups_unassigned_name ON groups(name) WHERE org_id IS NULL`
+	createInvitationsTable := `
+	CREATE TABLE IF NOT EXISTS organization_invitations (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"org_id" INTEGER NOT NULL,
+			"user_id" INTEGER NOT NULL,
+			"role" TEXT NOT NULL,
+			"group_id" INTEGER,
+			"invited_by" INTEGER NOT NULL,
+			"created_at" DATETIME NOT NULL,
+			"accepted_at" DATETIME,
+			FOREIGN KEY(org_id) REFERENCES organizations(id),
+			FOREIGN KEY(user_id) REFERENCES users(id)
+	)`
+	createInviteCodesTable := `
+	CREATE TABLE IF NOT EXISTS invite_codes (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"code_hash" TEXT NOT NULL UNIQUE,
+			"code_prefix" TEXT NOT NULL,
+			"org_id" INTEGER REFERENCES organizations(id),
+			"role" TEXT NOT NULL,
+			"group_id" INTEGER REFERENCES groups(id),
+			"max_uses" INTEGER NOT NULL,
+			"uses" INTEGER NOT NULL DEFAULT 0,
+			"expires_at" DATETIME NOT NULL,
+			"revoked_at" DATETIME,
+			"created_by" INTEGER NOT NULL,
+			"created_at" DATETIME NOT NULL
+	)`
+	createInviteCodeRedemptionsTable := `
+	CREATE TABLE IF NOT EXISTS invite_code_redemptions (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"code_id" INTEGER NOT NULL,
+			"user_id" INTEGER NOT NULL,
+			"client_ip" TEXT,
+			"redeemed_at" DATETIME NOT NULL,
+			FOREIGN KEY(code_id) REFERENCES invite_codes(id),
+			FOREIGN KEY(user_id) REFERENCES users(id)
+	)`
+	createRefreshTokensTable := `
+	CREATE TABLE IF NOT EXISTS refresh_tokens (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"user_id" INTEGER NOT NULL,
+			"token_hash" TEXT NOT NULL UNIQUE,
+			"family_id" TEXT NOT NULL,
+			"expires_at" DATETIME NOT NULL,
+			"created_at" DATETIME NOT NULL,
+			"used_at" DATETIME,
+			"revoked_at" DATETIME,
+			"mfa" INTEGER NOT NULL DEFAULT 0,
+			FOREIGN KEY(user_id) REFERENCES users(id)
+	)`
+	createRefreshTokensIndex := `CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id)`
+	// expires_at은 만료 항목 정리를 위한 비교용 unix 시각 (초)
+	createRevokedTokensTable := `
+	CREATE TABLE IF NOT EXISTS revoked_tokens (
+			"jti" TEXT PRIMARY KEY,
+			"expires_at" INTEGER NOT NULL
+	)`
+	// 1회용 티켓 (WebSocket 연결, 녹음 재생 URL), expires_at은 unix 시각 (초)
+	createAccessTicketsTable := `
+	CREATE TABLE IF NOT EXISTS access_tickets (
+			"ticket_hash" TEXT PRIMARY KEY,
+			"user_id" INTEGER NOT NULL,
+			"purpose" TEXT NOT NULL,
+			"resource" TEXT NOT NULL,
+			"expires_at" INTEGER NOT NULL,
+			FOREIGN KEY(user_id) REFERENCES users(id)
+	)`
+	createMFARecoveryCodesTable := `
+	CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"user_id" INTEGER NOT NULL,
+			"code_hash" TEXT NOT NULL,
+			"used_at" DATETIME,
+			FOREIGN KEY(user_id) REFERENCES users(id)
+	)`
+	// 관리자가 발급한 1회용 비밀번호 재설정 토큰, expires_at은 unix 시각 (초)
+	createPasswordResetTokensTable := `
+	CREATE TABLE IF NOT EXISTS password_reset_tokens (
+			"token_hash" TEXT PRIMARY KEY,
+			"user_id" INTEGER NOT NULL,
+			"created_by" INTEGER NOT NULL,
+			"expires_at" INTEGER NOT NULL,
+			FOREIGN KEY(user_id) REFERENCES users(id),
+			FOREIGN KEY(created_by) REFERENCES users(id)
+	)`
+	// SSO 로그인 진행 중인 요청 (state 해시, PKCE code_verifier, nonce), expires_at은 unix 시각 (초)
+	createOIDCLoginStatesTable := `
+	CREATE TABLE IF NOT EXISTS oidc_login_states (
+			"state_hash" TEXT PRIMARY KEY,
+			"provider" TEXT NOT NULL,
+			"code_verifier" TEXT NOT NULL,
+			"nonce" TEXT NOT NULL,
+			"expires_at" INTEGER NOT NULL
+	)`
+	// SSO 공급자의 사용자(sub)와 연결된 계정
+	createUserIdentitiesTable := `
+	CREATE TABLE IF NOT EXISTS user_identities (
+			"provider" TEXT NOT NULL,
+			"subject" TEXT NOT NULL,
+			"user_id" INTEGER NOT NULL,
+			"created_at" DATETIME NOT NULL,
+			"last_login_at" DATETIME NOT NULL,
+			PRIMARY KEY(provider, subject),
+			FOREIGN KEY(user_id) REFERENCES users(id)
+	)`
+	// 계정별 로그인 실패 기록 (존재하지 않는 사용자명 포함), 시각은 unix 시각 (초)
+	// blocked_until까지 로그인 시도 불가 (실패 후 대기, 잠금, 진행 중인 시도)
+	createLoginAttemptsTable := `
+	CREATE TABLE IF NOT EXISTS login_attempts (
+			"username" TEXT PRIMARY KEY,
+			"failed_count" INTEGER NOT NULL DEFAULT 0,
+			"last_failed_at" INTEGER,
+			"blocked_until" INTEGER NOT NULL
+	)`
 	createRecordsTable := `
 	CREATE TABLE IF NOT EXISTS Records (
 			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
 			"user_id" INTEGER NOT NULL,
+			"org_id" INTEGER,
 			"scenario_key" TEXT,
+			"mode" TEXT NOT NULL DEFAULT 'voice',
 			"file_path" TEXT NOT NULL,
+			"duration_ms" INTEGER NOT NULL DEFAULT 0,
+			"outcome" TEXT,
+			"final_state" TEXT,
+			"coach" INTEGER NOT NULL DEFAULT 0,
+			"score" INTEGER,
+			"report" TEXT,
+			"created_at" DATETIME NOT NULL,
+			FOREIGN KEY(user_id) REFERENCES users(id)
+	)`
+	createScenariosTable := `
+	CREATE TABLE IF NOT EXISTS scenarios (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"scenario_key" TEXT NOT NULL UNIQUE,
+			"name" TEXT NOT NULL,
+			"description" TEXT,
+			"modes" TEXT NOT NULL DEFAULT 'text,voice',
+			"difficulty" TEXT NOT NULL DEFAULT 'medium',
+			"estimated_minutes" INTEGER NOT NULL DEFAULT 10,
+			"persona" TEXT,
+			"opening_line" TEXT,
+			"goals" TEXT,
+			"forbidden_topics" TEXT,
+			"voice" TEXT,
+			"temperature" REAL,
+			"script" TEXT,
+			"hints" TEXT,
+			"source" TEXT,
+			"enabled" INTEGER NOT NULL DEFAULT 1,
 			"created_at" DATETIME NOT NULL,
+			"updated_at" DATETIME NOT NULL
+	)`
+	createTranscriptTurnsTable := `
+	CREATE TABLE IF NOT EXISTS transcript_turns (
+			"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+			"record_id" INTEGER NOT NULL,
+			"seq" INTEGER NOT NULL,
+			"speaker" TEXT NOT NULL,
+			"text" TEXT NOT NULL,
+			"start_ms" INTEGER NOT NULL,
+			"end_ms" INTEGER NOT NULL,
+			"confidence" REAL,
+			"hint_id" TEXT,
+			FOREIGN KEY(record_id) REFERENCES records(id)
+	)`
+	createTranscriptTurnsIndex := `CREATE INDEX IF NOT EXISTS idx_transcript_turns_record ON transcript_turns(record_id, seq)`
+	createUserProgressTable := `
+	CREATE TABLE IF NOT EXISTS user_progress (
+			"user_id" INTEGER NOT NULL,
+			"scenario_key" TEXT NOT NULL,
+			"attempts" INTEGER NOT NULL DEFAULT 0,
+			"best_score" INTEGER,
+			"last_score" INTEGER,
+			"last_record_id" INTEGER,
+			"updated_at" DATETIME NOT NULL,
+			PRIMARY KEY(user_id, scenario_key),
 			FOREIGN KEY(user_id) REFERENCES users(id)
 	)`
 
+	if _, err := db.Exec(createOrganizationsTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create organizations table: %v", err)
+	}
 	if _, err := db.Exec(createUsersTable); err != nil {
 		log.Fatalf("InitDB(): Failed to create users table: %v", err)
 	}
+	if _, err := db.Exec(createGroupsTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create groups table: %v", err)
+	}
+	if _, err := db.Exec(createInvitationsTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create organization_invitations table: %v", err)
+	}
+	if _, err := db.Exec(createInviteCodesTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create invite_codes table: %v", err)
+	}
+	if _, err := db.Exec(createInviteCodeRedemptionsTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create invite_code_redemptions table: %v", err)
+	}
+	if _, err := db.Exec(createRefreshTokensTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create refresh_tokens table: %v", err)
+	}
+	if _, err := db.Exec(createRefreshTokensIndex); err != nil {
+		log.Fatalf("InitDB(): Failed to create refresh_tokens index: %v", err)
+	}
+	if _, err := db.Exec(createRevokedTokensTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create revoked_tokens table: %v", err)
+	}
+	if _, err := db.Exec(createAccessTicketsTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create access_tickets table: %v", err)
+	}
+	if _, err := db.Exec(createMFARecoveryCodesTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create mfa_recovery_codes table: %v", err)
+	}
+	if _, err := db.Exec(createPasswordResetTokensTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create password_reset_tokens table: %v", err)
+	}
+	if _, err := db.Exec(createOIDCLoginStatesTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create oidc_login_states table: %v", err)
+	}
+	if _, err := db.Exec(createUserIdentitiesTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create user_identities table: %v", err)
+	}
+	if _, err := db.Exec(createLoginAttemptsTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create login_attempts table: %v", err)
+	}
 	if _, err := db.Exec(createRecordsTable); err != nil {
 		log.Fatalf("InitDB(): Failed to create recrodings table: %v", err)
 	}
+	if _, err := db.Exec(createScenariosTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create scenarios table: %v", err)
+	}
+	if _, err := db.Exec(createTranscriptTurnsTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create transcript_turns table: %v", err)
+	}
+	if _, err := db.Exec(createTranscriptTurnsIndex); err != nil {
+		log.Fatalf("InitDB(): Failed to create transcript_turns index: %v", err)
+	}
+	if _, err := db.Exec(createUserProgressTable); err != nil {
+		log.Fatalf("InitDB(): Failed to create user_progress table: %v", err)
+	}
+
+	// 기존 DB 파일에 누락된 컬럼 추가
+	migrations := []struct{ table, column, definition string }{
+		{"scenarios", "modes", `TEXT NOT NULL DEFAULT 'text,voice'`},
+		{"scenarios", "difficulty", `TEXT NOT NULL DEFAULT 'medium'`},
+		{"scenarios", "estimated_minutes", `INTEGER NOT NULL DEFAULT 10`},
+		{"scenarios", "persona", `TEXT`},
+		{"scenarios", "opening_line", `TEXT`},
+		{"scenarios", "goals", `TEXT`},
+		{"scenarios", "forbidden_topics", `TEXT`},
+		{"scenarios", "voice", `TEXT`},
+		{"scenarios", "temperature", `REAL`},
+		{"scenarios", "script", `TEXT`},
+		{"records", "outcome", `TEXT`},
+		{"records", "final_state", `TEXT`},
+		{"scenarios", "source", `TEXT`},
+		{"records", "mode", `TEXT NOT NULL DEFAULT 'voice'`}, // 기존 기록은 모두 음성 세션
+		{"records", "duration_ms", `INTEGER NOT NULL DEFAULT 0`},
+		{"records", "score", `INTEGER`},
+		{"records", "report", `TEXT`},
+		{"scenarios", "hints", `TEXT`},
+		{"records", "coach", `INTEGER NOT NULL DEFAULT 0`},
+		{"transcript_turns", "hint_id", `TEXT`},
+		{"users", "role", `TEXT NOT NULL DEFAULT 'trainee'`},
+		{"users", "group_id", `INTEGER REFERENCES groups(id)`},
+		{"users", "org_id", `INTEGER REFERENCES organizations(id)`},
+		{"groups", "org_id", `INTEGER REFERENCES organizations(id)`},
+		{"records", "org_id", `INTEGER`},
+		{"users", "mfa_secret", `TEXT`},
+		{"users", "mfa_enabled_at", `DATETIME`},
+		{"users", "mfa_last_step", `INTEGER`},
+		{"organizations", "mfa_required_roles", `TEXT`},
+		{"refresh_tokens", "mfa", `INTEGER NOT NULL DEFAULT 0`},
+		{"users", "tokens_valid_after", `INTEGER`},
+		{"users", "occupation", `TEXT`},
+		{"users", "bank", `TEXT`},
+		{"users", "region", `TEXT`},
+		{"users", "has_children", `INTEGER`},
+		{"users", "llm_shared_fields", `TEXT`}, // NULL이면 기본 항목 공유
+		{"organizations", "enabled_scenarios", `TEXT`},
+		{"organizations", "org_mfa_required_roles", `TEXT`},
+	}
+	for _, m := range migrations {
+		if err := ensureColumn(m.table, m.column, m.definition); err != nil {
+			log.Fatalf("InitDB(): Failed to migrate %s.%s: %v", m.table, m.column, err)
+		}
+	}
+	if err := migrateGroupNameUniqueness(createGroupsTable); err != nil {
+		log.Fatalf("InitDB(): Failed to migrate groups name uniqueness: %v", err)
+	}
+	if _, err := db.Exec(createGroupsUnassignedNameIndex); err != nil {
+		log.Fatalf("InitDB(): Failed to create groups name index: %v", err)
+	}
+	// mode 컬럼 추가 이전에 저장된 텍스트 세션 기록 (녹음 파일 없음) 보정
+	if _, err := db.Exec(`UPDATE records SET mode = 'text' WHERE file_path = '' AND mode <> 'text'`); err != nil {
+		log.Fatalf("InitDB(): Failed to migrate records.mode: %v", err)
+	}
+
+	if err := backfillDefaultOrganization(); err != nil {
+		log.Fatalf("InitDB(): Failed to backfill default organization: %v", err)
+	}
+	if err := backfillUserProgress(); err != nil {
+		log.Fatalf("InitDB(): Failed to backfill user_progress: %v", err)
+	}
+
+	if err := seedScenarios(); err != nil {
+		log.Fatalf("InitDB(): Failed to seed default scenarios: %v", err)
+	}
+	models.RegisterScenarioLookup(lookupActiveScenario)
+	auth.RegisterRevocationLookup(IsAccessTokenRevoked)
 	log.Println("InitDB(): Init and create table successfully!")
 
 }
+
+// 테이블에 컬럼이 없으면 ALTER TABLE로 추가 (CREATE TABLE IF NOT EXISTS는 기존 테이블을 변경하지 않음)
+func ensureColumn(table, column, definition string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return err
+		}
+		if strings.EqualFold(name, column) {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN "%s" %s`, table, column, definition))
+	return err
+}
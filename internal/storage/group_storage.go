@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+var ErrGroupExists = errors.New("group name already exists in the organization")
+
+// 그룹 생성, 생성된 그룹 반환 (orgID가 nil이면 조직에 속하지 않은 그룹)
+func CreateGroup(name string, orgID *int) (models.Group, error) {
+	group := models.Group{OrgID: orgID, Name: name, CreatedAt: time.Now()}
+	res, err := db.Exec("INSERT INTO groups(org_id, name, created_at) VALUES(?, ?, ?)", group.OrgID, group.Name, group.CreatedAt)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == 2067 {
+			return group, ErrGroupExists
+		}
+		return group, err
+	}
+	id, err := res.LastInsertId()
+	group.ID = int(id)
+	return group, err
+}
+
+// ID로 그룹 조회, 없으면 sql.ErrNoRows
+func GetGroupByID(id int) (models.Group, error) {
+	return scanGroup(db.QueryRow("SELECT id, org_id, name, created_at FROM groups WHERE id = ?", id))
+}
+
+// 그룹 목록 조회, orgID가 nil이 아니면 해당 조직의 그룹만
+func GetGroups(orgID *int) ([]models.Group, error) {
+	rows, err := db.Query("SELECT id, org_id, name, created_at FROM groups WHERE (? IS NULL OR org_id = ?) ORDER BY name", orgID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []models.Group{}
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+func scanGroup(row rowScanner) (models.Group, error) {
+	var group models.Group
+	var orgID sql.NullInt64
+	if err := row.Scan(&group.ID, &orgID, &group.Name, &group.CreatedAt); err != nil {
+		return group, err
+	}
+	group.OrgID = nullIntPtr(orgID)
+	return group, nil
+}
+
+// 전체 그룹에서 이름이 고유하던 기존 groups 테이블을 조직별 UNIQUE(org_id, name)로 변경
+// SQLite는 제약 조건을 변경할 수 없으므로 새 스키마(createTableSQL)로 테이블을 다시 만들어 데이터 복사
+func migrateGroupNameUniqueness(createTableSQL string) error {
+	unique, err := hasUniqueIndexOn("groups", "name")
+	if err != nil || !unique {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 기존 테이블의 이름을 바꾸면 users.group_id 등의 외래 키도 따라 바뀌므로 새 테이블을 만든 뒤 교체
+	statements := []string{
+		strings.Replace(createTableSQL, "groups", "groups_new", 1),
+		`INSERT INTO groups_new(id, org_id, name, created_at) SELECT id, org_id, name, created_at FROM groups`,
+		`DROP TABLE groups`,
+		`ALTER TABLE groups_new RENAME TO groups`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("%s: %w", strings.Fields(statement)[0], err)
+		}
+	}
+	return tx.Commit()
+}
+
+// column 하나로만 이루어진 UNIQUE 인덱스(제약 조건 포함)가 테이블에 있는지 검사
+func hasUniqueIndexOn(table, column string) (bool, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA index_list(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	var uniqueIndexes []string
+	for rows.Next() {
+		var seq, unique, partial int
+		var name, origin string
+		if err := rows.Scan(&seq, &name, &unique, &origin, &partial); err != nil {
+			rows.Close()
+			return false, err
+		}
+		if unique == 1 && partial == 0 {
+			uniqueIndexes = append(uniqueIndexes, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	for _, index := range uniqueIndexes {
+		var columns []string
+		rows, err := db.Query(fmt.Sprintf("PRAGMA index_info(%s)", index))
+		if err != nil {
+			return false, err
+		}
+		for rows.Next() {
+			var seqno, cid int
+			var name sql.NullString
+			if err := rows.Scan(&seqno, &cid, &name); err != nil {
+				rows.Close()
+				return false, err
+			}
+			columns = append(columns, name.String)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return false, err
+		}
+		if len(columns) == 1 && strings.EqualFold(columns[0], column) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
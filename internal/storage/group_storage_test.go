@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// 그룹 이름이 전체에서 고유하던 기존 DB 파일을 InitDB로 마이그레이션
+func TestInitDBMigratesGroupNameUniquenessPerOrganization(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	legacy, err := sql.Open("sqlite", "./pishing_simulator.db")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	for _, statement := range []string{
+		`CREATE TABLE groups ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "name" TEXT NOT NULL UNIQUE, "created_at" DATETIME NOT NULL)`,
+		`INSERT INTO groups(name, created_at) VALUES('1기', '2026-01-01 00:00:00'), ('2기', '2026-01-01 00:00:00')`,
+	} {
+		if _, err := legacy.Exec(statement); err != nil {
+			t.Fatalf("%s: %v", statement, err)
+		}
+	}
+	legacy.Close()
+
+	InitDB()
+	t.Cleanup(func() { db.Close() })
+
+	groups, err := GetGroups(nil)
+	if err != nil || len(groups) != 2 || groups[0].ID != 1 || groups[0].Name != "1기" {
+		t.Fatalf("GetGroups after migration = %+v, %v, want existing groups kept", groups, err)
+	}
+
+	first, second := 101, 102
+	for _, org := range []*int{&first, &second} {
+		if _, err := CreateGroup("1기", org); err != nil {
+			t.Errorf("CreateGroup(1기, org %d): %v", *org, err)
+		}
+	}
+	if _, err := CreateGroup("1기", &first); !errors.Is(err, ErrGroupExists) {
+		t.Errorf("CreateGroup duplicate in org: err = %v, want ErrGroupExists", err)
+	}
+	if _, err := CreateGroup("3기", nil); err != nil {
+		t.Errorf("CreateGroup(3기, nil): %v", err)
+	}
+	if _, err := CreateGroup("3기", nil); !errors.Is(err, ErrGroupExists) {
+		t.Errorf("CreateGroup duplicate without org: err = %v, want ErrGroupExists", err)
+	}
+
+	// users.group_id 외래 키가 새 groups 테이블을 계속 참조해야 함
+	var usersSQL string
+	if err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'users'`).Scan(&usersSQL); err != nil {
+		t.Fatalf("reading users schema: %v", err)
+	}
+	if strings.Contains(usersSQL, "groups_") {
+		t.Errorf("users schema references a temporary table: %s", usersSQL)
+	}
+	if unique, err := hasUniqueIndexOn("groups", "name"); err != nil || unique {
+		t.Errorf("hasUniqueIndexOn(groups, name) = %v, %v, want false after migration", unique, err)
+	}
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+// 초대 코드가 없거나 만료, 회수, 사용 횟수 초과인 경우 (가입 요청자에게는 사유를 구분하지 않음)
+var ErrInviteCodeInvalid = errors.New("invalid invite code")
+
+const selectInviteCodeColumns = `SELECT id, code_prefix, org_id, role, group_id, max_uses, uses, expires_at, revoked_at, created_by, created_at FROM invite_codes`
+
+// 초대 코드 생성, 코드 원문(Code)은 반환값에만 포함되고 DB에는 해시만 저장
+// inviteCode의 OrgID, Role, GroupID, MaxUses, ExpiresAt, CreatedBy를 사용
+func CreateInviteCode(inviteCode models.InviteCode) (models.InviteCode, error) {
+	code, err := generateInviteCode()
+	if err != nil {
+		return inviteCode, err
+	}
+	inviteCode.Code = code
+	inviteCode.CodePrefix = code[:4]
+	inviteCode.CreatedAt = time.Now()
+
+	res, err := db.Exec(`
+		INSERT INTO invite_codes(code_hash, code_prefix, org_id, role, group_id, max_uses, expires_at, created_by, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, hashEnteredCode(code), inviteCode.CodePrefix, inviteCode.OrgID, inviteCode.Role, inviteCode.GroupID,
+		inviteCode.MaxUses, inviteCode.ExpiresAt, inviteCode.CreatedBy, inviteCode.CreatedAt)
+	if err != nil {
+		return inviteCode, err
+	}
+	id, err := res.LastInsertId()
+	inviteCode.ID = int(id)
+	inviteCode.Status = inviteCode.StatusAt(time.Now())
+	return inviteCode, err
+}
+
+// ID로 초대 코드 조회, 없으면 sql.ErrNoRows
+func GetInviteCodeByID(id int) (models.InviteCode, error) {
+	return scanInviteCode(db.QueryRow(selectInviteCodeColumns+" WHERE id = ?", id))
+}
+
+// 초대 코드 목록 (최신순), orgID가 nil이 아니면 해당 조직의 코드만
+func GetInviteCodes(orgID *int) ([]models.InviteCode, error) {
+	rows, err := db.Query(selectInviteCodeColumns+" WHERE (? IS NULL OR org_id = ?) ORDER BY created_at DESC", orgID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codes := []models.InviteCode{}
+	for rows.Next() {
+		code, err := scanInviteCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, rows.Err()
+}
+
+// 코드 원문으로 사용 가능한 초대 코드 조회 (대소문자, 하이픈, 공백 무시)
+// 사용할 수 없는 코드는 ErrInviteCodeInvalid
+func GetUsableInviteCode(code string) (models.InviteCode, error) {
+	inviteCode, err := scanInviteCode(db.QueryRow(selectInviteCodeColumns+" WHERE code_hash = ?", hashEnteredCode(code)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return inviteCode, ErrInviteCodeInvalid
+	}
+	if err != nil {
+		return inviteCode, err
+	}
+	if inviteCode.Status != models.InviteCodeActive {
+		log.Printf("GetUsableInviteCode(): Rejected invite code %d (%s)", inviteCode.ID, inviteCode.Status)
+		return inviteCode, ErrInviteCodeInvalid
+	}
+	return inviteCode, nil
+}
+
+func scanInviteCode(row rowScanner) (models.InviteCode, error) {
+	var code models.InviteCode
+	var orgID, groupID sql.NullInt64
+	var revokedAt sql.NullTime
+	if err := row.Scan(
+		&code.ID, &code.CodePrefix, &orgID, &code.Role, &groupID, &code.MaxUses, &code.Uses,
+		&code.ExpiresAt, &revokedAt, &code.CreatedBy, &code.CreatedAt,
+	); err != nil {
+		return code, err
+	}
+	code.OrgID = nullIntPtr(orgID)
+	code.GroupID = nullIntPtr(groupID)
+	if revokedAt.Valid {
+		code.RevokedAt = &revokedAt.Time
+	}
+	code.Status = code.StatusAt(time.Now())
+	return code, nil
+}
+
+// 초대 코드 회수, 없거나 이미 회수된 코드는 sql.ErrNoRows
+func RevokeInviteCode(id int) error {
+	result, err := db.Exec("UPDATE invite_codes SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", time.Now(), id)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 초대 코드로 사용자 생성, 코드에 지정된 조직, 역할, 그룹을 적용하고 사용 기록을 남김
+// 사용 횟수 증가와 사용자 생성은 하나의 트랜잭션으로 처리 (동시 가입으로 최대 사용 횟수를 넘지 않음)
+func CreateUserWithInviteCode(code string, user models.User, clientIP string) error {
+	inviteCode, err := GetUsableInviteCode(code)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		"UPDATE invite_codes SET uses = uses + 1 WHERE id = ? AND revoked_at IS NULL AND uses < max_uses",
+		inviteCode.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if err := checkRowsAffected(result); err != nil {
+		return ErrInviteCodeInvalid
+	}
+
+	user.Role = inviteCode.Role
+	user.OrgID = inviteCode.OrgID
+	user.GroupID = inviteCode.GroupID
+	userID, err := insertUser(tx, user)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO invite_code_redemptions(code_id, user_id, client_ip, redeemed_at) VALUES(?, ?, ?, ?)",
+		inviteCode.ID, userID, clientIP, time.Now(),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// 초대 코드 사용 기록 (최신순)
+func GetInviteCodeRedemptions(codeID int) ([]models.InviteCodeRedemption, error) {
+	rows, err := db.Query(`
+		SELECT r.id, r.code_id, r.user_id, u.username, r.client_ip, r.redeemed_at
+		FROM invite_code_redemptions r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.code_id = ?
+		ORDER BY r.redeemed_at DESC
+	`, codeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	redemptions := []models.InviteCodeRedemption{}
+	for rows.Next() {
+		var r models.InviteCodeRedemption
+		var clientIP sql.NullString
+		if err := rows.Scan(&r.ID, &r.CodeID, &r.UserID, &r.Username, &clientIP, &r.RedeemedAt); err != nil {
+			return nil, err
+		}
+		r.ClientIP = clientIP.String
+		redemptions = append(redemptions, r)
+	}
+	return redemptions, rows.Err()
+}
+
+// 16자리 무작위 코드 (80비트, 4자리씩 하이픈으로 구분, 예: K7QX-M2PA-9FZD-W4TR)
+func generateInviteCode() (string, error) {
+	buf := make([]byte, 10)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	encoded := base32.StdEncoding.EncodeToString(buf)
+	return encoded[0:4] + "-" + encoded[4:8] + "-" + encoded[8:12] + "-" + encoded[12:16], nil
+}
+
+// 사용자가 입력하는 코드(초대 코드, 복구 코드) 정규화(대문자, 하이픈/공백 제거) 후 SHA-256
+func hashEnteredCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// 계정의 로그인 제한 상태
+type LoginThrottle struct {
+	Failures     int       // 연속 실패 횟수
+	BlockedUntil time.Time // 다음 로그인 시도 가능 시각
+}
+
+// 로그인 시도 예약, 제한 중이 아니면 hold 동안 같은 사용자명의 다른 시도를 막고 true
+// 동시에 들어온 시도가 실패 횟수 확인을 함께 통과하지 않도록 시도를 한 번에 하나씩 처리
+// 제한 중이면 현재 제한 상태와 false
+func BeginLoginAttempt(username string, hold time.Duration) (LoginThrottle, bool, error) {
+	now := time.Now()
+	var throttle LoginThrottle
+	err := db.QueryRow(`
+		INSERT INTO login_attempts(username, blocked_until) VALUES(?, ?)
+		ON CONFLICT(username) DO UPDATE SET blocked_until = excluded.blocked_until
+		WHERE login_attempts.blocked_until <= ?
+		RETURNING failed_count`,
+		username, now.Add(hold).Unix(), now.Unix(),
+	).Scan(&throttle.Failures)
+	if err == nil {
+		return throttle, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return throttle, false, err
+	}
+
+	var blockedUntil int64
+	err = db.QueryRow("SELECT failed_count, blocked_until FROM login_attempts WHERE username = ?", username).Scan(&throttle.Failures, &blockedUntil)
+	throttle.BlockedUntil = time.Unix(blockedUntil, 0)
+	return throttle, false, err
+}
+
+// 로그인 실패 기록 후 연속 실패 횟수 반환, 마지막 실패가 window보다 오래되었으면 다시 1부터 셈
+// 제한이 끝나고 window가 지난 기록은 이때 정리
+func RecordLoginFailure(username string, window time.Duration) (int, error) {
+	now := time.Now()
+	expired := now.Add(-window).Unix()
+	if _, err := db.Exec(
+		"DELETE FROM login_attempts WHERE blocked_until < ? AND (last_failed_at IS NULL OR last_failed_at < ?)",
+		expired, expired,
+	); err != nil {
+		return 0, err
+	}
+
+	var failures int
+	err := db.QueryRow(`
+		INSERT INTO login_attempts(username, failed_count, last_failed_at, blocked_until) VALUES(?, 1, ?, ?)
+		ON CONFLICT(username) DO UPDATE SET
+			failed_count = CASE WHEN login_attempts.last_failed_at < ? THEN 1 ELSE login_attempts.failed_count + 1 END,
+			last_failed_at = excluded.last_failed_at
+		RETURNING failed_count`,
+		username, now.Unix(), now.Unix(), expired,
+	).Scan(&failures)
+	return failures, err
+}
+
+// 다음 로그인 시도 가능 시각 지정 (실패 후 대기, 잠금, 예약 해제)
+func BlockLogin(username string, until time.Time) error {
+	_, err := db.Exec("UPDATE login_attempts SET blocked_until = ? WHERE username = ?", until.Unix(), username)
+	return err
+}
+
+// 로그인 제한 해제 (로그인 성공, 관리자 잠금 해제)
+func ClearLoginFailures(username string) error {
+	_, err := db.Exec("DELETE FROM login_attempts WHERE username = ?", username)
+	return err
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"time"
+)
+
+// 2단계 인증 복구 코드 개수
+const mfaRecoveryCodeCount = 10
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+	// 이미 사용한 TOTP 시간 단계의 코드 (같은 코드 재사용)
+	ErrTOTPStepUsed = errors.New("totp code already used")
+)
+
+// 사용자의 TOTP 비밀 키와 사용 여부, 등록 대기 중이면 Secret만 있고 Enabled는 false
+type MFASecret struct {
+	Secret  string
+	Enabled bool
+}
+
+// 사용자의 TOTP 비밀 키 조회, 없으면 Secret이 빈 값
+func GetMFASecret(userID int) (MFASecret, error) {
+	var secret sql.NullString
+	var state MFASecret
+	err := db.QueryRow("SELECT mfa_secret, mfa_enabled_at IS NOT NULL FROM users WHERE id = ?", userID).Scan(&secret, &state.Enabled)
+	state.Secret = secret.String
+	return state, err
+}
+
+// 등록 대기 중인 TOTP 비밀 키 저장 (다시 등록하면 이전 대기 키를 대체), 이미 사용 중이면 ErrMFAAlreadyEnabled
+func SetPendingMFASecret(userID int, secret string) error {
+	result, err := db.Exec(
+		"UPDATE users SET mfa_secret = ?, mfa_last_step = NULL WHERE id = ? AND mfa_enabled_at IS NULL",
+		secret, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if checkRowsAffected(result) != nil {
+		return ErrMFAAlreadyEnabled
+	}
+	return nil
+}
+
+// 등록 확인 후 2단계 인증 사용 시작, 확인에 사용한 시간 단계를 기록하고 새 복구 코드 원문 반환 (DB에는 해시만 저장)
+func EnableMFA(userID int, step int64) ([]string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		"UPDATE users SET mfa_enabled_at = ?, mfa_last_step = ? WHERE id = ? AND mfa_secret IS NOT NULL AND mfa_enabled_at IS NULL",
+		time.Now(), step, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if checkRowsAffected(result) != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	codes, err := replaceRecoveryCodes(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return codes, tx.Commit()
+}
+
+// 2단계 인증 해제, 비밀 키와 복구 코드 삭제
+func DisableMFA(userID int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE users SET mfa_secret = NULL, mfa_enabled_at = NULL, mfa_last_step = NULL WHERE id = ?", userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM mfa_recovery_codes WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// TOTP 시간 단계 사용 처리, 마지막으로 사용한 단계 이하의 코드는 ErrTOTPStepUsed (코드 재전송 공격 방지)
+func UseTOTPStep(userID int, step int64) error {
+	result, err := db.Exec(
+		"UPDATE users SET mfa_last_step = ? WHERE id = ? AND (mfa_last_step IS NULL OR mfa_last_step < ?)",
+		step, userID, step,
+	)
+	if err != nil {
+		return err
+	}
+	if checkRowsAffected(result) != nil {
+		return ErrTOTPStepUsed
+	}
+	return nil
+}
+
+// 복구 코드 사용 처리 (한 번만 사용 가능), 없거나 이미 사용한 코드는 sql.ErrNoRows
+func UseRecoveryCode(userID int, code string) error {
+	result, err := db.Exec(
+		"UPDATE mfa_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL",
+		time.Now(), userID, hashEnteredCode(code),
+	)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 사용하지 않은 복구 코드 개수
+func CountRecoveryCodes(userID int) (int, error) {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = ? AND used_at IS NULL", userID).Scan(&count)
+	return count, err
+}
+
+func replaceRecoveryCodes(exec execer, userID int) ([]string, error) {
+	if _, err := exec.Exec("DELETE FROM mfa_recovery_codes WHERE user_id = ?", userID); err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, mfaRecoveryCodeCount)
+	for range mfaRecoveryCodeCount {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := exec.Exec("INSERT INTO mfa_recovery_codes(user_id, code_hash) VALUES(?, ?)", userID, hashEnteredCode(code)); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// 10자리 무작위 복구 코드 (50비트, 예: 7KQ2M-XP4RZ)
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 7)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	encoded := base32.StdEncoding.EncodeToString(buf)
+	return encoded[0:5] + "-" + encoded[5:10], nil
+}
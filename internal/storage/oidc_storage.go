@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// state가 없거나 만료, 이미 사용되었거나 다른 공급자의 state인 경우
+var ErrOIDCStateInvalid = errors.New("invalid oidc state")
+
+// SSO 로그인 요청의 PKCE code_verifier와 nonce
+type OIDCLoginState struct {
+	CodeVerifier string
+	Nonce        string
+}
+
+// SSO 공급자에서 확인된 사용자와 매핑된 역할, 조직
+type SSOAccount struct {
+	Provider string
+	Subject  string
+	Username string
+	Name     string
+	Role     string
+	OrgID    *int
+}
+
+// SSO 로그인 요청 저장 후 state 반환 (DB에는 state 해시만 저장), 만료된 요청은 이때 정리
+func CreateOIDCLoginState(provider string, loginState OIDCLoginState, ttl time.Duration) (string, error) {
+	if _, err := db.Exec("DELETE FROM oidc_login_states WHERE expires_at < ?", time.Now().Unix()); err != nil {
+		return "", err
+	}
+	state, err := generateSecretToken()
+	if err != nil {
+		return "", err
+	}
+	_, err = db.Exec(
+		"INSERT INTO oidc_login_states(state_hash, provider, code_verifier, nonce, expires_at) VALUES(?, ?, ?, ?, ?)",
+		hashSecretToken(state), provider, loginState.CodeVerifier, loginState.Nonce, time.Now().Add(ttl).Unix(),
+	)
+	if err != nil {
+		return "", err
+	}
+	return state, nil
+}
+
+// SSO 로그인 요청 사용, 조회와 동시에 삭제하므로 같은 state는 한 번만 사용 가능
+func ConsumeOIDCLoginState(state, provider string) (OIDCLoginState, error) {
+	var loginState OIDCLoginState
+	var stateProvider string
+	var expiresAt int64
+	err := db.QueryRow(
+		"DELETE FROM oidc_login_states WHERE state_hash = ? RETURNING provider, code_verifier, nonce, expires_at",
+		hashSecretToken(state),
+	).Scan(&stateProvider, &loginState.CodeVerifier, &loginState.Nonce, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return loginState, ErrOIDCStateInvalid
+	}
+	if err != nil {
+		return loginState, err
+	}
+	if stateProvider != provider || time.Now().Unix() >= expiresAt {
+		return loginState, ErrOIDCStateInvalid
+	}
+	return loginState, nil
+}
+
+// SSO 사용자 계정 생성 또는 연결, IdP의 그룹 매핑에 따라 역할과 조직을 매 로그인마다 갱신
+// 처음 로그인하면 link가 true이고 같은 사용자명의 계정이 있으면 그 계정과 연결, 아니면 새 계정 생성 (사용자명 중복 시 ErrUsernameExists)
+// SSO로 생성한 계정은 비밀번호가 없어 /login으로 로그인할 수 없음
+func ProvisionSSOUser(account SSOAccount, link bool) (models.User, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return models.User{}, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var userID int
+	err = tx.QueryRow("SELECT user_id FROM user_identities WHERE provider = ? AND subject = ?", account.Provider, account.Subject).Scan(&userID)
+	switch {
+	case err == nil:
+		if _, err := tx.Exec("UPDATE user_identities SET last_login_at = ? WHERE provider = ? AND subject = ?", now, account.Provider, account.Subject); err != nil {
+			return models.User{}, err
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		userID, err = linkOrCreateSSOUser(tx, account, link)
+		if err != nil {
+			return models.User{}, err
+		}
+		if _, err := tx.Exec(
+			"INSERT INTO user_identities(provider, subject, user_id, created_at, last_login_at) VALUES(?, ?, ?, ?, ?)",
+			account.Provider, account.Subject, userID, now, now,
+		); err != nil {
+			return models.User{}, err
+		}
+	default:
+		return models.User{}, err
+	}
+
+	// 조직이 바뀌면 이전 조직의 그룹 배정 해제
+	if _, err := tx.Exec(
+		"UPDATE users SET role = ?, group_id = CASE WHEN org_id IS ? THEN group_id ELSE NULL END, org_id = ? WHERE id = ?",
+		account.Role, account.OrgID, account.OrgID, userID,
+	); err != nil {
+		return models.User{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return models.User{}, err
+	}
+	return GetUserByID(userID)
+}
+
+func linkOrCreateSSOUser(tx *sql.Tx, account SSOAccount, link bool) (int, error) {
+	if link {
+		var userID int
+		err := tx.QueryRow("SELECT id FROM users WHERE username = ?", account.Username).Scan(&userID)
+		if err == nil || !errors.Is(err, sql.ErrNoRows) {
+			return userID, err
+		}
+	}
+	return insertUser(tx, models.User{
+		Username: account.Username,
+		Role:     account.Role,
+		OrgID:    account.OrgID,
+		Profile:  models.UserProfile{Name: account.Name},
+	})
+}
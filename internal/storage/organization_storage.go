@@ -0,0 +1,297 @@
+package storage
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"database/sql"
+	"errors"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+var (
+	ErrOrganizationExists    = errors.New("organization name already exists")
+	ErrInvitationPending     = errors.New("invitation already pending")
+	ErrInvitationNotPending  = errors.New("invitation is not pending")
+	ErrAlreadyInOrganization = errors.New("user already belongs to an organization")
+)
+
+// 조직 도입 이전 DB에서 사용하는 기본 조직 이름
+const defaultOrganizationName = "기본 조직"
+
+const selectOrganizationColumns = `SELECT id, name, scenarios, enabled_scenarios, mfa_required_roles, org_mfa_required_roles, created_at FROM organizations`
+
+// 조직 생성, 생성된 조직 반환 (허용 시나리오는 제한 없음)
+func CreateOrganization(name string) (models.Organization, error) {
+	org := models.Organization{Name: name, CreatedAt: time.Now()}
+	res, err := db.Exec("INSERT INTO organizations(name, created_at) VALUES(?, ?)", org.Name, org.CreatedAt)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == 2067 {
+			return org, ErrOrganizationExists
+		}
+		return org, err
+	}
+	id, err := res.LastInsertId()
+	org.ID = int(id)
+	return org, err
+}
+
+// ID로 조직 조회, 없으면 sql.ErrNoRows
+func GetOrganizationByID(id int) (models.Organization, error) {
+	return scanOrganization(db.QueryRow(selectOrganizationColumns+" WHERE id = ?", id))
+}
+
+// 이름으로 조직 조회, 없으면 sql.ErrNoRows
+func GetOrganizationByName(name string) (models.Organization, error) {
+	return scanOrganization(db.QueryRow(selectOrganizationColumns+" WHERE name = ?", name))
+}
+
+func GetOrganizations() ([]models.Organization, error) {
+	rows, err := db.Query(selectOrganizationColumns + " ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orgs := []models.Organization{}
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, rows.Err()
+}
+
+func scanOrganization(row rowScanner) (models.Organization, error) {
+	var org models.Organization
+	var scenarios, enabledScenarios, mfaRequiredRoles, orgMFARoles sql.NullString
+	if err := row.Scan(&org.ID, &org.Name, &scenarios, &enabledScenarios, &mfaRequiredRoles, &orgMFARoles, &org.CreatedAt); err != nil {
+		return org, err
+	}
+	org.MFARequiredRoles = decodeStringList(mfaRequiredRoles.String)
+	if org.MFARequiredRoles == nil {
+		org.MFARequiredRoles = []string{}
+	}
+	org.OrgMFARoles = decodeStringList(orgMFARoles.String)
+	if org.OrgMFARoles == nil {
+		org.OrgMFARoles = []string{}
+	}
+	org.Scenarios = decodeScenarioList(scenarios)
+	org.EnabledScenarios = decodeScenarioList(enabledScenarios)
+	return org, nil
+}
+
+// NULL이면 제한 없음(nil), 빈 배열이면 허용된 시나리오 없음
+func decodeScenarioList(value sql.NullString) []string {
+	if !value.Valid {
+		return nil
+	}
+	if scenarios := decodeStringList(value.String); scenarios != nil {
+		return scenarios
+	}
+	return []string{}
+}
+
+func encodeScenarioList(scenarios []string) any {
+	if scenarios == nil {
+		return nil
+	}
+	return encodeStringList(scenarios)
+}
+
+// 관리자가 조직에 허용하는 시나리오 변경, scenarios가 nil이면 모든 활성 시나리오 허용
+func UpdateOrganizationScenarios(orgID int, scenarios []string) error {
+	result, err := db.Exec("UPDATE organizations SET scenarios = ? WHERE id = ?", encodeScenarioList(scenarios), orgID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 조직 관리자가 허용 시나리오 중 구성원이 사용할 시나리오 변경, scenarios가 nil이면 허용 시나리오 전체
+func UpdateOrganizationEnabledScenarios(orgID int, scenarios []string) error {
+	result, err := db.Exec("UPDATE organizations SET enabled_scenarios = ? WHERE id = ?", encodeScenarioList(scenarios), orgID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 관리자가 지정하는 조직의 2단계 인증 필수 역할 변경
+func UpdateOrganizationMFAPolicy(orgID int, roles []string) error {
+	result, err := db.Exec("UPDATE organizations SET mfa_required_roles = ? WHERE id = ?", encodeStringList(roles), orgID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 조직 관리자가 추가로 지정하는 2단계 인증 필수 역할 변경
+func UpdateOrganizationOrgMFARoles(orgID int, roles []string) error {
+	result, err := db.Exec("UPDATE organizations SET org_mfa_required_roles = ? WHERE id = ?", encodeStringList(roles), orgID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+const selectInvitationColumns = `
+	SELECT i.id, i.org_id, o.name, u.username, i.role, i.group_id, i.invited_by, i.created_at, i.accepted_at
+	FROM organization_invitations i
+	JOIN organizations o ON o.id = i.org_id
+	JOIN users u ON u.id = i.user_id`
+
+// 조직 초대 생성, 같은 조직의 대기 중인 초대가 있으면 ErrInvitationPending
+func CreateInvitation(orgID, userID int, role string, groupID *int, invitedBy int) (models.OrganizationInvitation, error) {
+	var pending int
+	if err := db.QueryRow(
+		"SELECT COUNT(*) FROM organization_invitations WHERE org_id = ? AND user_id = ? AND accepted_at IS NULL",
+		orgID, userID,
+	).Scan(&pending); err != nil {
+		return models.OrganizationInvitation{}, err
+	}
+	if pending > 0 {
+		return models.OrganizationInvitation{}, ErrInvitationPending
+	}
+
+	res, err := db.Exec(
+		"INSERT INTO organization_invitations(org_id, user_id, role, group_id, invited_by, created_at) VALUES(?, ?, ?, ?, ?, ?)",
+		orgID, userID, role, groupID, invitedBy, time.Now(),
+	)
+	if err != nil {
+		return models.OrganizationInvitation{}, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return models.OrganizationInvitation{}, err
+	}
+	return GetInvitationByID(int(id))
+}
+
+// ID로 초대 조회, 없으면 sql.ErrNoRows
+func GetInvitationByID(id int) (models.OrganizationInvitation, error) {
+	return scanInvitation(db.QueryRow(selectInvitationColumns+" WHERE i.id = ?", id))
+}
+
+// 조직이 보낸 초대 목록 (최신순)
+func GetInvitationsByOrg(orgID int) ([]models.OrganizationInvitation, error) {
+	return queryInvitations(selectInvitationColumns+" WHERE i.org_id = ? ORDER BY i.created_at DESC", orgID)
+}
+
+// 사용자가 받은 대기 중인 초대 목록 (최신순)
+func GetPendingInvitationsByUserID(userID int) ([]models.OrganizationInvitation, error) {
+	return queryInvitations(selectInvitationColumns+" WHERE i.user_id = ? AND i.accepted_at IS NULL ORDER BY i.created_at DESC", userID)
+}
+
+func queryInvitations(query string, args ...any) ([]models.OrganizationInvitation, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []models.OrganizationInvitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, rows.Err()
+}
+
+func scanInvitation(row rowScanner) (models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	var groupID sql.NullInt64
+	var acceptedAt sql.NullTime
+	if err := row.Scan(
+		&invitation.ID, &invitation.OrgID, &invitation.OrgName, &invitation.Username, &invitation.Role,
+		&groupID, &invitation.InvitedBy, &invitation.CreatedAt, &acceptedAt,
+	); err != nil {
+		return invitation, err
+	}
+	invitation.GroupID = nullIntPtr(groupID)
+	if acceptedAt.Valid {
+		invitation.AcceptedAt = &acceptedAt.Time
+	}
+	return invitation, nil
+}
+
+// 초대 수락, 사용자를 초대한 조직에 소속시키고 초대에 지정된 역할과 그룹을 적용
+// 이미 수락된 초대는 ErrInvitationNotPending, 다른 조직에 소속된 사용자는 ErrAlreadyInOrganization
+func AcceptInvitation(invitationID, userID int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var orgID int
+	var role string
+	var groupID sql.NullInt64
+	err = tx.QueryRow(
+		"SELECT org_id, role, group_id FROM organization_invitations WHERE id = ? AND user_id = ? AND accepted_at IS NULL",
+		invitationID, userID,
+	).Scan(&orgID, &role, &groupID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvitationNotPending
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(
+		"UPDATE users SET org_id = ?, role = ?, group_id = ? WHERE id = ? AND org_id IS NULL",
+		orgID, role, nullIntPtr(groupID), userID,
+	)
+	if err != nil {
+		return err
+	}
+	if err := checkRowsAffected(result); err != nil {
+		return ErrAlreadyInOrganization
+	}
+	if _, err := tx.Exec("UPDATE organization_invitations SET accepted_at = ? WHERE id = ?", time.Now(), invitationID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// 조직 도입 이전 DB: 조직이 하나도 없고 사용자가 있으면 기본 조직을 만들어 기존 사용자, 그룹, 기록을 배정
+// 기존 강사의 그룹 단위 조회 권한이 조직 도입 후에도 유지되도록 함
+func backfillDefaultOrganization() error {
+	var orgs, users int
+	if err := db.QueryRow("SELECT COUNT(*) FROM organizations").Scan(&orgs); err != nil {
+		return err
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM users WHERE org_id IS NULL").Scan(&users); err != nil {
+		return err
+	}
+	if orgs > 0 || users == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("INSERT INTO organizations(name, created_at) VALUES(?, ?)", defaultOrganizationName, time.Now())
+	if err != nil {
+		return err
+	}
+	orgID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for _, table := range []string{"users", "groups", "records"} {
+		if _, err := tx.Exec("UPDATE "+table+" SET org_id = ? WHERE org_id IS NULL", orgID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// 재설정 토큰이 없거나 만료, 이미 사용된 경우
+var ErrPasswordResetTokenInvalid = errors.New("invalid password reset token")
+
+// 비밀번호 변경 후 사용자의 기존 세션 무효화
+func UpdatePassword(userID int, passwordHash string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updatePassword(tx, userID, passwordHash); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// 1회용 비밀번호 재설정 토큰 발급 (관리자), 사용자의 이전 재설정 토큰은 폐기
+// 토큰 원문은 반환값에만 포함되고 DB에는 해시만 저장, 만료된 토큰은 이때 정리
+func CreatePasswordResetToken(userID, createdBy int, ttl time.Duration) (string, error) {
+	if _, err := db.Exec("DELETE FROM password_reset_tokens WHERE user_id = ? OR expires_at < ?", userID, time.Now().Unix()); err != nil {
+		return "", err
+	}
+	token, err := generateSecretToken()
+	if err != nil {
+		return "", err
+	}
+	_, err = db.Exec(
+		"INSERT INTO password_reset_tokens(token_hash, user_id, created_by, expires_at) VALUES(?, ?, ?, ?)",
+		hashSecretToken(token), userID, createdBy, time.Now().Add(ttl).Unix(),
+	)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// 재설정 토큰의 사용자 조회 (토큰은 사용하지 않음), 유효하지 않으면 ErrPasswordResetTokenInvalid
+func GetPasswordResetTokenUser(token string) (models.User, error) {
+	user, err := scanUser(db.QueryRow(
+		selectUserColumns+" WHERE id = (SELECT user_id FROM password_reset_tokens WHERE token_hash = ? AND expires_at >= ?)",
+		hashSecretToken(token), time.Now().Unix(),
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, ErrPasswordResetTokenInvalid
+	}
+	return user, err
+}
+
+// 재설정 토큰을 사용하여 비밀번호 변경 (토큰은 한 번만 사용 가능), 유효하지 않으면 ErrPasswordResetTokenInvalid
+func ResetPasswordWithToken(token string, passwordHash string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	var expiresAt int64
+	err = tx.QueryRow(
+		"DELETE FROM password_reset_tokens WHERE token_hash = ? RETURNING user_id, expires_at",
+		hashSecretToken(token),
+	).Scan(&userID, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && time.Now().Unix() > expiresAt) {
+		return ErrPasswordResetTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+	if err := updatePassword(tx, userID, passwordHash); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// 비밀번호 변경 및 기존 세션 무효화
+// tokens_valid_after 이전에 발급된 액세스 토큰은 폐기된 것으로 처리 (IsAccessTokenRevoked)
+// 리프레시 토큰, 1회용 티켓, 재설정 토큰도 모두 폐기
+func updatePassword(exec execer, userID int, passwordHash string) error {
+	now := time.Now()
+	result, err := exec.Exec("UPDATE users SET password_hash = ?, tokens_valid_after = ? WHERE id = ?", passwordHash, now.Unix(), userID)
+	if err != nil {
+		return err
+	}
+	if err := checkRowsAffected(result); err != nil {
+		return err
+	}
+	if _, err := exec.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL", now, userID); err != nil {
+		return err
+	}
+	if _, err := exec.Exec("DELETE FROM access_tickets WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+	_, err = exec.Exec("DELETE FROM password_reset_tokens WHERE user_id = ?", userID)
+	return err
+}
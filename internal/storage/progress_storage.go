@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"database/sql"
+	"time"
+)
+
+// 평가가 끝난 세션을 사용자 진행 기록에 반영
+// passed가 false(치명 항목 발견, 사기 성공)이면 시도 횟수와 최근 점수만 갱신하고 최고 점수에는 반영하지 않음
+func RecordScenarioProgress(userID int, scenarioKey string, recordID int, score int, passed bool) error {
+	var bestScore sql.NullInt64
+	if passed {
+		bestScore = sql.NullInt64{Int64: int64(score), Valid: true}
+	}
+	_, err := db.Exec(`
+		INSERT INTO user_progress(user_id, scenario_key, attempts, best_score, last_score, last_record_id, updated_at)
+		VALUES(?, ?, 1, ?, ?, ?, ?)
+		ON CONFLICT(user_id, scenario_key) DO UPDATE SET
+			attempts = attempts + 1,
+			best_score = CASE
+				WHEN excluded.best_score IS NOT NULL AND (best_score IS NULL OR excluded.best_score > best_score) THEN excluded.best_score
+				ELSE best_score
+			END,
+			last_score = excluded.last_score,
+			last_record_id = excluded.last_record_id,
+			updated_at = excluded.updated_at
+	`, userID, scenarioKey, bestScore, score, recordID, time.Now())
+	return err
+}
+
+// 사용자의 시나리오별 진행 기록 조회 (시나리오 키 기준)
+func GetUserProgress(userID int) (map[string]models.ScenarioProgress, error) {
+	rows, err := db.Query(`
+		SELECT user_id, scenario_key, attempts, best_score, last_score, last_record_id, updated_at
+		FROM user_progress
+		WHERE user_id = ?
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progress := make(map[string]models.ScenarioProgress)
+	for rows.Next() {
+		var p models.ScenarioProgress
+		var bestScore, lastScore, lastRecordID sql.NullInt64
+		if err := rows.Scan(&p.UserID, &p.ScenarioKey, &p.Attempts, &bestScore, &lastScore, &lastRecordID, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		p.BestScore = nullIntPtr(bestScore)
+		p.LastScore = nullIntPtr(lastScore)
+		p.LastRecordID = nullIntPtr(lastRecordID)
+		progress[p.ScenarioKey] = p
+	}
+	return progress, rows.Err()
+}
+
+// 진행 기록 테이블 추가 이전에 평가된 기록으로 진행 기록 생성 (이미 있는 항목은 유지)
+func backfillUserProgress() error {
+	_, err := db.Exec(`
+		INSERT OR IGNORE INTO user_progress(user_id, scenario_key, attempts, best_score, last_score, last_record_id, updated_at)
+		SELECT r.user_id, r.scenario_key, COUNT(*),
+			MAX(CASE WHEN json_extract(r.report, '$.passed') = 1 THEN r.score END),
+			(SELECT l.score FROM records l
+				WHERE l.user_id = r.user_id AND l.scenario_key = r.scenario_key AND l.score IS NOT NULL
+				ORDER BY l.id DESC LIMIT 1),
+			MAX(r.id), ?
+		FROM records r
+		WHERE r.score IS NOT NULL AND r.scenario_key IS NOT NULL
+		GROUP BY r.user_id, r.scenario_key
+	`, time.Now())
+	return err
+}
+
+func nullIntPtr(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	v := int(value.Int64)
+	return &v
+}
@@ -2,28 +2,38 @@ package storage
 
 import (
 	"PishingSimulator_SecurityProject/internal/models"
+	"database/sql"
+	"encoding/json"
+	"log"
 	"time"
 )
 
-func CreateRecords(userID int, scenarioKey string, filePath string) error {
-	stmt, err := db.Prepare("INSERT INTO records(user_id, scenario_key, file_path, created_at) VALUES(?, ?, ?, ?)")
+const selectRecordColumns = `SELECT id, user_id, org_id, scenario_key, mode, file_path, duration_ms, outcome, final_state, coach, score, created_at FROM records`
+
+// 시뮬레이션 기록 저장, 생성된 기록 ID 반환 (ID, CreatedAt은 무시됨)
+func CreateRecords(record models.Record) (int, error) {
+	stmt, err := db.Prepare("INSERT INTO records(user_id, org_id, scenario_key, mode, file_path, duration_ms, outcome, final_state, coach, created_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(userID, scenarioKey, filePath, time.Now())
-	return err
+	res, err := stmt.Exec(record.UserID, record.OrgID, record.Scenario, record.Mode, record.FilePath, record.DurationMs, record.Outcome, record.FinalState, record.Coach, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	return int(id), err
 }
 
-func GetRecordsByUserID(userID int) ([]models.Record, error) {
-	query := `
-		SELECT id, scenario_key, file_path, created_at 
-		FROM records 
-		WHERE user_id = ? 
+// 사용자의 기록을 최신순으로 조회, mode가 비어 있으면 모든 모드
+// orgID가 nil이 아니면 해당 조직 소속일 때 진행한 기록만 (다른 사용자의 기록을 조회하는 강사, 조직 관리자용)
+func GetRecordsByUserID(userID int, orgID *int, mode string) ([]models.Record, error) {
+	query := selectRecordColumns + `
+		WHERE user_id = ? AND (? IS NULL OR org_id = ?) AND (? = '' OR mode = ?)
 		ORDER BY created_at DESC
 	`
-	rows, err := db.Query(query, userID)
+	rows, err := db.Query(query, userID, orgID, orgID, mode, mode)
 	if err != nil {
 		return nil, err
 	}
@@ -31,18 +41,71 @@ func GetRecordsByUserID(userID int) ([]models.Record, error) {
 
 	var records []models.Record
 	for rows.Next() {
-		var r models.Record
-		var createdStr string // SQLite는 시간을 문자열로 저장함
-
-		if err := rows.Scan(&r.ID, &r.Scenario, &r.FilePath, &createdStr); err != nil {
+		r, err := scanRecord(rows)
+		if err != nil {
 			return nil, err
 		}
-
-		// 시간 파싱 (SQLite 포맷에 따라 수정 필요할 수 있음)
-		parsedTime, _ := time.Parse("2006-01-02 15:04:05", createdStr)
-		r.CreatedAt = parsedTime
-
 		records = append(records, r)
 	}
 	return records, nil
 }
+
+// ID로 기록 조회, 없으면 sql.ErrNoRows
+func GetRecordByID(id int) (models.Record, error) {
+	return scanRecord(db.QueryRow(selectRecordColumns+` WHERE id = ?`, id))
+}
+
+// 녹음 파일 경로로 기록 조회
+func GetRecordByFilePath(filePath string) (models.Record, error) {
+	return scanRecord(db.QueryRow(selectRecordColumns+` WHERE file_path = ? AND file_path <> ''`, filePath))
+}
+
+func scanRecord(row rowScanner) (models.Record, error) {
+	var r models.Record
+	var nullScenario, nullOutcome, nullFinalState sql.NullString
+	var nullOrgID, nullScore sql.NullInt64
+
+	// created_at은 드라이버가 time.Time으로 변환함
+	if err := row.Scan(&r.ID, &r.UserID, &nullOrgID, &nullScenario, &r.Mode, &r.FilePath, &r.DurationMs, &nullOutcome, &nullFinalState, &r.Coach, &nullScore, &r.CreatedAt); err != nil {
+		return r, err
+	}
+	r.OrgID = nullIntPtr(nullOrgID)
+	if nullScore.Valid {
+		score := int(nullScore.Int64)
+		r.Score = &score
+	}
+	r.Scenario = nullScenario.String
+	r.Outcome = nullOutcome.String
+	r.FinalState = nullFinalState.String
+	return r, nil
+}
+
+// 평가 리포트를 기록에 저장 (기존 리포트는 덮어씀)
+func SaveRecordReport(report models.EvaluationReport) error {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	res, err := db.Exec(`UPDATE records SET score = ?, report = ? WHERE id = ?`, report.Score, string(encoded), report.RecordID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(res)
+}
+
+// 기록의 평가 리포트 조회, 아직 평가되지 않았으면 nil
+func GetRecordReport(recordID int) (*models.EvaluationReport, error) {
+	var encoded sql.NullString
+	if err := db.QueryRow(`SELECT report FROM records WHERE id = ?`, recordID).Scan(&encoded); err != nil {
+		return nil, err
+	}
+	if !encoded.Valid || encoded.String == "" {
+		return nil, nil
+	}
+	var report models.EvaluationReport
+	if err := json.Unmarshal([]byte(encoded.String), &report); err != nil {
+		log.Printf("GetRecordReport(): invalid report for record %d: %v", recordID, err)
+		return nil, nil
+	}
+	return &report, nil
+}
@@ -0,0 +1,313 @@
+package storage
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+var (
+	ErrScenarioExists   = errors.New("scenario key already exists")
+	ErrScenarioNotOwned = errors.New("scenario key is owned by an admin-created scenario")
+)
+
+const insertScenarioQuery = `INSERT INTO scenarios(
+		scenario_key, name, description, modes, difficulty, estimated_minutes,
+		persona, opening_line, goals, forbidden_topics, voice, temperature,
+		script, hints, source, enabled, created_at, updated_at
+	) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+// INSERT 쿼리 파라미터 (insertScenarioQuery 컬럼 순서)
+func scenarioInsertArgs(scenario models.Scenario, now time.Time) []any {
+	return []any{
+		scenario.Key, scenario.Name, scenario.Description,
+		joinModes(scenario.Modes), scenario.Difficulty, scenario.EstimatedMinutes,
+		scenario.Persona, scenario.OpeningLine, encodeStringList(scenario.Goals), encodeStringList(scenario.ForbiddenTopics),
+		scenario.Voice, scenario.Temperature,
+		encodeScript(scenario.Script), encodeHints(scenario.Hints), scenario.Source, scenario.Enabled, now, now,
+	}
+}
+
+// 기본 시나리오를 DB에 등록, 이미 존재하는 키는 건드리지 않음
+func seedScenarios() error {
+	stmt, err := db.Prepare(strings.Replace(insertScenarioQuery, "INSERT INTO", "INSERT OR IGNORE INTO", 1))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, scenario := range models.DefaultScenarios() {
+		if _, err := stmt.Exec(scenarioInsertArgs(scenario, now)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func CreateScenario(scenario models.Scenario) error {
+	stmt, err := db.Prepare(insertScenarioQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(scenarioInsertArgs(scenario, time.Now())...)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) {
+			if sqliteErr.Code() == 1555 || sqliteErr.Code() == 2067 {
+				return ErrScenarioExists
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// 시나리오 팩 파일에서 로드된 시나리오 등록, 같은 키가 있으면 파일 내용으로 덮어씀
+// 관리자가 API로 생성한 시나리오는 덮어쓰지 않고 ErrScenarioNotOwned 반환
+func UpsertScenario(scenario models.Scenario) error {
+	query := insertScenarioQuery + `
+	ON CONFLICT(scenario_key) DO UPDATE SET
+		name = excluded.name, description = excluded.description,
+		modes = excluded.modes, difficulty = excluded.difficulty, estimated_minutes = excluded.estimated_minutes,
+		persona = excluded.persona, opening_line = excluded.opening_line,
+		goals = excluded.goals, forbidden_topics = excluded.forbidden_topics,
+		voice = excluded.voice, temperature = excluded.temperature, script = excluded.script,
+		hints = excluded.hints, source = excluded.source, enabled = excluded.enabled, updated_at = excluded.updated_at
+	WHERE scenarios.source IS NOT ?`
+
+	args := append(scenarioInsertArgs(scenario, time.Now()), models.ScenarioSourceAdmin)
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrScenarioNotOwned
+	}
+	return nil
+}
+
+// 시나리오 수정, 해당 키가 없으면 sql.ErrNoRows 반환
+func UpdateScenario(scenario models.Scenario) error {
+	result, err := db.Exec(`UPDATE scenarios SET
+			name = ?, description = ?, modes = ?, difficulty = ?, estimated_minutes = ?,
+			persona = ?, opening_line = ?, goals = ?, forbidden_topics = ?, voice = ?, temperature = ?,
+			script = ?, hints = ?, enabled = ?, updated_at = ?
+		WHERE scenario_key = ?`,
+		scenario.Name, scenario.Description,
+		joinModes(scenario.Modes), scenario.Difficulty, scenario.EstimatedMinutes,
+		scenario.Persona, scenario.OpeningLine, encodeStringList(scenario.Goals), encodeStringList(scenario.ForbiddenTopics),
+		scenario.Voice, scenario.Temperature,
+		encodeScript(scenario.Script), encodeHints(scenario.Hints), scenario.Enabled, time.Now(), scenario.Key,
+	)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 시나리오 비활성화 (기존 기록의 scenario_key 참조 유지를 위해 삭제하지 않음)
+func DisableScenario(scenarioKey string) error {
+	result, err := db.Exec("UPDATE scenarios SET enabled = 0, updated_at = ? WHERE scenario_key = ?", time.Now(), scenarioKey)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+const scenarioColumns = `scenario_key, name, description, modes, difficulty, estimated_minutes,
+	persona, opening_line, goals, forbidden_topics, voice, temperature, script, hints, source, enabled`
+
+// QueryRow와 Rows 모두에서 사용하기 위한 Scan 인터페이스
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScenario(row rowScanner) (models.Scenario, error) {
+	var s models.Scenario
+	var nullDescription, nullModes, nullDifficulty sql.NullString
+	var nullPersona, nullOpening, nullGoals, nullForbidden, nullVoice, nullScript, nullHints, nullSource sql.NullString
+	var nullMinutes sql.NullInt64
+	var nullTemperature sql.NullFloat64
+
+	if err := row.Scan(
+		&s.Key, &s.Name, &nullDescription, &nullModes, &nullDifficulty, &nullMinutes,
+		&nullPersona, &nullOpening, &nullGoals, &nullForbidden, &nullVoice, &nullTemperature, &nullScript, &nullHints, &nullSource,
+		&s.Enabled,
+	); err != nil {
+		return s, err
+	}
+	if nullDescription.Valid {
+		s.Description = nullDescription.String
+	}
+	if nullModes.Valid {
+		s.Modes = splitModes(nullModes.String)
+	}
+	if nullDifficulty.Valid {
+		s.Difficulty = nullDifficulty.String
+	}
+	if nullMinutes.Valid {
+		s.EstimatedMinutes = int(nullMinutes.Int64)
+	}
+	s.Persona = nullPersona.String
+	s.OpeningLine = nullOpening.String
+	s.Goals = decodeStringList(nullGoals.String)
+	s.ForbiddenTopics = decodeStringList(nullForbidden.String)
+	s.Voice = nullVoice.String
+	s.Script = decodeScript(nullScript.String)
+	s.Hints = decodeHints(nullHints.String)
+	s.Source = nullSource.String
+	s.Temperature = models.DefaultTemperature
+	if nullTemperature.Valid {
+		s.Temperature = nullTemperature.Float64
+	}
+	s.ApplyDefaults()
+	return s, nil
+}
+
+func GetScenarioByKey(scenarioKey string) (models.Scenario, error) {
+	row := db.QueryRow("SELECT "+scenarioColumns+" FROM scenarios WHERE scenario_key = ?", scenarioKey)
+	return scanScenario(row)
+}
+
+// 전체 시나리오 조회, includeDisabled가 false면 활성 시나리오만 반환
+func GetScenarios(includeDisabled bool) ([]models.Scenario, error) {
+	query := "SELECT " + scenarioColumns + " FROM scenarios"
+	if !includeDisabled {
+		query += " WHERE enabled = 1"
+	}
+	query += " ORDER BY scenario_key"
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scenarios := []models.Scenario{}
+	for rows.Next() {
+		s, err := scanScenario(rows)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, s)
+	}
+	return scenarios, rows.Err()
+}
+
+// models.GetScenario에 등록되는 조회 함수, 비활성 시나리오는 존재하지 않는 것으로 취급
+func lookupActiveScenario(scenarioKey string) (models.Scenario, bool) {
+	scenario, err := GetScenarioByKey(scenarioKey)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("lookupActiveScenario(): Failed to query scenario %s: %v", scenarioKey, err)
+		}
+		return scenario, false
+	}
+	return scenario, scenario.Enabled
+}
+
+func checkRowsAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// 모드 목록은 "text,voice" 형태의 문자열로 저장
+func joinModes(modes []string) string {
+	return strings.Join(modes, ",")
+}
+
+func splitModes(value string) []string {
+	modes := []string{}
+	for _, mode := range strings.Split(value, ",") {
+		if mode = strings.TrimSpace(mode); mode != "" {
+			modes = append(modes, mode)
+		}
+	}
+	return modes
+}
+
+// 목록형 필드(goals, forbidden_topics)는 JSON 배열 문자열로 저장
+func encodeStringList(values []string) string {
+	if len(values) == 0 {
+		return "[]"
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return "[]"
+	}
+	return string(encoded)
+}
+
+func decodeStringList(value string) []string {
+	var values []string
+	if value == "" {
+		return values
+	}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		log.Printf("decodeStringList(): invalid list value %q: %v", value, err)
+	}
+	return values
+}
+
+// 대화 트리는 JSON 문자열로 저장, 없으면 NULL
+func encodeScript(script *models.DialogueScript) any {
+	if script == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(script)
+	if err != nil {
+		return nil
+	}
+	return string(encoded)
+}
+
+func decodeScript(value string) *models.DialogueScript {
+	if value == "" {
+		return nil
+	}
+	var script models.DialogueScript
+	if err := json.Unmarshal([]byte(value), &script); err != nil {
+		log.Printf("decodeScript(): invalid dialogue script: %v", err)
+		return nil
+	}
+	return &script
+}
+
+// 코칭 힌트는 JSON 배열 문자열로 저장, 없으면 NULL
+func encodeHints(hints []models.CoachHint) any {
+	if len(hints) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(hints)
+	if err != nil {
+		return nil
+	}
+	return string(encoded)
+}
+
+func decodeHints(value string) []models.CoachHint {
+	if value == "" {
+		return nil
+	}
+	var hints []models.CoachHint
+	if err := json.Unmarshal([]byte(value), &hints); err != nil {
+		log.Printf("decodeHints(): invalid coach hints: %v", err)
+		return nil
+	}
+	return hints
+}
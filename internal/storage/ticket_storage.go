@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+)
+
+// 티켓이 없거나 만료, 이미 사용되었거나 다른 용도/리소스의 티켓인 경우
+var ErrAccessTicketInvalid = errors.New("invalid access ticket")
+
+// 1회용 티켓 발급, 티켓 원문은 반환값에만 포함되고 DB에는 해시만 저장
+// 만료된 티켓은 이때 정리
+func CreateAccessTicket(userID int, purpose, resource string, ttl time.Duration) (string, error) {
+	if _, err := db.Exec("DELETE FROM access_tickets WHERE expires_at < ?", time.Now().Unix()); err != nil {
+		return "", err
+	}
+	ticket, err := generateSecretToken()
+	if err != nil {
+		return "", err
+	}
+	_, err = db.Exec(
+		"INSERT INTO access_tickets(ticket_hash, user_id, purpose, resource, expires_at) VALUES(?, ?, ?, ?, ?)",
+		hashSecretToken(ticket), userID, purpose, resource, time.Now().Add(ttl).Unix(),
+	)
+	if err != nil {
+		return "", err
+	}
+	return ticket, nil
+}
+
+// 티켓 사용, 조회와 동시에 삭제하므로 같은 티켓은 한 번만 사용 가능
+// 용도와 리소스가 발급 시와 같고 만료 전이면 발급한 사용자 ID 반환, 아니면 ErrAccessTicketInvalid
+func ConsumeAccessTicket(ticket, purpose, resource string) (int, error) {
+	userID, ticketResource, err := ConsumeAccessTicketResource(ticket, purpose)
+	if err != nil {
+		return 0, err
+	}
+	if ticketResource != resource {
+		log.Printf("ConsumeAccessTicket(): Rejected %s ticket of user %d for %q", purpose, userID, resource)
+		return 0, ErrAccessTicketInvalid
+	}
+	return userID, nil
+}
+
+// 티켓 사용 (용도만 확인), 발급한 사용자 ID와 티켓의 리소스 반환
+// 리소스를 미리 알 수 없는 티켓(SSO 로그인)에 사용
+func ConsumeAccessTicketResource(ticket, purpose string) (int, string, error) {
+	var userID int
+	var ticketPurpose, ticketResource string
+	var expiresAt int64
+	err := db.QueryRow(
+		"DELETE FROM access_tickets WHERE ticket_hash = ? RETURNING user_id, purpose, resource, expires_at",
+		hashSecretToken(ticket),
+	).Scan(&userID, &ticketPurpose, &ticketResource, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", ErrAccessTicketInvalid
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	if ticketPurpose != purpose {
+		log.Printf("ConsumeAccessTicket(): Rejected %s ticket of user %d for %s", ticketPurpose, userID, purpose)
+		return 0, "", ErrAccessTicketInvalid
+	}
+	if time.Now().Unix() >= expiresAt {
+		return 0, "", ErrAccessTicketInvalid
+	}
+	return userID, ticketResource, nil
+}
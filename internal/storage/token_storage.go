@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// 리프레시 토큰이 없거나 만료, 폐기된 경우
+	ErrRefreshTokenInvalid = errors.New("invalid refresh token")
+	// 이미 교체된 리프레시 토큰이 다시 사용된 경우 (탈취 의심, 해당 세션 전체 폐기)
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// 로그인 세션의 첫 리프레시 토큰 발급, 토큰 원문은 반환값에만 포함되고 DB에는 해시만 저장
+// 같은 로그인에서 교체되며 이어지는 토큰들은 같은 family_id와 2단계 인증 여부(mfa)를 가짐
+func CreateRefreshToken(userID int, mfa bool, ttl time.Duration) (string, error) {
+	return insertRefreshToken(db, userID, uuid.NewString(), mfa, ttl)
+}
+
+func insertRefreshToken(exec execer, userID int, familyID string, mfa bool, ttl time.Duration) (string, error) {
+	token, err := generateSecretToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	_, err = exec.Exec(
+		"INSERT INTO refresh_tokens(user_id, token_hash, family_id, mfa, expires_at, created_at) VALUES(?, ?, ?, ?, ?, ?)",
+		userID, hashSecretToken(token), familyID, mfa, now.Add(ttl), now,
+	)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// 리프레시 토큰 교체 결과
+type RefreshSession struct {
+	Token  string // 새 리프레시 토큰
+	UserID int
+	MFA    bool // 2단계 인증을 거친 로그인 세션
+}
+
+// 리프레시 토큰 교체 (rotation), 사용한 토큰은 사용 처리하고 같은 세션의 새 토큰 발급
+// 이미 사용된 토큰이 다시 들어오면 세션 전체를 폐기하고 ErrRefreshTokenReused (UserID만 채워짐)
+func RotateRefreshToken(token string, ttl time.Duration) (RefreshSession, error) {
+	var session RefreshSession
+	tx, err := db.Begin()
+	if err != nil {
+		return session, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	var familyID string
+	var expiresAt time.Time
+	var usedAt, revokedAt sql.NullTime
+	err = tx.QueryRow(
+		"SELECT id, user_id, family_id, mfa, expires_at, used_at, revoked_at FROM refresh_tokens WHERE token_hash = ?",
+		hashSecretToken(token),
+	).Scan(&id, &session.UserID, &familyID, &session.MFA, &expiresAt, &usedAt, &revokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return RefreshSession{}, ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		return RefreshSession{}, err
+	}
+	if revokedAt.Valid || !time.Now().Before(expiresAt) {
+		return RefreshSession{}, ErrRefreshTokenInvalid
+	}
+
+	result, err := tx.Exec("UPDATE refresh_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL", time.Now(), id)
+	if err != nil {
+		return RefreshSession{}, err
+	}
+	if usedAt.Valid || checkRowsAffected(result) != nil {
+		if err := revokeRefreshTokenFamily(tx, familyID); err != nil {
+			return RefreshSession{}, err
+		}
+		if err := tx.Commit(); err != nil {
+			return RefreshSession{}, err
+		}
+		log.Printf("RotateRefreshToken(): Reuse detected, revoked token family %s of user %d", familyID, session.UserID)
+		return RefreshSession{UserID: session.UserID}, ErrRefreshTokenReused
+	}
+
+	session.Token, err = insertRefreshToken(tx, session.UserID, familyID, session.MFA, ttl)
+	if err != nil {
+		return RefreshSession{}, err
+	}
+	return session, tx.Commit()
+}
+
+// 리프레시 토큰이 속한 세션 전체 폐기 (로그아웃), 없는 토큰은 sql.ErrNoRows
+func RevokeRefreshTokenFamily(token string) error {
+	var familyID string
+	err := db.QueryRow("SELECT family_id FROM refresh_tokens WHERE token_hash = ?", hashSecretToken(token)).Scan(&familyID)
+	if err != nil {
+		return err
+	}
+	return revokeRefreshTokenFamily(db, familyID)
+}
+
+func revokeRefreshTokenFamily(exec execer, familyID string) error {
+	_, err := exec.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", time.Now(), familyID)
+	return err
+}
+
+// 액세스 토큰 폐기, 토큰 만료 시각까지만 보관하며 만료된 항목은 이때 정리
+func RevokeAccessToken(jti string, expiresAt time.Time) error {
+	if _, err := db.Exec("DELETE FROM revoked_tokens WHERE expires_at < ?", time.Now().Unix()); err != nil {
+		return err
+	}
+	_, err := db.Exec("INSERT OR IGNORE INTO revoked_tokens(jti, expires_at) VALUES(?, ?)", jti, expiresAt.Unix())
+	return err
+}
+
+// 폐기된 토큰인지 확인 (auth.ValidateToken에서 사용)
+// 로그아웃으로 폐기된 jti이거나 비밀번호 변경 등으로 사용자의 세션이 무효화되기 전에 발급된 토큰
+func IsAccessTokenRevoked(jti, username string, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	err := db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = ?)
+			OR EXISTS(SELECT 1 FROM users WHERE username = ? AND tokens_valid_after > ?)`,
+		jti, username, issuedAt.Unix(),
+	).Scan(&revoked)
+	return revoked, err
+}
+
+// 256비트 무작위 토큰 (URL-safe base64, 리프레시 토큰과 1회용 티켓에 사용)
+func generateSecretToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"PishingSimulator_SecurityProject/internal/models"
+	"database/sql"
+)
+
+// 세션 대화 기록 저장, 하나의 트랜잭션으로 모든 턴을 기록
+func CreateTranscriptTurns(recordID int, turns []models.TranscriptTurn) error {
+	if len(turns) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO transcript_turns(record_id, seq, speaker, text, start_ms, end_ms, confidence, hint_id) VALUES(?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, turn := range turns {
+		var confidence sql.NullFloat64
+		if turn.Confidence != nil {
+			confidence = sql.NullFloat64{Float64: *turn.Confidence, Valid: true}
+		}
+		var hintID sql.NullString
+		if turn.HintID != "" {
+			hintID = sql.NullString{String: turn.HintID, Valid: true}
+		}
+		if _, err := stmt.Exec(recordID, turn.Seq, turn.Speaker, turn.Text, turn.StartMs, turn.EndMs, confidence, hintID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func GetTranscriptByRecordID(recordID int) ([]models.TranscriptTurn, error) {
+	rows, err := db.Query(`
+		SELECT id, record_id, seq, speaker, text, start_ms, end_ms, confidence, hint_id
+		FROM transcript_turns
+		WHERE record_id = ?
+		ORDER BY seq
+	`, recordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	turns := []models.TranscriptTurn{}
+	for rows.Next() {
+		var turn models.TranscriptTurn
+		var confidence sql.NullFloat64
+		var hintID sql.NullString
+		if err := rows.Scan(&turn.ID, &turn.RecordID, &turn.Seq, &turn.Speaker, &turn.Text, &turn.StartMs, &turn.EndMs, &confidence, &hintID); err != nil {
+			return nil, err
+		}
+		if confidence.Valid {
+			turn.Confidence = &confidence.Float64
+		}
+		turn.HintID = hintID.String
+		turns = append(turns, turn)
+	}
+	return turns, rows.Err()
+}
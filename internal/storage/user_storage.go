@@ -4,6 +4,7 @@ import (
 	"PishingSimulator_SecurityProject/internal/models"
 	"database/sql"
 	"errors"
+	"slices"
 
 	"modernc.org/sqlite"
 )
@@ -11,47 +12,104 @@ import (
 var ErrUsernameExists = errors.New("username already exists")
 
 func CreateUser(username, passwordHash string, profile models.UserProfile) error {
-	stmt, err := db.Prepare("INSERT INTO users(username, password_hash, name, age, gender) VALUES(?, ?, ?, ?, ?)")
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
+	_, err := insertUser(db, models.User{Username: username, PasswordHash: passwordHash, Role: models.RoleTrainee, Profile: profile})
+	return err
+}
 
-	_, err = stmt.Exec(username, passwordHash, profile.Name, profile.Age, profile.Gender)
+// db 또는 트랜잭션
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// 사용자 생성, 생성된 사용자 ID 반환 (사용자명 중복 시 ErrUsernameExists)
+func insertUser(exec execer, user models.User) (int, error) {
+	res, err := exec.Exec(
+		`INSERT INTO users(username, password_hash, role, org_id, group_id, name, age, gender, occupation, bank, region, has_children, llm_shared_fields)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.Username, user.PasswordHash, user.Role, user.OrgID, user.GroupID,
+		user.Profile.Name, user.Profile.Age, user.Profile.Gender, user.Profile.Occupation, user.Profile.Bank, user.Profile.Region, user.Profile.HasChildren,
+		encodeLLMSharedFields(user.LLMSharedFields),
+	)
 	if err != nil {
 		var sqliteErr *sqlite.Error
 		if errors.As(err, &sqliteErr) {
 			if sqliteErr.Code() == 2067 {
-				return ErrUsernameExists
+				return 0, ErrUsernameExists
 			}
 		}
-		return err
+		return 0, err
 	}
-	return nil
+	id, err := res.LastInsertId()
+	return int(id), err
 }
 
+const selectUserColumns = `SELECT id, username, password_hash, role, org_id, group_id, name, age, gender, occupation, bank, region, has_children, llm_shared_fields, mfa_enabled_at IS NOT NULL FROM users`
+
 func GetUserByUsername(username string) (models.User, error) {
-	var user models.User
-	var id int
+	return scanUser(db.QueryRow(selectUserColumns+" WHERE username = ?", username))
+}
+
+// ID로 사용자 조회, 없으면 sql.ErrNoRows
+func GetUserByID(id int) (models.User, error) {
+	return scanUser(db.QueryRow(selectUserColumns+" WHERE id = ?", id))
+}
+
+// 사용자 목록 조회 조건, nil 또는 빈 값인 조건은 적용하지 않음
+type UserFilter struct {
+	OrgID   *int
+	GroupID *int
+	Role    string
+}
 
-	row := db.QueryRow("SELECT id, username, password_hash, name, age, gender FROM users WHERE username = ?", username)
+// 조건에 맞는 사용자 목록을 사용자명 순으로 조회
+func GetUsers(filter UserFilter) ([]models.User, error) {
+	rows, err := db.Query(selectUserColumns+`
+		WHERE (? IS NULL OR org_id = ?) AND (? IS NULL OR group_id = ?) AND (? = '' OR role = ?)
+		ORDER BY username
+	`, filter.OrgID, filter.OrgID, filter.GroupID, filter.GroupID, filter.Role, filter.Role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
 
-	var nullAge sql.NullInt64
-	var nullName, nullGender sql.NullString
+func scanUser(row rowScanner) (models.User, error) {
+	var user models.User
+	var nullAge, nullOrgID, nullGroupID sql.NullInt64
+	var nullName, nullGender, nullOccupation, nullBank, nullRegion, nullSharedFields sql.NullString
+	var nullHasChildren sql.NullBool
 
 	if err := row.Scan(
-		&id, &user.Username,
+		&user.ID, &user.Username,
 		&user.PasswordHash,
+		&user.Role,
+		&nullOrgID,
+		&nullGroupID,
 		&nullName,
 		&nullAge,
 		&nullGender,
+		&nullOccupation,
+		&nullBank,
+		&nullRegion,
+		&nullHasChildren,
+		&nullSharedFields,
+		&user.MFAEnabled,
 	); err != nil {
-		if err == sql.ErrNoRows {
-			return user, err // no selected user
-		}
-		return user, err // unexpected error
+		return user, err
 	}
 
+	user.OrgID = nullIntPtr(nullOrgID)
+	user.GroupID = nullIntPtr(nullGroupID)
 	if nullName.Valid {
 		user.Profile.Name = nullName.String
 	}
@@ -61,10 +119,81 @@ func GetUserByUsername(username string) (models.User, error) {
 	if nullGender.Valid {
 		user.Profile.Gender = nullGender.String
 	}
+	user.Profile.Occupation = nullOccupation.String
+	user.Profile.Bank = nullBank.String
+	user.Profile.Region = nullRegion.String
+	if nullHasChildren.Valid {
+		user.Profile.HasChildren = &nullHasChildren.Bool
+	}
+	// 공유 항목을 지정한 적 없는 사용자는 기본 항목 공유
+	if nullSharedFields.Valid {
+		user.LLMSharedFields = decodeStringList(nullSharedFields.String)
+	} else {
+		user.LLMSharedFields = slices.Clone(models.DefaultLLMSharedFields)
+	}
 
 	return user, nil
 }
 
+// 사용자 프로필과 LLM 공유 항목 변경, 해당 사용자가 없으면 sql.ErrNoRows
+func UpdateUserProfile(userID int, profile models.UserProfile, sharedFields []string) error {
+	result, err := db.Exec(
+		`UPDATE users SET name = ?, age = ?, gender = ?, occupation = ?, bank = ?, region = ?, has_children = ?, llm_shared_fields = ?
+		WHERE id = ?`,
+		profile.Name, profile.Age, profile.Gender, profile.Occupation, profile.Bank, profile.Region, profile.HasChildren,
+		encodeLLMSharedFields(sharedFields), userID,
+	)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 공유 항목이 nil이면 NULL로 저장 (기본 항목 공유), 빈 목록은 공유하지 않음
+func encodeLLMSharedFields(fields []string) any {
+	if fields == nil {
+		return nil
+	}
+	return encodeStringList(fields)
+}
+
+// 사용자 역할 변경, 해당 사용자가 없으면 sql.ErrNoRows
+func UpdateUserRole(userID int, role string) error {
+	result, err := db.Exec("UPDATE users SET role = ? WHERE id = ?", role, userID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 사용자 그룹 지정, groupID가 nil이면 그룹에서 제외
+func UpdateUserGroup(userID int, groupID *int) error {
+	result, err := db.Exec("UPDATE users SET group_id = ? WHERE id = ?", groupID, userID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// 사용자 조직 변경, 이전 조직의 그룹 배정은 해제됨 (orgID가 nil이면 조직에서 제외)
+func UpdateUserOrganization(userID int, orgID *int) error {
+	result, err := db.Exec("UPDATE users SET org_id = ?, group_id = NULL WHERE id = ?", orgID, userID)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+// ADMIN_USERNAMES에 등록된 기존 사용자를 관리자로 지정 (역할 도입 이전 설정 호환)
+func PromoteAdmins(usernames []string) error {
+	for _, username := range usernames {
+		if _, err := db.Exec("UPDATE users SET role = ? WHERE username = ?", models.RoleAdmin, username); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func GetUserIDByUsername(username string) (int, error) {
 	var id int
 	row := db.QueryRow("SELECT id FROM users WHERE username = ?", username)